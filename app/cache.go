@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/cache"
+	"github.com/redis/go-redis/v9"
+	"os"
+	"time"
+)
+
+const (
+	defaultCacheSize = 10000
+	defaultCacheTTL  = 5 * time.Minute
+)
+
+// NewCache uses Redis at SISKO_REDIS_ADDR when set, so every instance sees
+// the same invalidations, and an in-process LRU of SISKO_CACHE_SIZE entries
+// otherwise. The returned func closes the Redis connections.
+func NewCache() (cache.Cache, func(ctx context.Context) error) {
+	addr := os.Getenv("SISKO_REDIS_ADDR")
+	if addr == "" {
+		return cache.NewLRUCache(intFromEnv("SISKO_CACHE_SIZE", defaultCacheSize)), func(ctx context.Context) error { return nil }
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: os.Getenv("SISKO_REDIS_PASSWORD"),
+	})
+	return cache.NewRedisCache(client, "sisko:"), func(ctx context.Context) error { return client.Close() }
+}
+
+// NewCacheTTL reads how long a cached siswa may be served from
+// SISKO_CACHE_TTL, defaulting to 5 minutes.
+func NewCacheTTL() time.Duration {
+	return durationFromEnv("SISKO_CACHE_TTL", defaultCacheTTL)
+}
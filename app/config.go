@@ -0,0 +1,153 @@
+package app
+
+import (
+	"github.com/Arraf18/go-sisko/middleware"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTrashRetention  = 30 * 24 * time.Hour
+	defaultIdempotencyTTL  = 24 * time.Hour
+	defaultShutdownTimeout = 30 * time.Second
+	defaultAuthMaxFailures = 5
+	defaultAuthWindow      = 15 * time.Minute
+	defaultAuthLockout     = 15 * time.Minute
+	defaultMaxBodySize     = 1 << 20
+	defaultCorsMaxAge      = 10 * time.Minute
+	defaultCompressionSize = 1024
+	defaultBootstrapApiKey = "RAHASIA"
+	defaultMaxComplexity   = 1000
+)
+
+var defaultV1Sunset = time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+// NewTrashRetention reads how long soft deleted siswa stay restorable from
+// SISKO_TRASH_RETENTION, defaulting to 30 days.
+func NewTrashRetention() time.Duration {
+	return durationFromEnv("SISKO_TRASH_RETENTION", defaultTrashRetention)
+}
+
+// NewIdempotencyTTL reads how long an Idempotency-Key is remembered from
+// SISKO_IDEMPOTENCY_TTL, defaulting to 24 hours.
+func NewIdempotencyTTL() time.Duration {
+	return durationFromEnv("SISKO_IDEMPOTENCY_TTL", defaultIdempotencyTTL)
+}
+
+// NewV1Sunset reads when /api/v1 will be removed from SISKO_V1_SUNSET
+// (2006-01-02), announced to clients in the Sunset header.
+func NewV1Sunset() time.Time {
+	sunset, err := time.Parse("2006-01-02", os.Getenv("SISKO_V1_SUNSET"))
+	if err != nil {
+		return defaultV1Sunset
+	}
+	return sunset
+}
+
+// NewShutdownTimeout reads how long in-flight requests may take to finish
+// after SIGINT/SIGTERM from SISKO_SHUTDOWN_TIMEOUT, defaulting to 30 seconds.
+func NewShutdownTimeout() time.Duration {
+	return durationFromEnv("SISKO_SHUTDOWN_TIMEOUT", defaultShutdownTimeout)
+}
+
+// NewAuthLockout locks a client out for SISKO_AUTH_LOCKOUT after
+// SISKO_AUTH_MAX_FAILURES wrong API keys within SISKO_AUTH_WINDOW, defaulting
+// to 5 failures in 15 minutes and a 15 minute lockout.
+func NewAuthLockout() *middleware.AuthLockout {
+	return middleware.NewAuthLockout(
+		intFromEnv("SISKO_AUTH_MAX_FAILURES", defaultAuthMaxFailures),
+		durationFromEnv("SISKO_AUTH_WINDOW", defaultAuthWindow),
+		durationFromEnv("SISKO_AUTH_LOCKOUT", defaultAuthLockout),
+	)
+}
+
+// NewCorsConfig allows the origins in SISKO_CORS_ORIGINS (comma separated,
+// none by default) to call the API. SISKO_CORS_CREDENTIALS=true lets them
+// send cookies and SISKO_CORS_MAX_AGE controls how long browsers cache a
+// preflight, defaulting to 10 minutes.
+func NewCorsConfig() middleware.CorsConfig {
+	allowCredentials, _ := strconv.ParseBool(os.Getenv("SISKO_CORS_CREDENTIALS"))
+	return middleware.CorsConfig{
+		AllowedOrigins:   listFromEnv("SISKO_CORS_ORIGINS"),
+		AllowedMethods:   []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+		AllowedHeaders:   []string{"Content-Type", "X-API-Key", "If-Match", "If-None-Match", "Idempotency-Key", "X-Request-ID", "traceparent", "tracestate"},
+		ExposedHeaders:   []string{"ETag", "Location", "X-Request-ID", "Idempotent-Replayed", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", "Deprecation", "Sunset", "Link"},
+		AllowCredentials: allowCredentials,
+		MaxAge:           durationFromEnv("SISKO_CORS_MAX_AGE", defaultCorsMaxAge),
+	}
+}
+
+// NewMaxBodySize reads the largest accepted request body in bytes from
+// SISKO_MAX_BODY_SIZE, defaulting to 1 MiB.
+func NewMaxBodySize() int64 {
+	return int64(intFromEnv("SISKO_MAX_BODY_SIZE", defaultMaxBodySize))
+}
+
+// NewCompressionMinSize reads from SISKO_COMPRESSION_MIN_SIZE how many bytes
+// a response needs before it is compressed, defaulting to 1 KiB.
+func NewCompressionMinSize() int {
+	return intFromEnv("SISKO_COMPRESSION_MIN_SIZE", defaultCompressionSize)
+}
+
+// NewGraphqlMaxComplexity reads from SISKO_GRAPHQL_MAX_COMPLEXITY the most a
+// GraphQL query may cost, see graph.Complexity, defaulting to 1000.
+func NewGraphqlMaxComplexity() int {
+	return intFromEnv("SISKO_GRAPHQL_MAX_COMPLEXITY", defaultMaxComplexity)
+}
+
+// NewBootstrapApiKey reads from SISKO_API_KEY the key that authenticates as
+// admin without a user in the database, defaulting to RAHASIA as before users
+// existed. Set it to an empty value to only accept keys made with sisko.
+func NewBootstrapApiKey() string {
+	key, ok := os.LookupEnv("SISKO_API_KEY")
+	if !ok {
+		return defaultBootstrapApiKey
+	}
+	return key
+}
+
+// NewMigrationCheck reads SISKO_READINESS_MIGRATIONS, which turns off
+// comparing schema_migrations with the embedded migrations in /readyz when
+// false. Keep it on when the schema is applied with sisko migrate.
+func NewMigrationCheck() bool {
+	check, err := strconv.ParseBool(os.Getenv("SISKO_READINESS_MIGRATIONS"))
+	return err != nil || check
+}
+
+func stringFromEnv(name string, fallback string) string {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// durationFromEnv parses a Go duration such as "720h" from the environment.
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(name))
+	if err != nil || duration <= 0 {
+		return fallback
+	}
+	return duration
+}
+
+func listFromEnv(name string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func intFromEnv(name string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
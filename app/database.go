@@ -6,8 +6,13 @@ import (
 	"time"
 )
 
+// NewDataSourceName is the MySQL DSN of the sisko database.
+func NewDataSourceName() string {
+	return "root@tcp(localhost:3306)/go_sisko?parseTime=true"
+}
+
 func NewDB() *sql.DB {
-	db, err := sql.Open("mysql", "root@tcp(localhost:3306)/go_sisko")
+	db, err := sql.Open("mysql", NewDataSourceName())
 	helper.PanicIfError(err)
 
 	db.SetMaxIdleConns(5)
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"github.com/Arraf18/go-sisko/controller"
+	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/Arraf18/go-sisko/pb"
+	"google.golang.org/grpc"
+)
+
+// NewGrpcServer serves pb.SiswaService behind interceptor.
+func NewGrpcServer(siswaServer *controller.SiswaGrpcServer, interceptor *middleware.GrpcInterceptor) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.Unary),
+		grpc.StreamInterceptor(interceptor.Stream),
+	)
+	pb.RegisterSiswaServiceServer(server, siswaServer)
+	return server
+}
+
+// NewGrpcAddr is where the gRPC server listens, SISKO_GRPC_ADDR.
+func NewGrpcAddr() string {
+	return stringFromEnv("SISKO_GRPC_ADDR", "localhost:3001")
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/job"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/outbox"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	defaultJobWorkers   = 4
+	defaultJobRetention = 30 * 24 * time.Hour
+	purgeAttempts       = 3
+)
+
+// NewJobRunner registers the background jobs on a runner with
+// SISKO_JOB_WORKERS workers, defaulting to 4. Expired data is purged every
+// hour, the trash of every school; finished jobs are kept for
+// SISKO_JOB_RETENTION, defaulting to 30 days.
+func NewJobRunner(db *sql.DB, siswaService service.SiswaService, sekolahService service.SekolahService, idempotencyService service.IdempotencyService, jobService service.JobService, outboxRelay *outbox.Relay) *job.Runner {
+	runner := job.NewRunner(db, repository.NewJobRepository(), intFromEnv("SISKO_JOB_WORKERS", defaultJobWorkers))
+	trashRetention := NewTrashRetention()
+	jobRetention := durationFromEnv("SISKO_JOB_RETENTION", defaultJobRetention)
+
+	runner.Register("purge_trash", purgeAttempts, purgeJob("siswa", func(ctx context.Context) int {
+		purged := 0
+		sekolahService.ForEach(ctx, func(ctx context.Context, sekolah domain.Sekolah) {
+			purged += siswaService.Purge(ctx, trashRetention)
+		})
+		return purged
+	}))
+	runner.Register("purge_idempotency_keys", purgeAttempts, purgeJob("idempotency_key", idempotencyService.PurgeExpired))
+	runner.Register("purge_outbox_events", purgeAttempts, purgeJob("outbox_event", outboxRelay.PurgePublished))
+	runner.Register("purge_jobs", purgeAttempts, purgeJob("job", func(ctx context.Context) int {
+		return jobService.PurgeFinished(ctx, jobRetention)
+	}))
+
+	runner.Schedule("purge_trash", "@hourly")
+	runner.Schedule("purge_idempotency_keys", "@hourly")
+	runner.Schedule("purge_outbox_events", "@hourly")
+	runner.Schedule("purge_jobs", "30 3 * * *")
+	return runner
+}
+
+func purgeJob(table string, fn func(ctx context.Context) int) job.Handler {
+	return func(ctx context.Context, payload []byte) error {
+		logrus.WithFields(logrus.Fields{"table": table, "purged": fn(ctx)}).Info("purge completed")
+		return nil
+	}
+}
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"github.com/sirupsen/logrus"
+	"os"
+)
+
+// NewLogger configures the standard logrus logger to write JSON, so that
+// loggers taken from a request context share the same output and level.
+func NewLogger() *logrus.Logger {
+	logger := logrus.StandardLogger()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.SetOutput(os.Stdout)
+
+	level, err := logrus.ParseLevel(os.Getenv("SISKO_LOG_LEVEL"))
+	if err != nil {
+		level = logrus.InfoLevel
+	}
+	logger.SetLevel(level)
+
+	return logger
+}
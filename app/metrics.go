@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/sirupsen/logrus"
+)
+
+func NewMetricsRegistry(db *sql.DB, siswaService service.SiswaService, sekolahService service.SekolahService) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, "go_sisko"),
+		newSiswaCollector(siswaService, sekolahService),
+	)
+	return registry
+}
+
+// siswaCollector reads the business gauges from the database on every
+// scrape instead of keeping counters in sync with each write, per school.
+type siswaCollector struct {
+	SiswaService   service.SiswaService
+	SekolahService service.SekolahService
+	Active         *prometheus.Desc
+	Trash          *prometheus.Desc
+}
+
+func newSiswaCollector(siswaService service.SiswaService, sekolahService service.SekolahService) *siswaCollector {
+	return &siswaCollector{
+		SiswaService:   siswaService,
+		SekolahService: sekolahService,
+		Active: prometheus.NewDesc("sisko_siswa_active", "Number of siswa that are not deleted, by sekolah and jenis kelamin.",
+			[]string{"sekolah", "jenis_kelamin"}, nil),
+		Trash: prometheus.NewDesc("sisko_siswa_trash", "Number of soft deleted siswa waiting to be purged, by sekolah.",
+			[]string{"sekolah"}, nil),
+	}
+}
+
+func (collector *siswaCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- collector.Active
+	descs <- collector.Trash
+}
+
+func (collector *siswaCollector) Collect(metrics chan<- prometheus.Metric) {
+	defer func() {
+		if err := recover(); err != nil {
+			logrus.WithField("error", err).Error("collecting siswa metrics failed")
+		}
+	}()
+
+	collector.SekolahService.ForEach(context.Background(), func(ctx context.Context, sekolah domain.Sekolah) {
+		statistics := collector.SiswaService.Statistics(ctx)
+		for jenisKelamin, count := range statistics.ActiveByJenisKelamin {
+			metrics <- prometheus.MustNewConstMetric(collector.Active, prometheus.GaugeValue, float64(count), sekolah.Kode, jenisKelamin)
+		}
+		metrics <- prometheus.MustNewConstMetric(collector.Trash, prometheus.GaugeValue, float64(statistics.Trash), sekolah.Kode)
+	})
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/Arraf18/go-sisko/job"
+	"github.com/Arraf18/go-sisko/notification"
+	"github.com/Arraf18/go-sisko/service"
+	"os"
+	"strconv"
+)
+
+const notificationAttempts = 5
+
+// NewNotificationProviders configures a provider for every channel with
+// settings: SISKO_SMTP_ADDR for email, SISKO_SMS_URL for SMS and
+// SISKO_WHATSAPP_PHONE_NUMBER_ID for WhatsApp. With
+// SISKO_NOTIFICATION_FAKE=true every channel is faked instead, so nothing
+// leaves the server.
+func NewNotificationProviders() []notification.Provider {
+	fake, _ := strconv.ParseBool(os.Getenv("SISKO_NOTIFICATION_FAKE"))
+	if fake {
+		return []notification.Provider{
+			notification.NewFakeProvider(notification.ChannelEmail),
+			notification.NewFakeProvider(notification.ChannelSms),
+			notification.NewFakeProvider(notification.ChannelWhatsapp),
+		}
+	}
+
+	var providers []notification.Provider
+	if addr := os.Getenv("SISKO_SMTP_ADDR"); addr != "" {
+		providers = append(providers, notification.NewSmtpProvider(addr, os.Getenv("SISKO_SMTP_USERNAME"), os.Getenv("SISKO_SMTP_PASSWORD"), os.Getenv("SISKO_SMTP_FROM")))
+	}
+	if url := os.Getenv("SISKO_SMS_URL"); url != "" {
+		providers = append(providers, notification.NewSmsProvider(url, os.Getenv("SISKO_SMS_API_KEY"), os.Getenv("SISKO_SMS_SENDER")))
+	}
+	if phoneNumberId := os.Getenv("SISKO_WHATSAPP_PHONE_NUMBER_ID"); phoneNumberId != "" {
+		providers = append(providers, notification.NewWhatsappProvider(os.Getenv("SISKO_WHATSAPP_URL"), phoneNumberId, os.Getenv("SISKO_WHATSAPP_TOKEN")))
+	}
+	return providers
+}
+
+// RegisterNotificationJobs lets the runner deliver queued notifications. A
+// notification none of whose channels took it is tried again with backoff.
+func RegisterNotificationJobs(runner *job.Runner, notificationService service.NotificationService) {
+	runner.Register(service.SendNotificationJob, notificationAttempts, func(ctx context.Context, payload []byte) error {
+		notificationId, err := strconv.ParseInt(string(payload), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid notification id %q", payload)
+		}
+		return notificationService.Deliver(ctx, notificationId)
+	})
+}
@@ -0,0 +1,545 @@
+package app
+
+import (
+	_ "embed"
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/controller"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/ppdb"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// apiOperation documents one route. Request and Response are zero values of
+// the web structs, whose json and validate tags become the schemas. Tenant
+// marks a Public route that is still scoped to a school, and Files the
+// media types of a file sent instead of JSON: the request body, or the
+// response of a GET.
+type apiOperation struct {
+	Method     string
+	Path       string
+	Tag        string
+	Summary    string
+	Query      []string
+	Headers    []string
+	Request    interface{}
+	Response   interface{}
+	List       bool
+	Status     int
+	Errors     []int
+	Public     bool
+	Tenant     bool
+	MediaType  string
+	Files      []string
+	Problem    bool
+	Deprecated bool
+}
+
+var apiOperations = concatOperations(
+	siswaOperations("/api", false),
+	siswaOperations("/api/v1", false),
+	siswaOperations("/api/v2", true),
+	webhookOperations("/api/v2"),
+	jobOperations("/api/v2"),
+	notificationOperations("/api/v2"),
+	sekolahOperations("/api/v2"),
+	pendaftarOperations("/api/v2"),
+	ppdbOperations(),
+	[]apiOperation{
+		{Method: "GET", Path: "/healthz", Tag: "health", Summary: "Liveness probe", Public: true},
+		{Method: "GET", Path: "/readyz", Tag: "health", Summary: "Readiness probe", Response: web.HealthResponse{}, Errors: []int{503}, Public: true},
+		{Method: "GET", Path: "/metrics", Tag: "health", Summary: "Prometheus metrics", Public: true, MediaType: "text/plain"},
+		{Method: "GET", Path: "/api/openapi.json", Tag: "docs", Summary: "This OpenAPI document", Public: true, MediaType: "application/json"},
+		{Method: "GET", Path: "/api/docs", Tag: "docs", Summary: "Swagger UI", Public: true, MediaType: "text/html"},
+	},
+)
+
+// siswaOperations documents the routes registered by siswaRoutes under
+// prefix. Version 2 uses SiswaResponseV2, 201/204 statuses and problem+json
+// errors; the other prefixes are deprecated.
+func siswaOperations(prefix string, v2 bool) []apiOperation {
+	var siswa, batch interface{} = web.SiswaResponse{}, web.SiswaBatchResponse{}
+	createStatus, deleteStatus := 0, 0
+	if v2 {
+		siswa, batch = web.SiswaResponseV2{}, web.SiswaBatchResponseV2{}
+		createStatus, deleteStatus = http.StatusCreated, http.StatusNoContent
+	}
+
+	operations := []apiOperation{
+		{Method: "GET", Path: prefix + "/siswas", Tag: "siswa", Summary: "List siswa", Response: siswa, List: true},
+		{Method: "GET", Path: prefix + "/siswas/trash", Tag: "siswa", Summary: "List soft deleted siswa", Response: siswa, List: true},
+		{Method: "GET", Path: prefix + "/siswas/{siswaId}", Tag: "siswa", Summary: "Find siswa by id", Headers: []string{"If-None-Match"}, Response: siswa, Errors: []int{304, 400, 404}},
+		{Method: "POST", Path: prefix + "/siswas", Tag: "siswa", Summary: "Create siswa", Headers: []string{"Idempotency-Key"}, Request: web.SiswaCreateRequest{}, Response: siswa, Status: createStatus, Errors: []int{400, 409, 422}},
+		{Method: "POST", Path: prefix + "/siswas/batch", Tag: "siswa", Summary: "Create, update and delete siswa in one transaction", Headers: []string{"Idempotency-Key"}, Request: web.SiswaBatchRequest{}, Response: batch, Errors: []int{400, 422}},
+		{Method: "PUT", Path: prefix + "/siswas/{siswaId}", Tag: "siswa", Summary: "Update siswa", Headers: []string{"If-Match"}, Request: web.SiswaUpdateRequest{}, Response: siswa, Errors: []int{400, 404, 412, 428}},
+		{Method: "PATCH", Path: prefix + "/siswas/{siswaId}", Tag: "siswa", Summary: "Change some fields of siswa", Headers: []string{"If-Match"}, Request: web.SiswaPatchRequest{}, Response: siswa, Errors: []int{400, 404, 412, 428}},
+		{Method: "DELETE", Path: prefix + "/siswas/{siswaId}", Tag: "siswa", Summary: "Move siswa to the trash", Headers: []string{"If-Match"}, Status: deleteStatus, Errors: []int{400, 404, 412, 428}},
+		{Method: "POST", Path: prefix + "/siswas/{siswaId}/restore", Tag: "siswa", Summary: "Restore siswa from the trash", Headers: []string{"Idempotency-Key"}, Response: siswa, Errors: []int{400, 404}},
+		{Method: "GET", Path: prefix + "/siswas/{siswaId}/history", Tag: "audit", Summary: "List changes of a siswa", Response: web.AuditResponse{}, List: true, Errors: []int{400}},
+		{Method: "GET", Path: prefix + "/audits", Tag: "audit", Summary: "Search audit trail", Query: []string{"actor", "entity", "entity_id", "from", "to"}, Response: web.AuditResponse{}, List: true, Errors: []int{400}},
+	}
+	for i := range operations {
+		operations[i].Errors = append(operations[i].Errors, http.StatusTooManyRequests)
+		if operations[i].Request != nil {
+			operations[i].Errors = append(operations[i].Errors, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType)
+		}
+		operations[i].Problem = v2
+		operations[i].Deprecated = !v2
+	}
+	return operations
+}
+
+// webhookOperations documents the routes registered by webhookRoutes.
+func webhookOperations(prefix string) []apiOperation {
+	operations := []apiOperation{
+		{Method: "GET", Path: prefix + "/webhooks", Tag: "webhook", Summary: "List webhook subscriptions", Response: web.WebhookResponse{}, List: true},
+		{Method: "GET", Path: prefix + "/webhooks/{webhookId}", Tag: "webhook", Summary: "Find webhook subscription by id", Response: web.WebhookResponse{}, Errors: []int{400, 404}},
+		{Method: "POST", Path: prefix + "/webhooks", Tag: "webhook", Summary: "Subscribe a URL to siswa events", Headers: []string{"Idempotency-Key"}, Request: web.WebhookCreateRequest{}, Response: web.WebhookCreateResponse{}, Status: http.StatusCreated, Errors: []int{400}},
+		{Method: "PUT", Path: prefix + "/webhooks/{webhookId}", Tag: "webhook", Summary: "Update or re-enable webhook subscription", Request: web.WebhookUpdateRequest{}, Response: web.WebhookResponse{}, Errors: []int{400, 404}},
+		{Method: "DELETE", Path: prefix + "/webhooks/{webhookId}", Tag: "webhook", Summary: "Delete webhook subscription and its deliveries", Status: http.StatusNoContent, Errors: []int{400, 404}},
+		{Method: "GET", Path: prefix + "/webhooks/{webhookId}/deliveries", Tag: "webhook", Summary: "List the latest deliveries of a webhook", Response: web.WebhookDeliveryResponse{}, List: true, Errors: []int{400, 404}},
+		{Method: "POST", Path: prefix + "/webhooks/{webhookId}/deliveries/{deliveryId}/replay", Tag: "webhook", Summary: "Send a delivery again", Headers: []string{"Idempotency-Key"}, Response: web.WebhookDeliveryResponse{}, Status: http.StatusAccepted, Errors: []int{400, 404, 409}},
+	}
+	for i := range operations {
+		operations[i].Errors = append(operations[i].Errors, http.StatusTooManyRequests)
+		if operations[i].Request != nil {
+			operations[i].Errors = append(operations[i].Errors, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType)
+		}
+		operations[i].Problem = true
+	}
+	return operations
+}
+
+// jobOperations documents the routes registered by jobRoutes.
+func jobOperations(prefix string) []apiOperation {
+	operations := []apiOperation{
+		{Method: "GET", Path: prefix + "/jobs", Tag: "job", Summary: "List the latest background jobs", Query: []string{"name", "status"}, Response: web.JobResponse{}, List: true, Errors: []int{400}},
+		{Method: "GET", Path: prefix + "/jobs/{jobId}", Tag: "job", Summary: "Find background job by id", Response: web.JobResponse{}, Errors: []int{400, 404}},
+		{Method: "POST", Path: prefix + "/jobs/{jobId}/retry", Tag: "job", Summary: "Retry a failed background job", Headers: []string{"Idempotency-Key"}, Response: web.JobResponse{}, Status: http.StatusAccepted, Errors: []int{400, 404, 409}},
+	}
+	for i := range operations {
+		operations[i].Errors = append(operations[i].Errors, http.StatusTooManyRequests)
+		operations[i].Problem = true
+	}
+	return operations
+}
+
+// notificationOperations documents the routes registered by
+// notificationRoutes.
+func notificationOperations(prefix string) []apiOperation {
+	operations := []apiOperation{
+		{Method: "GET", Path: prefix + "/notifications", Tag: "notification", Summary: "List the latest notifications", Query: []string{"siswa_id", "status"}, Response: web.NotificationResponse{}, List: true, Errors: []int{400}},
+		{Method: "POST", Path: prefix + "/notifications", Tag: "notification", Summary: "Notify the parents of siswa", Headers: []string{"Idempotency-Key"}, Request: web.NotificationSendRequest{}, Response: web.NotificationResponse{}, List: true, Status: http.StatusAccepted, Errors: []int{400, 404}},
+		{Method: "GET", Path: prefix + "/siswas/{siswaId}/notification-preference", Tag: "notification", Summary: "Find how the parent of a siswa is notified", Response: web.NotificationPreferenceResponse{}, Errors: []int{400, 404}},
+		{Method: "PUT", Path: prefix + "/siswas/{siswaId}/notification-preference", Tag: "notification", Summary: "Set how the parent of a siswa is notified", Request: web.NotificationPreferenceRequest{}, Response: web.NotificationPreferenceResponse{}, Errors: []int{400, 404}},
+	}
+	for i := range operations {
+		operations[i].Errors = append(operations[i].Errors, http.StatusTooManyRequests)
+		if operations[i].Request != nil {
+			operations[i].Errors = append(operations[i].Errors, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType)
+		}
+		operations[i].Problem = true
+	}
+	return operations
+}
+
+// sekolahOperations documents the routes registered by sekolahRoutes. All
+// but the current school are refused to users of one school.
+func sekolahOperations(prefix string) []apiOperation {
+	operations := []apiOperation{
+		{Method: "GET", Path: prefix + "/sekolah", Tag: "sekolah", Summary: "Find the school the request works on", Response: web.SekolahResponse{}, Errors: []int{404}},
+		{Method: "GET", Path: prefix + "/sekolahs", Tag: "sekolah", Summary: "List the schools of the yayasan", Response: web.SekolahResponse{}, List: true},
+		{Method: "GET", Path: prefix + "/sekolahs/{sekolahId}", Tag: "sekolah", Summary: "Find school by id", Response: web.SekolahResponse{}, Errors: []int{400, 404}},
+		{Method: "POST", Path: prefix + "/sekolahs", Tag: "sekolah", Summary: "Add a school", Headers: []string{"Idempotency-Key"}, Request: web.SekolahCreateRequest{}, Response: web.SekolahResponse{}, Status: http.StatusCreated, Errors: []int{400, 409}},
+		{Method: "PUT", Path: prefix + "/sekolahs/{sekolahId}", Tag: "sekolah", Summary: "Update the name and settings of a school", Request: web.SekolahUpdateRequest{}, Response: web.SekolahResponse{}, Errors: []int{400, 404}},
+	}
+	for i := range operations {
+		operations[i].Errors = append(operations[i].Errors, http.StatusTooManyRequests)
+		if operations[i].Request != nil {
+			operations[i].Errors = append(operations[i].Errors, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType)
+		}
+		operations[i].Problem = true
+	}
+	return operations
+}
+
+// pendaftarOperations documents the routes registered by pendaftarRoutes.
+func pendaftarOperations(prefix string) []apiOperation {
+	pendaftar := web.PendaftarResponse{}
+	operations := []apiOperation{
+		{Method: "GET", Path: prefix + "/pendaftars", Tag: "ppdb", Summary: "List the pendaftar of the school", Query: []string{"jalur", "status"}, Response: pendaftar, List: true, Errors: []int{400}},
+		{Method: "GET", Path: prefix + "/pendaftars/{pendaftarId}", Tag: "ppdb", Summary: "Find pendaftar by id", Response: pendaftar, Errors: []int{400, 404}},
+		{Method: "GET", Path: prefix + "/pendaftars/{pendaftarId}/dokumen/{jenis}", Tag: "ppdb", Summary: "Download a document of a pendaftar", Files: ppdb.DokumenContentTypes, Errors: []int{400, 404}},
+		{Method: "POST", Path: prefix + "/pendaftars/{pendaftarId}/verify", Tag: "ppdb", Summary: "Confirm the documents of a pendaftar", Headers: []string{"Idempotency-Key"}, Response: pendaftar, Errors: []int{400, 404, 409, 422}},
+		{Method: "POST", Path: prefix + "/pendaftars/{pendaftarId}/score", Tag: "ppdb", Summary: "Record the test score of a pendaftar", Headers: []string{"Idempotency-Key"}, Request: web.PendaftarScoreRequest{}, Response: pendaftar, Errors: []int{400, 404, 409}},
+		{Method: "POST", Path: prefix + "/pendaftars/{pendaftarId}/reject", Tag: "ppdb", Summary: "Turn a pendaftar down before selection", Headers: []string{"Idempotency-Key"}, Request: web.PendaftarRejectRequest{}, Response: pendaftar, Errors: []int{400, 404, 409}},
+		{Method: "POST", Path: prefix + "/pendaftars/{pendaftarId}/re-register", Tag: "ppdb", Summary: "Enrol an accepted pendaftar as siswa", Headers: []string{"Idempotency-Key"}, Response: pendaftar, Errors: []int{400, 404, 409, 422}},
+		{Method: "POST", Path: prefix + "/pendaftars/select", Tag: "ppdb", Summary: "Accept the best tested pendaftar of each jalur within its kuota and the kuota of siswa", Headers: []string{"Idempotency-Key"}, Response: pendaftar, List: true, Errors: []int{409}},
+		{Method: "POST", Path: prefix + "/pendaftars/announce", Tag: "ppdb", Summary: "Announce the results of the selection", Headers: []string{"Idempotency-Key"}, Response: pendaftar, List: true},
+	}
+	for i := range operations {
+		operations[i].Errors = append(operations[i].Errors, http.StatusTooManyRequests)
+		if operations[i].Request != nil {
+			operations[i].Errors = append(operations[i].Errors, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType)
+		}
+		operations[i].Problem = true
+	}
+	return operations
+}
+
+// ppdbOperations documents the routes registered by ppdbRoutes, which need
+// no API key but the X-Ppdb-Token of a pendaftar.
+func ppdbOperations() []apiOperation {
+	operations := []apiOperation{
+		{Method: "POST", Path: "/api/v2/ppdb/pendaftars", Tag: "ppdb", Summary: "Register for admission", Request: web.PendaftarCreateRequest{}, Response: web.PendaftarRegisterResponse{}, Status: http.StatusCreated, Errors: []int{400, 413, 415}},
+		{Method: "GET", Path: "/api/v2/ppdb/pendaftars/{pendaftarId}", Tag: "ppdb", Summary: "Find your registration", Headers: []string{controller.PendaftarTokenHeader}, Response: web.PendaftarResponse{}, Errors: []int{400, 404}},
+		{Method: "PUT", Path: "/api/v2/ppdb/pendaftars/{pendaftarId}/dokumen/{jenis}", Tag: "ppdb", Summary: "Upload a document of your registration", Headers: []string{controller.PendaftarTokenHeader}, Files: ppdb.DokumenContentTypes, Response: web.DokumenResponse{}, Errors: []int{400, 404, 409, 413, 415}},
+		{Method: "GET", Path: "/api/v2/ppdb/pengumuman", Tag: "ppdb", Summary: "List the admitted pendaftar", Response: web.PengumumanResponse{}, List: true},
+	}
+	for i := range operations {
+		operations[i].Errors = append(operations[i].Errors, http.StatusTooManyRequests)
+		operations[i].Public = true
+		operations[i].Tenant = true
+		operations[i].Problem = true
+	}
+	return operations
+}
+
+func concatOperations(groups ...[]apiOperation) []apiOperation {
+	var operations []apiOperation
+	for _, group := range groups {
+		operations = append(operations, group...)
+	}
+	return operations
+}
+
+var openAPIDocument = newOpenAPIDocument()
+
+//go:embed swagger_ui.html
+var swaggerUI []byte
+
+func OpenAPIHandle(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Write(openAPIDocument)
+}
+
+func SwaggerUIHandle(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	writer.Write(swaggerUI)
+}
+
+func newOpenAPIDocument() []byte {
+	schemas := map[string]interface{}{
+		"WebResponse": map[string]interface{}{
+			"type":     "object",
+			"required": []string{"code", "status"},
+			"properties": map[string]interface{}{
+				"code":   map[string]interface{}{"type": "integer"},
+				"status": map[string]interface{}{"type": "string"},
+				"data":   map[string]interface{}{},
+			},
+		},
+	}
+	schemaRef(reflect.TypeOf(web.ProblemResponse{}), schemas)
+
+	paths := map[string]map[string]interface{}{}
+	for _, operation := range apiOperations {
+		if paths[operation.Path] == nil {
+			paths[operation.Path] = map[string]interface{}{}
+		}
+		paths[operation.Path][strings.ToLower(operation.Method)] = openAPIOperation(operation, schemas)
+	}
+
+	document := map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":   "go-sisko API",
+			"version": "1.0.0",
+		},
+		"security": []interface{}{map[string]interface{}{"ApiKeyAuth": []string{}}},
+		"paths":    paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"ApiKeyAuth": map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			},
+		},
+	}
+
+	bytes, err := json.MarshalIndent(document, "", "  ")
+	helper.PanicIfError(err)
+	return bytes
+}
+
+func openAPIOperation(operation apiOperation, schemas map[string]interface{}) map[string]interface{} {
+	var parameters []interface{}
+	for _, segment := range strings.Split(operation.Path, "/") {
+		if strings.HasPrefix(segment, "{") {
+			name, typ := strings.Trim(segment, "{}"), "integer"
+			if !strings.HasSuffix(name, "Id") {
+				typ = "string"
+			}
+			parameters = append(parameters, map[string]interface{}{
+				"name": name, "in": "path", "required": true,
+				"schema": map[string]interface{}{"type": typ},
+			})
+		}
+	}
+	for _, query := range operation.Query {
+		parameters = append(parameters, map[string]interface{}{
+			"name": query, "in": "query", "schema": map[string]interface{}{"type": "string"},
+		})
+	}
+	for _, header := range operation.Headers {
+		parameters = append(parameters, map[string]interface{}{
+			"name": header, "in": "header", "schema": map[string]interface{}{"type": "string"},
+		})
+	}
+	// see middleware.TenantMiddleware
+	if !operation.Public || operation.Tenant {
+		parameters = append(parameters, map[string]interface{}{
+			"name": "X-Sekolah", "in": "header", "schema": map[string]interface{}{"type": "string"},
+			"description": "Kode of the school to work on; the subdomain or the default school otherwise",
+		})
+	}
+
+	status := operation.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	responses := map[string]interface{}{
+		strconv.Itoa(status): openAPIResponse(operation, schemas),
+	}
+	if !operation.Public {
+		responses["401"] = errorResponse(http.StatusUnauthorized, operation.Problem)
+		responses["403"] = errorResponse(http.StatusForbidden, operation.Problem)
+	}
+	for _, code := range operation.Errors {
+		responses[strconv.Itoa(code)] = errorResponse(code, operation.Problem)
+	}
+
+	result := map[string]interface{}{
+		"tags":        []string{operation.Tag},
+		"summary":     operation.Summary,
+		"operationId": operationId(operation),
+		"responses":   responses,
+	}
+	if parameters != nil {
+		result["parameters"] = parameters
+	}
+	if operation.Request != nil {
+		result["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": schemaRef(reflect.TypeOf(operation.Request), schemas)},
+			},
+		}
+	}
+	if operation.Files != nil && operation.Method != http.MethodGet {
+		result["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  fileContent(operation.Files),
+		}
+	}
+	if operation.Public {
+		result["security"] = []interface{}{}
+	}
+	if operation.Deprecated {
+		result["deprecated"] = true
+	}
+	return result
+}
+
+// operationId turns "GET /api/siswas/{siswaId}" into "getApiSiswasSiswaId".
+func operationId(operation apiOperation) string {
+	id := strings.ToLower(operation.Method)
+	for _, word := range strings.FieldsFunc(operation.Path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == '.'
+	}) {
+		id += strings.ToUpper(word[:1]) + word[1:]
+	}
+	return id
+}
+
+func openAPIResponse(operation apiOperation, schemas map[string]interface{}) map[string]interface{} {
+	if operation.Status == http.StatusNoContent {
+		return map[string]interface{}{"description": http.StatusText(operation.Status)}
+	}
+	if operation.MediaType != "" {
+		return map[string]interface{}{
+			"description": operation.Summary,
+			"content":     map[string]interface{}{operation.MediaType: map[string]interface{}{}},
+		}
+	}
+	if operation.Files != nil && operation.Method == http.MethodGet {
+		return map[string]interface{}{
+			"description": operation.Summary,
+			"content":     fileContent(operation.Files),
+		}
+	}
+
+	data := map[string]interface{}{}
+	var item map[string]interface{}
+	if operation.Response != nil {
+		data = schemaRef(reflect.TypeOf(operation.Response), schemas)
+		if operation.List {
+			item = data
+			data = map[string]interface{}{"type": []string{"array", "null"}, "items": item}
+		}
+	}
+
+	status := operation.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	envelope := map[string]interface{}{
+		"schema": map[string]interface{}{
+			"allOf": []interface{}{
+				map[string]interface{}{"$ref": "#/components/schemas/WebResponse"},
+				map[string]interface{}{"properties": map[string]interface{}{"data": data}},
+			},
+		},
+	}
+	content := map[string]interface{}{helper.MediaTypeJSON: envelope}
+	// see helper.WriteListToResponseBody
+	if item != nil {
+		content[helper.MediaTypeNDJSON] = map[string]interface{}{"schema": item}
+		content[helper.MediaTypeCSV] = map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}
+		content[helper.MediaTypeMessagePack] = envelope
+	}
+
+	return map[string]interface{}{
+		"description": http.StatusText(status),
+		"content":     content,
+	}
+}
+
+func fileContent(mediaTypes []string) map[string]interface{} {
+	content := map[string]interface{}{}
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = map[string]interface{}{"schema": map[string]interface{}{"type": "string", "format": "binary"}}
+	}
+	return content
+}
+
+func errorResponse(code int, problem bool) map[string]interface{} {
+	if code == http.StatusNotModified {
+		return map[string]interface{}{"description": http.StatusText(code)}
+	}
+	if problem {
+		return map[string]interface{}{
+			"description": http.StatusText(code),
+			"content": map[string]interface{}{
+				"application/problem+json": map[string]interface{}{
+					"schema": map[string]interface{}{"$ref": "#/components/schemas/ProblemResponse"},
+				},
+			},
+		}
+	}
+	return map[string]interface{}{
+		"description": http.StatusText(code),
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"$ref": "#/components/schemas/WebResponse"},
+			},
+		},
+	}
+}
+
+// schemaRef describes typ in components.schemas and returns a reference to
+// it. Only fields with a json tag are part of the wire format; validate tags
+// become required, length and enum constraints.
+func schemaRef(typ reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch {
+	case typ == reflect.TypeOf(time.Time{}):
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+		return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+	case typ.Kind() == reflect.Slice:
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": schemaRef(typ.Elem(), schemas)}
+	case typ.Kind() == reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaRef(typ.Elem(), schemas)}
+	case typ.Kind() == reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case typ.Kind() == reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case typ.Kind() >= reflect.Int && typ.Kind() <= reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case typ.Kind() != reflect.Struct:
+		return map[string]interface{}{}
+	}
+
+	ref := map[string]interface{}{"$ref": "#/components/schemas/" + typ.Name()}
+	if _, ok := schemas[typ.Name()]; ok {
+		return ref
+	}
+	schemas[typ.Name()] = map[string]interface{}{}
+
+	properties := map[string]interface{}{}
+	var required []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		property := schemaRef(field.Type, schemas)
+		if property["$ref"] == nil {
+			// the rules after dive constrain the items of a slice
+			target, dived := property, false
+		rules:
+			for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+				key, value := rule, ""
+				if index := strings.Index(rule, "="); index >= 0 {
+					key, value = rule[:index], rule[index+1:]
+				}
+
+				limit, _ := strconv.Atoi(value)
+				switch {
+				case key == "dive":
+					items, ok := target["items"].(map[string]interface{})
+					if !ok || items["$ref"] != nil {
+						break rules
+					}
+					target, dived = items, true
+				case key == "required" && !dived:
+					required = append(required, name)
+				case key == "oneof":
+					target["enum"] = strings.Split(value, " ")
+				case key == "min" && target["type"] == "string":
+					target["minLength"] = limit
+				case key == "max" && target["type"] == "string":
+					target["maxLength"] = limit
+				case key == "min":
+					target["minItems"] = limit
+				case key == "max":
+					target["maxItems"] = limit
+				}
+			}
+		} else if strings.Contains(field.Tag.Get("validate"), "required") {
+			required = append(required, name)
+		}
+		properties[name] = property
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if required != nil {
+		schema["required"] = required
+	}
+	schemas[typ.Name()] = schema
+	return ref
+}
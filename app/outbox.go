@@ -0,0 +1,57 @@
+package app
+
+import (
+	"github.com/Arraf18/go-sisko/outbox"
+	"os"
+	"time"
+)
+
+const (
+	defaultOutboxTopic       = "sisko.siswa"
+	defaultOutboxInterval    = time.Second
+	defaultOutboxRetention   = 7 * 24 * time.Hour
+	defaultOutboxMaxAttempts = 20
+)
+
+// NewOutboxSinks publishes to the in-process bus, and also to the webhook at
+// SISKO_OUTBOX_WEBHOOK_URL, the NATS server at SISKO_OUTBOX_NATS_URL and the
+// Kafka REST proxy at SISKO_OUTBOX_KAFKA_URL when those are set. NATS and
+// Kafka use SISKO_OUTBOX_TOPIC as subject and topic, defaulting to
+// sisko.siswa. The returned func closes the NATS connection.
+func NewOutboxSinks(bus *outbox.Bus) ([]outbox.Sink, func() error) {
+	sinks := []outbox.Sink{bus}
+	closeSinks := func() error { return nil }
+	topic := stringFromEnv("SISKO_OUTBOX_TOPIC", defaultOutboxTopic)
+
+	if url := os.Getenv("SISKO_OUTBOX_WEBHOOK_URL"); url != "" {
+		sinks = append(sinks, outbox.NewWebhookSink(url))
+	}
+	if url := os.Getenv("SISKO_OUTBOX_NATS_URL"); url != "" {
+		natsSink := outbox.NewNatsSink(url, topic)
+		sinks = append(sinks, natsSink)
+		closeSinks = natsSink.Close
+	}
+	if url := os.Getenv("SISKO_OUTBOX_KAFKA_URL"); url != "" {
+		sinks = append(sinks, outbox.NewKafkaSink(url, topic))
+	}
+	return sinks, closeSinks
+}
+
+// NewOutboxInterval reads how often the relay looks for new events from
+// SISKO_OUTBOX_INTERVAL, defaulting to every second.
+func NewOutboxInterval() time.Duration {
+	return durationFromEnv("SISKO_OUTBOX_INTERVAL", defaultOutboxInterval)
+}
+
+// NewOutboxRetention reads how long published events are kept from
+// SISKO_OUTBOX_RETENTION, defaulting to 7 days.
+func NewOutboxRetention() time.Duration {
+	return durationFromEnv("SISKO_OUTBOX_RETENTION", defaultOutboxRetention)
+}
+
+// NewOutboxMaxAttempts reads how often the relay tries an event before it
+// gives up on it from SISKO_OUTBOX_MAX_ATTEMPTS, defaulting to 20, which
+// with the backoff of the relay is about an hour of retries.
+func NewOutboxMaxAttempts() int {
+	return intFromEnv("SISKO_OUTBOX_MAX_ATTEMPTS", defaultOutboxMaxAttempts)
+}
@@ -3,19 +3,330 @@ package app
 import (
 	"github.com/Arraf18/go-sisko/controller"
 	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/middleware"
 	"github.com/julienschmidt/httprouter"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strings"
+	"time"
 )
 
-func NewRouter(siswaController controller.SiswaController) *httprouter.Router {
-	router := httprouter.New()
+// Per client limits of the API routes. Listing reads every siswa, so it is
+// limited the most; a kiosk polling it once every few seconds still fits.
+var (
+	listRateLimit  = middleware.RateLimit{Requests: 30, Per: time.Minute}
+	readRateLimit  = middleware.RateLimit{Requests: 300, Per: time.Minute}
+	writeRateLimit = middleware.RateLimit{Requests: 60, Per: time.Minute}
+	batchRateLimit = middleware.RateLimit{Requests: 10, Per: time.Minute}
+)
+
+// Cache-Control of the API routes. Kiosks may reuse a siswa for a while and
+// revalidate it with If-None-Match afterwards.
+const (
+	siswaCacheControl        = "private, max-age=30"
+	listCacheControl         = "private, max-age=10"
+	historyCacheControl      = "private, no-cache"
+	writeCacheControl        = "no-store"
+	graphqlCacheControl      = "no-store"
+	webhookCacheControl      = "private, no-cache"
+	jobCacheControl          = "private, no-cache"
+	notificationCacheControl = "private, no-cache"
+	sekolahCacheControl      = "private, no-cache"
+	pendaftarCacheControl    = "private, no-cache"
+	pengumumanCacheControl   = "no-cache"
+	ppdbCacheControl         = "no-store" // pendaftar may use a shared computer
+)
+
+// rateLimiters are shared by every version of the API, so a client cannot
+// double its allowance by switching prefixes.
+type rateLimiters struct {
+	List  *middleware.RateLimiter
+	Read  *middleware.RateLimiter
+	Write *middleware.RateLimiter
+	Batch *middleware.RateLimiter
+}
+
+func newRateLimiters() rateLimiters {
+	return rateLimiters{
+		List:  middleware.NewRateLimiter(listRateLimit),
+		Read:  middleware.NewRateLimiter(readRateLimit),
+		Write: middleware.NewRateLimiter(writeRateLimit),
+		Batch: middleware.NewRateLimiter(batchRateLimit),
+	}
+}
+
+// NewRouter serves the API under /api/v2 and, with deprecation headers, the
+// original shapes under both /api/v1 and the unversioned /api prefix. GraphQL
+// queries go to /graphql. Webhooks, jobs, notifications, schools and
+// admissions only exist in /api/v2.
+func NewRouter(siswaController controller.SiswaController, siswaControllerV2 controller.SiswaController, auditController controller.AuditController, graphqlController controller.GraphqlController, webhookController controller.WebhookController, jobController controller.JobController, notificationController controller.NotificationController, sekolahController controller.SekolahController, pendaftarController controller.PendaftarController) http.Handler {
+	limiters := newRateLimiters()
+
+	graphqlRoutes := &routeRecorder{}
+	// a query may read as much as a list, within its complexity limit
+	graphqlRoutes.POST("/graphql", limiters.List.Handle(middleware.CacheControl(graphqlCacheControl, graphqlController.Query)))
+
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", graphqlRoutes.Build())
+	mux.Handle("/api/v2/", apiRoutesV2(siswaControllerV2, auditController, webhookController, jobController, notificationController, sekolahController, pendaftarController, limiters).Build())
+	mux.Handle("/", middleware.NewDeprecationMiddleware(apiRoutesV1(siswaController, auditController, limiters).Build(), NewV1Sunset(), "/api/v2/siswas"))
+	return mux
+}
+
+// NewPublicRouter serves the endpoints used by the platform itself, which are
+// mounted in front of AuthMiddleware.
+func NewPublicRouter(healthController controller.HealthController, registry *prometheus.Registry) *httprouter.Router {
+	return publicRoutes(healthController, registry).Build()
+}
+
+// NewPpdbRouter serves the admission endpoints prospective students use
+// without an account, which are mounted in front of AuthMiddleware but
+// behind TenantMiddleware. A pendaftar proves who they are with the token
+// they got when registering.
+func NewPpdbRouter(pendaftarController controller.PendaftarController) *httprouter.Router {
+	return ppdbRoutes(pendaftarController, newRateLimiters()).Build()
+}
+
+// ApiRoutes lists every REST route served by NewRouter, NewPpdbRouter and
+// NewPublicRouter as "METHOD /path/{param}", the way they appear in the
+// OpenAPI document.
+func ApiRoutes() []string {
+	limiters := newRateLimiters()
+	routes := apiRoutesV1(&controller.SiswaControllerImpl{}, &controller.AuditControllerImpl{}, limiters).Routes()
+	routes = append(routes, apiRoutesV2(&controller.SiswaControllerV2Impl{}, &controller.AuditControllerImpl{}, &controller.WebhookControllerImpl{}, &controller.JobControllerImpl{}, &controller.NotificationControllerImpl{}, &controller.SekolahControllerImpl{}, &controller.PendaftarControllerImpl{}, limiters).Routes()...)
+	routes = append(routes, ppdbRoutes(&controller.PendaftarControllerImpl{}, limiters).Routes()...)
+	return append(routes, publicRoutes(&controller.HealthControllerImpl{}, prometheus.NewRegistry()).Routes()...)
+}
+
+func apiRoutesV1(siswaController controller.SiswaController, auditController controller.AuditController, limiters rateLimiters) *routeRecorder {
+	router := &routeRecorder{}
+	siswaRoutes(router, "/api", siswaController, auditController, limiters)
+	siswaRoutes(router, "/api/v1", siswaController, auditController, limiters)
+	return router
+}
+
+func apiRoutesV2(siswaController controller.SiswaController, auditController controller.AuditController, webhookController controller.WebhookController, jobController controller.JobController, notificationController controller.NotificationController, sekolahController controller.SekolahController, pendaftarController controller.PendaftarController, limiters rateLimiters) *routeRecorder {
+	router := &routeRecorder{}
+	siswaRoutes(router, "/api/v2", siswaController, auditController, limiters)
+	webhookRoutes(router, "/api/v2", webhookController, limiters)
+	jobRoutes(router, "/api/v2", jobController, limiters)
+	notificationRoutes(router, "/api/v2", notificationController, limiters)
+	sekolahRoutes(router, "/api/v2", sekolahController, limiters)
+	pendaftarRoutes(router, "/api/v2", pendaftarController, limiters)
+	return router
+}
+
+func siswaRoutes(router *routeRecorder, prefix string, siswaController controller.SiswaController, auditController controller.AuditController, limiters rateLimiters) {
+	router.GET(prefix+"/siswas", limiters.List.Handle(middleware.CacheControl(listCacheControl, siswaController.FindAll)))
+	router.GET(prefix+"/siswas/trash", limiters.List.Handle(middleware.CacheControl(historyCacheControl, siswaController.FindTrash)))
+	router.GET(prefix+"/siswas/:siswaId", limiters.Read.Handle(middleware.CacheControl(siswaCacheControl, siswaController.FindById)))
+	router.POST(prefix+"/siswas", limiters.Write.Handle(middleware.CacheControl(writeCacheControl, siswaController.Create)))
+	router.POST(prefix+"/siswas/batch", limiters.Batch.Handle(middleware.CacheControl(writeCacheControl, siswaController.Batch)))
+	router.PUT(prefix+"/siswas/:siswaId", limiters.Write.Handle(middleware.CacheControl(writeCacheControl, siswaController.Update)))
+	router.PATCH(prefix+"/siswas/:siswaId", limiters.Write.Handle(middleware.CacheControl(writeCacheControl, siswaController.Patch)))
+	router.DELETE(prefix+"/siswas/:siswaId", limiters.Write.Handle(middleware.CacheControl(writeCacheControl, siswaController.Delete)))
+	router.POST(prefix+"/siswas/:siswaId/restore", limiters.Write.Handle(middleware.CacheControl(writeCacheControl, siswaController.Restore)))
+	router.GET(prefix+"/siswas/:siswaId/history", limiters.Read.Handle(middleware.CacheControl(historyCacheControl, auditController.FindSiswaHistory)))
+
+	router.GET(prefix+"/audits", limiters.List.Handle(middleware.CacheControl(historyCacheControl, auditController.Search)))
+}
+
+func webhookRoutes(router *routeRecorder, prefix string, webhookController controller.WebhookController, limiters rateLimiters) {
+	router.GET(prefix+"/webhooks", limiters.Read.Handle(middleware.YayasanOnly(middleware.CacheControl(webhookCacheControl, webhookController.FindAll))))
+	router.GET(prefix+"/webhooks/:webhookId", limiters.Read.Handle(middleware.YayasanOnly(middleware.CacheControl(webhookCacheControl, webhookController.FindById))))
+	router.POST(prefix+"/webhooks", limiters.Write.Handle(middleware.YayasanOnly(middleware.CacheControl(writeCacheControl, webhookController.Create))))
+	router.PUT(prefix+"/webhooks/:webhookId", limiters.Write.Handle(middleware.YayasanOnly(middleware.CacheControl(writeCacheControl, webhookController.Update))))
+	router.DELETE(prefix+"/webhooks/:webhookId", limiters.Write.Handle(middleware.YayasanOnly(middleware.CacheControl(writeCacheControl, webhookController.Delete))))
+	router.GET(prefix+"/webhooks/:webhookId/deliveries", limiters.Read.Handle(middleware.YayasanOnly(middleware.CacheControl(webhookCacheControl, webhookController.FindDeliveries))))
+	router.POST(prefix+"/webhooks/:webhookId/deliveries/:deliveryId/replay", limiters.Write.Handle(middleware.YayasanOnly(middleware.CacheControl(writeCacheControl, webhookController.Replay))))
+}
+
+func jobRoutes(router *routeRecorder, prefix string, jobController controller.JobController, limiters rateLimiters) {
+	router.GET(prefix+"/jobs", limiters.Read.Handle(middleware.YayasanOnly(middleware.CacheControl(jobCacheControl, jobController.FindAll))))
+	router.GET(prefix+"/jobs/:jobId", limiters.Read.Handle(middleware.YayasanOnly(middleware.CacheControl(jobCacheControl, jobController.FindById))))
+	router.POST(prefix+"/jobs/:jobId/retry", limiters.Write.Handle(middleware.YayasanOnly(middleware.CacheControl(writeCacheControl, jobController.Retry))))
+}
+
+func notificationRoutes(router *routeRecorder, prefix string, notificationController controller.NotificationController, limiters rateLimiters) {
+	router.GET(prefix+"/notifications", limiters.List.Handle(middleware.CacheControl(notificationCacheControl, notificationController.FindAll)))
+	// one request notifies up to 500 parents
+	router.POST(prefix+"/notifications", limiters.Batch.Handle(middleware.CacheControl(writeCacheControl, notificationController.Send)))
+	router.GET(prefix+"/siswas/:siswaId/notification-preference", limiters.Read.Handle(middleware.CacheControl(notificationCacheControl, notificationController.FindPreference)))
+	router.PUT(prefix+"/siswas/:siswaId/notification-preference", limiters.Write.Handle(middleware.CacheControl(writeCacheControl, notificationController.SavePreference)))
+}
+
+func sekolahRoutes(router *routeRecorder, prefix string, sekolahController controller.SekolahController, limiters rateLimiters) {
+	router.GET(prefix+"/sekolah", limiters.Read.Handle(middleware.CacheControl(sekolahCacheControl, sekolahController.FindCurrent)))
+	router.GET(prefix+"/sekolahs", limiters.List.Handle(middleware.YayasanOnly(middleware.CacheControl(sekolahCacheControl, sekolahController.FindAll))))
+	router.GET(prefix+"/sekolahs/:sekolahId", limiters.Read.Handle(middleware.YayasanOnly(middleware.CacheControl(sekolahCacheControl, sekolahController.FindById))))
+	router.POST(prefix+"/sekolahs", limiters.Write.Handle(middleware.YayasanOnly(middleware.CacheControl(writeCacheControl, sekolahController.Create))))
+	router.PUT(prefix+"/sekolahs/:sekolahId", limiters.Write.Handle(middleware.YayasanOnly(middleware.CacheControl(writeCacheControl, sekolahController.Update))))
+}
+
+func pendaftarRoutes(router *routeRecorder, prefix string, pendaftarController controller.PendaftarController, limiters rateLimiters) {
+	router.GET(prefix+"/pendaftars", limiters.List.Handle(middleware.CacheControl(pendaftarCacheControl, pendaftarController.FindAll)))
+	router.GET(prefix+"/pendaftars/:pendaftarId", limiters.Read.Handle(middleware.CacheControl(pendaftarCacheControl, pendaftarController.FindById)))
+	router.GET(prefix+"/pendaftars/:pendaftarId/dokumen/:jenis", limiters.Read.Handle(middleware.CacheControl(pendaftarCacheControl, pendaftarController.FindDokumen)))
+	router.POST(prefix+"/pendaftars/:pendaftarId/verify", limiters.Write.Handle(middleware.CacheControl(writeCacheControl, pendaftarController.Verify)))
+	router.POST(prefix+"/pendaftars/:pendaftarId/score", limiters.Write.Handle(middleware.CacheControl(writeCacheControl, pendaftarController.Score)))
+	router.POST(prefix+"/pendaftars/:pendaftarId/reject", limiters.Write.Handle(middleware.CacheControl(writeCacheControl, pendaftarController.Reject)))
+	router.POST(prefix+"/pendaftars/:pendaftarId/re-register", limiters.Write.Handle(middleware.CacheControl(writeCacheControl, pendaftarController.ReRegister)))
+	// one request decides on, or announces, every pendaftar of the school
+	router.POST(prefix+"/pendaftars/select", limiters.Batch.Handle(middleware.CacheControl(writeCacheControl, pendaftarController.Select)))
+	router.POST(prefix+"/pendaftars/announce", limiters.Batch.Handle(middleware.CacheControl(writeCacheControl, pendaftarController.Announce)))
+}
 
-	router.GET("/api/siswas", siswaController.FindAll)
-	router.GET("/api/siswas/:siswaId", siswaController.FindById)
-	router.POST("/api/siswas", siswaController.Create)
-	router.PUT("/api/siswas/:siswaId", siswaController.Update)
-	router.DELETE("/api/siswas/:siswaId", siswaController.Delete)
+func ppdbRoutes(pendaftarController controller.PendaftarController, limiters rateLimiters) *routeRecorder {
+	router := &routeRecorder{}
 
-	router.PanicHandler = exception.ErrorHandler
+	router.POST("/api/v2/ppdb/pendaftars", limiters.Write.Handle(middleware.CacheControl(writeCacheControl, pendaftarController.Register)))
+	router.GET("/api/v2/ppdb/pendaftars/:pendaftarId", limiters.Read.Handle(middleware.CacheControl(ppdbCacheControl, pendaftarController.FindOwn)))
+	router.PUT("/api/v2/ppdb/pendaftars/:pendaftarId/dokumen/:jenis", limiters.Write.Handle(middleware.CacheControl(writeCacheControl, pendaftarController.UploadDokumen)))
+	router.GET("/api/v2/ppdb/pengumuman", limiters.List.Handle(middleware.CacheControl(pengumumanCacheControl, pendaftarController.FindAnnounced)))
 
 	return router
 }
+
+func publicRoutes(healthController controller.HealthController, registry *prometheus.Registry) *routeRecorder {
+	router := &routeRecorder{}
+
+	router.GET("/healthz", healthController.Liveness)
+	router.GET("/readyz", healthController.Readiness)
+	router.Handler(http.MethodGet, "/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	router.GET("/api/openapi.json", OpenAPIHandle)
+	router.GET("/api/docs", SwaggerUIHandle)
+
+	return router
+}
+
+// routeRecorder collects routes before handing them to httprouter, so that
+// the route list can be inspected and every handle reports the pattern it
+// was registered with through helper.RouteFromContext.
+type routeRecorder struct {
+	routes []recordedRoute
+}
+
+type recordedRoute struct {
+	Method string
+	Path   string
+	Handle httprouter.Handle
+}
+
+func (router *routeRecorder) Handle(method string, path string, handle httprouter.Handle) {
+	router.routes = append(router.routes, recordedRoute{Method: method, Path: path, Handle: handle})
+}
+
+func (router *routeRecorder) Handler(method string, path string, handler http.Handler) {
+	router.Handle(method, path, func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		handler.ServeHTTP(writer, request)
+	})
+}
+
+func (router *routeRecorder) GET(path string, handle httprouter.Handle) {
+	router.Handle(http.MethodGet, path, handle)
+}
+
+func (router *routeRecorder) POST(path string, handle httprouter.Handle) {
+	router.Handle(http.MethodPost, path, handle)
+}
+
+func (router *routeRecorder) PUT(path string, handle httprouter.Handle) {
+	router.Handle(http.MethodPut, path, handle)
+}
+
+func (router *routeRecorder) PATCH(path string, handle httprouter.Handle) {
+	router.Handle(http.MethodPatch, path, handle)
+}
+
+func (router *routeRecorder) DELETE(path string, handle httprouter.Handle) {
+	router.Handle(http.MethodDelete, path, handle)
+}
+
+func (router *routeRecorder) Routes() []string {
+	var routes []string
+	for _, route := range router.routes {
+		segments := strings.Split(route.Path, "/")
+		for i, segment := range segments {
+			if strings.HasPrefix(segment, ":") {
+				segments[i] = "{" + segment[1:] + "}"
+			}
+		}
+		routes = append(routes, route.Method+" "+strings.Join(segments, "/"))
+	}
+	return routes
+}
+
+// Build registers the routes on a new httprouter. httprouter refuses a fixed
+// segment next to a wildcard, so a route such as /api/siswas/trash is served
+// by the /api/siswas/:siswaId handle, which dispatches on the segment value.
+func (router *routeRecorder) Build() *httprouter.Router {
+	wildcards := map[string]string{}
+	for _, route := range router.routes {
+		segments := strings.Split(route.Path, "/")
+		for i, segment := range segments {
+			if strings.HasPrefix(segment, ":") {
+				wildcards[strings.Join(segments[:i], "/")] = segment
+			}
+		}
+	}
+
+	statics := map[string]map[string]httprouter.Handle{}
+	var plain []recordedRoute
+	for _, route := range router.routes {
+		index := strings.LastIndex(route.Path, "/")
+		prefix, segment := route.Path[:index], route.Path[index+1:]
+		wildcard, ok := wildcards[prefix]
+		if !ok || strings.HasPrefix(segment, ":") {
+			plain = append(plain, route)
+			continue
+		}
+
+		key := route.Method + " " + prefix + "/" + wildcard
+		if statics[key] == nil {
+			statics[key] = map[string]httprouter.Handle{}
+		}
+		statics[key][segment] = withRoute(route.Path, route.Handle)
+	}
+
+	httpRouter := httprouter.New()
+	for _, route := range plain {
+		handle := withRoute(route.Path, route.Handle)
+		key := route.Method + " " + route.Path
+		if static, ok := statics[key]; ok {
+			handle = staticOr(route.Path[strings.LastIndex(route.Path, ":")+1:], static, handle)
+			delete(statics, key)
+		}
+		httpRouter.Handle(route.Method, route.Path, handle)
+	}
+	for key, static := range statics {
+		method, path := key[:strings.Index(key, " ")], key[strings.Index(key, " ")+1:]
+		httpRouter.Handle(method, path, staticOr(path[strings.LastIndex(path, ":")+1:], static, nil))
+	}
+
+	httpRouter.PanicHandler = exception.ErrorHandler
+
+	return httpRouter
+}
+
+func withRoute(path string, handle httprouter.Handle) httprouter.Handle {
+	return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		helper.RouteFromContext(request.Context()).Pattern = path
+		handle(writer, request, params)
+	}
+}
+
+func staticOr(name string, statics map[string]httprouter.Handle, handle httprouter.Handle) httprouter.Handle {
+	return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		if static, ok := statics[params.ByName(name)]; ok {
+			static(writer, request, params)
+			return
+		}
+		if handle == nil {
+			http.NotFound(writer, request)
+			return
+		}
+		handle(writer, request, params)
+	}
+}
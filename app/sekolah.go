@@ -0,0 +1,17 @@
+package app
+
+const defaultSekolah = "default"
+
+// NewDefaultSekolah reads the kode of the school requests naming none work
+// on from SISKO_DEFAULT_SEKOLAH, defaulting to the school the migrations
+// create for existing data.
+func NewDefaultSekolah() string {
+	return stringFromEnv("SISKO_DEFAULT_SEKOLAH", defaultSekolah)
+}
+
+// NewTenantDomain reads the domain whose subdomains name a school from
+// SISKO_TENANT_DOMAIN, such as sisko.example.id for smpn1.sisko.example.id.
+// Schools are only named by the X-Sekolah header when it is empty.
+func NewTenantDomain() string {
+	return stringFromEnv("SISKO_TENANT_DOMAIN", "")
+}
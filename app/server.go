@@ -0,0 +1,20 @@
+package app
+
+import (
+	"net/http"
+	"time"
+)
+
+// NewServer builds the HTTP server listening on SISKO_ADDR. Its timeouts are
+// read from SISKO_READ_HEADER_TIMEOUT, SISKO_READ_TIMEOUT, SISKO_WRITE_TIMEOUT
+// and SISKO_IDLE_TIMEOUT.
+func NewServer(handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              stringFromEnv("SISKO_ADDR", "localhost:3000"),
+		Handler:           handler,
+		ReadHeaderTimeout: durationFromEnv("SISKO_READ_HEADER_TIMEOUT", 5*time.Second),
+		ReadTimeout:       durationFromEnv("SISKO_READ_TIMEOUT", 15*time.Second),
+		WriteTimeout:      durationFromEnv("SISKO_WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:       durationFromEnv("SISKO_IDLE_TIMEOUT", 120*time.Second),
+	}
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/helper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"os"
+)
+
+// NewTracerProvider exports spans over OTLP/HTTP when
+// OTEL_EXPORTER_OTLP_ENDPOINT is set (for example http://localhost:4318 for a
+// local collector); the exporter reads the other standard OTEL_* variables
+// itself. The returned function flushes pending spans on shutdown.
+func NewTracerProvider() func(ctx context.Context) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return func(ctx context.Context) error { return nil }
+	}
+
+	exporter, err := otlptracehttp.New(context.Background())
+	helper.PanicIfError(err)
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName("go-sisko"))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	return tracerProvider.Shutdown
+}
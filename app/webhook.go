@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/Arraf18/go-sisko/outbox"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultWebhookTimeout      = 10 * time.Second
+	defaultWebhookInterval     = 5 * time.Second
+	defaultWebhookDisableAfter = 20
+)
+
+// NewWebhookClient is the client deliveries are sent with. An endpoint gets
+// SISKO_WEBHOOK_TIMEOUT, defaulting to 10 seconds, to answer.
+func NewWebhookClient() *http.Client {
+	return &http.Client{Timeout: durationFromEnv("SISKO_WEBHOOK_TIMEOUT", defaultWebhookTimeout)}
+}
+
+// NewWebhookInterval reads how often due deliveries are sent from
+// SISKO_WEBHOOK_INTERVAL, defaulting to every 5 seconds.
+func NewWebhookInterval() time.Duration {
+	return durationFromEnv("SISKO_WEBHOOK_INTERVAL", defaultWebhookInterval)
+}
+
+// NewWebhookDisableAfter reads after how many failed attempts in a row a
+// subscription is disabled from SISKO_WEBHOOK_DISABLE_AFTER, defaulting to 20.
+func NewWebhookDisableAfter() int {
+	return intFromEnv("SISKO_WEBHOOK_DISABLE_AFTER", defaultWebhookDisableAfter)
+}
+
+// NewWebhookHandler queues the deliveries of every event on the outbox bus.
+// A failure is returned to the relay, which publishes the event again.
+func NewWebhookHandler(webhookService service.WebhookService) outbox.Handler {
+	return func(ctx context.Context, event outbox.Event) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = fmt.Errorf("queueing webhook deliveries: %v", recovered)
+			}
+		}()
+
+		webhookService.Enqueue(ctx, event)
+		return nil
+	}
+}
+
+// StartWebhookDispatcher sends due deliveries every interval until ctx is
+// cancelled.
+func StartWebhookDispatcher(ctx context.Context, webhookService service.WebhookService, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				dispatchWebhooks(ctx, webhookService)
+			}
+		}
+	}()
+}
+
+func dispatchWebhooks(ctx context.Context, webhookService service.WebhookService) {
+	// a failed run is simply retried on the next tick
+	defer func() {
+		if err := recover(); err != nil {
+			logrus.WithField("error", err).Error("webhook dispatch failed")
+		}
+	}()
+
+	webhookService.DeliverPending(ctx)
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// LRUCache keeps up to Size entries in process memory, evicting the least
+// recently used one when full. Every instance of the server has its own.
+type LRUCache struct {
+	Size    int
+	mutex   sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{Size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (cache *LRUCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		cache.remove(element)
+		return nil, false, nil
+	}
+
+	cache.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (cache *LRUCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.entries[key]; ok {
+		cache.remove(element)
+	}
+	cache.entries[key] = cache.order.PushFront(&lruEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)})
+
+	for cache.order.Len() > cache.Size {
+		cache.remove(cache.order.Back())
+	}
+	return nil
+}
+
+func (cache *LRUCache) Delete(ctx context.Context, keys ...string) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for _, key := range keys {
+		if element, ok := cache.entries[key]; ok {
+			cache.remove(element)
+		}
+	}
+	return nil
+}
+
+func (cache *LRUCache) remove(element *list.Element) {
+	cache.order.Remove(element)
+	delete(cache.entries, element.Value.(*lruEntry).key)
+}
@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// RedisCache shares entries between every instance of the server, so an
+// invalidation on one instance is seen by all of them.
+type RedisCache struct {
+	Client *redis.Client
+	Prefix string
+}
+
+func NewRedisCache(client *redis.Client, prefix string) *RedisCache {
+	return &RedisCache{Client: client, Prefix: prefix}
+}
+
+func (cache *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := cache.Client.Get(ctx, cache.Prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (cache *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return cache.Client.Set(ctx, cache.Prefix+key, value, ttl).Err()
+}
+
+func (cache *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = cache.Prefix + key
+	}
+	return cache.Client.Del(ctx, prefixed...).Err()
+}
@@ -0,0 +1,186 @@
+// Command sisko administers go-sisko from the shell: siswa, import/export,
+// migrations, users and API keys. It talks to the database directly through
+// the same services as the HTTP API, so validation and audit apply as well.
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/Arraf18/go-sisko/app"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/go-playground/validator"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"os/user"
+)
+
+const usage = `usage: sisko [-output json|table] [-sekolah kode] <command> [arguments]
+
+commands:
+  siswa list [-trash]
+  siswa show <id>
+  siswa create -nama ... -alamat ... -tanggal-lahir ... -tempat-lahir ...
+               -jenis-kelamin ... -agama ... -golongan-darah ... -no-telepon ...
+  siswa update [-version n] [-nama ...] ... <id>
+  siswa delete [-version n] <id>
+  siswa restore <id>
+  import [-best-effort] <file.json|file.ndjson|file.csv>
+  export [-format json|ndjson|csv] [-o file] [-trash]
+  migrate up | down [n] | force <version> | version
+  sekolah create [-bahasa id|en] [-kuota-siswa n] [-kuota-zonasi n]
+                 [-kuota-prestasi n] [-kuota-afirmasi n] <kode> <nama>
+  sekolah list
+  user create [-sekolah kode] <username> <nama>
+  user list
+  apikey create <username> <name>
+  apikey list
+  apikey revoke <id>
+`
+
+var errUsage = errors.New("invalid arguments")
+
+type command func(cli *cli, args []string) error
+
+var commands = map[string]map[string]command{
+	"siswa": {
+		"list":    siswaList,
+		"show":    siswaShow,
+		"create":  siswaCreate,
+		"update":  siswaUpdate,
+		"delete":  siswaDelete,
+		"restore": siswaRestore,
+	},
+	"sekolah": {
+		"create": sekolahCreate,
+		"list":   sekolahList,
+	},
+	"user": {
+		"create": userCreate,
+		"list":   userList,
+	},
+	"apikey": {
+		"create": apiKeyCreate,
+		"list":   apiKeyList,
+		"revoke": apiKeyRevoke,
+	},
+}
+
+// cli is what every command gets: the services and where to print. ctx is
+// scoped to the school chosen with -sekolah.
+type cli struct {
+	ctx            context.Context
+	out            io.Writer
+	output         string
+	siswaService   service.SiswaService
+	userService    service.UserService
+	sekolahService service.SekolahService
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) (code int) {
+	flags := flag.NewFlagSet("sisko", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprint(stderr, usage) }
+	output := flags.String("output", "table", "json or table")
+	sekolah := flags.String("sekolah", "", "kode of the school to work on, the default school otherwise")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	args = flags.Args()
+	if len(args) == 0 || (*output != "json" && *output != "table") {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	// services panic with the exception types, like in the HTTP handlers
+	defer func() {
+		if err := recover(); err != nil {
+			webResponse := exception.ToWebResponse(err)
+			fmt.Fprintf(stderr, "sisko: %s: %v\n", webResponse.Status, webResponse.Data)
+			code = 1
+		}
+	}()
+
+	var err error
+	switch args[0] {
+	case "migrate":
+		err = migrateCommand(stdout, args[1:])
+	case "import", "export":
+		cli := newCli(stdout, *output, *sekolah)
+		if args[0] == "import" {
+			err = importSiswa(cli, args[1:])
+		} else {
+			err = exportSiswa(cli, args[1:])
+		}
+	default:
+		group, ok := commands[args[0]]
+		if !ok || len(args) < 2 || group[args[1]] == nil {
+			err = errUsage
+			break
+		}
+		err = group[args[1]](newCli(stdout, *output, *sekolah), args[2:])
+	}
+
+	if errors.Is(err, errUsage) {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "sisko: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func newCli(stdout io.Writer, output string, kode string) *cli {
+	logger := app.NewLogger()
+	logger.SetOutput(os.Stderr)
+	logger.SetLevel(logrus.WarnLevel)
+
+	db := app.NewDB()
+	validate := validator.New()
+	siswaCache, _ := app.NewCache()
+	siswaService := service.NewSiswaService(repository.NewSiswaRepository(), repository.NewAuditRepository(), repository.NewOutboxEventRepository(), db, validate)
+	sekolahService := service.NewSekolahService(repository.NewSekolahRepository(), repository.NewUserRepository(), db, validate, app.NewDefaultSekolah())
+
+	// the shell user is no user of the API, so may work on every school
+	ctx := cliContext()
+	sekolah, _ := sekolahService.Resolve(ctx, helper.ActorFromContext(ctx), kode)
+
+	return &cli{
+		ctx:    helper.WithSekolah(ctx, sekolah, false),
+		out:    stdout,
+		output: output,
+		// keeps a shared Redis cache in step with changes made here
+		siswaService:   service.NewSiswaServiceCache(siswaService, siswaCache, app.NewCacheTTL()),
+		userService:    service.NewUserService(repository.NewUserRepository(), repository.NewApiKeyRepository(), repository.NewSekolahRepository(), db, validate, ""),
+		sekolahService: sekolahService,
+	}
+}
+
+// cliContext makes changes show up in the audit trail as cli:<login>.
+func cliContext() context.Context {
+	actor := "cli"
+	if current, err := user.Current(); err == nil {
+		actor = "cli:" + current.Username
+	}
+
+	random := make([]byte, 8)
+	_, err := rand.Read(random)
+	helper.PanicIfError(err)
+
+	ctx := helper.WithActor(context.Background(), actor)
+	return helper.WithRequestId(ctx, "cli-"+hex.EncodeToString(random))
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Arraf18/go-sisko/app"
+	"github.com/Arraf18/go-sisko/db"
+	"github.com/golang-migrate/migrate/v4"
+	"io"
+	"strconv"
+)
+
+// migrateCommand applies the embedded migrations. A database that already
+// has the siswa table from before migrations were kept is adopted by migrate
+// up, since 000001 leaves an existing table alone. When the schema is known
+// to be at a version already, or a migration failed halfway and left it
+// dirty, repair the schema by hand and record the version with
+// migrate force <version>.
+func migrateCommand(stdout io.Writer, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errUsage
+	}
+
+	steps, version := 1, 0
+	switch {
+	case args[0] == "down" && len(args) == 2:
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return errUsage
+		}
+		steps = n
+	case args[0] == "force" && len(args) == 2:
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 0 {
+			return errUsage
+		}
+		version = n
+	case len(args) != 1:
+		return errUsage
+	case args[0] != "up" && args[0] != "down" && args[0] != "version":
+		return errUsage
+	}
+
+	m, err := db.NewMigrate(app.NewDataSourceName())
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch args[0] {
+	case "up":
+		err = m.Up()
+	case "down":
+		err = m.Steps(-steps)
+	case "force":
+		err = m.Force(version)
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	current, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		fmt.Fprintln(stdout, "no migrations applied")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if dirty {
+		fmt.Fprintf(stdout, "version %d (dirty)\n", current)
+	} else {
+		fmt.Fprintf(stdout, "version %d\n", current)
+	}
+	return nil
+}
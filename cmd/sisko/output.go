@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Arraf18/go-sisko/helper"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// print writes a slice, or a single value as a one row table.
+func (cli *cli) print(value interface{}) {
+	if cli.output == "json" {
+		encoder := json.NewEncoder(cli.out)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(value)
+		helper.PanicIfError(err)
+		return
+	}
+
+	data := reflect.ValueOf(value)
+	if data.Kind() != reflect.Slice {
+		list := reflect.MakeSlice(reflect.SliceOf(data.Type()), 1, 1)
+		list.Index(0).Set(data)
+		data = list
+	}
+
+	header, rows := helper.ToTable(data.Interface())
+	writer := tabwriter.NewWriter(cli.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.ToUpper(strings.Join(header, "\t")))
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	err := writer.Flush()
+	helper.PanicIfError(err)
+}
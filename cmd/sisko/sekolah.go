@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"github.com/Arraf18/go-sisko/model/web"
+	"io"
+)
+
+func sekolahCreate(cli *cli, args []string) error {
+	flags := flag.NewFlagSet("sekolah create", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	bahasa := flags.String("bahasa", "id", "language parents are notified in")
+	kuotaSiswa := flags.Int("kuota-siswa", 0, "most active siswa, 0 for no cap")
+	kuotaZonasi := flags.Int("kuota-zonasi", 0, "PPDB seats of the zonasi jalur")
+	kuotaPrestasi := flags.Int("kuota-prestasi", 0, "PPDB seats of the prestasi jalur")
+	kuotaAfirmasi := flags.Int("kuota-afirmasi", 0, "PPDB seats of the afirmasi jalur")
+	if flags.Parse(args) != nil || flags.NArg() != 2 {
+		return errUsage
+	}
+
+	cli.print(cli.sekolahService.Create(cli.ctx, web.SekolahCreateRequest{
+		Kode:          flags.Arg(0),
+		Nama:          flags.Arg(1),
+		Bahasa:        *bahasa,
+		KuotaSiswa:    *kuotaSiswa,
+		KuotaZonasi:   *kuotaZonasi,
+		KuotaPrestasi: *kuotaPrestasi,
+		KuotaAfirmasi: *kuotaAfirmasi,
+	}))
+	return nil
+}
+
+func sekolahList(cli *cli, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+
+	cli.print(cli.sekolahService.FindAll(cli.ctx))
+	return nil
+}
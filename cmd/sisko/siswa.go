@@ -0,0 +1,154 @@
+package main
+
+import (
+	"flag"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"io"
+	"strconv"
+)
+
+// siswaFlags are the fields of a siswa as command line flags.
+type siswaFlags struct {
+	flags   *flag.FlagSet
+	request web.SiswaCreateRequest
+}
+
+func newSiswaFlags(name string) *siswaFlags {
+	siswaFlags := &siswaFlags{flags: flag.NewFlagSet(name, flag.ContinueOnError)}
+	siswaFlags.flags.SetOutput(io.Discard)
+	siswaFlags.flags.StringVar(&siswaFlags.request.Nama, "nama", "", "nama")
+	siswaFlags.flags.StringVar(&siswaFlags.request.Alamat, "alamat", "", "alamat")
+	siswaFlags.flags.StringVar(&siswaFlags.request.TanggalLahir, "tanggal-lahir", "", "tanggal lahir")
+	siswaFlags.flags.StringVar(&siswaFlags.request.TempatLahir, "tempat-lahir", "", "tempat lahir")
+	siswaFlags.flags.StringVar(&siswaFlags.request.JenisKelamin, "jenis-kelamin", "", "jenis kelamin")
+	siswaFlags.flags.StringVar(&siswaFlags.request.Agama, "agama", "", "agama")
+	siswaFlags.flags.StringVar(&siswaFlags.request.GolonganDarah, "golongan-darah", "", "golongan darah")
+	siswaFlags.flags.StringVar(&siswaFlags.request.NoTelepon, "no-telepon", "", "no telepon")
+	return siswaFlags
+}
+
+func idArgument(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, errUsage
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, errUsage
+	}
+	return id, nil
+}
+
+func siswaList(cli *cli, args []string) error {
+	flags := flag.NewFlagSet("siswa list", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	trash := flags.Bool("trash", false, "list soft deleted siswa")
+	if flags.Parse(args) != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	if *trash {
+		cli.print(helper.ToSiswaResponsesV2(cli.siswaService.FindTrash(cli.ctx)))
+	} else {
+		cli.print(helper.ToSiswaResponsesV2(cli.siswaService.FindAll(cli.ctx)))
+	}
+	return nil
+}
+
+func siswaShow(cli *cli, args []string) error {
+	id, err := idArgument(args)
+	if err != nil {
+		return err
+	}
+
+	cli.print(helper.ToSiswaResponseV2(cli.siswaService.FindById(cli.ctx, id)))
+	return nil
+}
+
+func siswaCreate(cli *cli, args []string) error {
+	siswaFlags := newSiswaFlags("siswa create")
+	if siswaFlags.flags.Parse(args) != nil || siswaFlags.flags.NArg() != 0 {
+		return errUsage
+	}
+
+	cli.print(helper.ToSiswaResponseV2(cli.siswaService.Create(cli.ctx, siswaFlags.request)))
+	return nil
+}
+
+// siswaUpdate changes only the given fields. Without -version it updates the
+// current version, which skips the optimistic locking the API enforces.
+func siswaUpdate(cli *cli, args []string) error {
+	siswaFlags := newSiswaFlags("siswa update")
+	version := siswaFlags.flags.Int("version", 0, "expected version")
+	if siswaFlags.flags.Parse(args) != nil {
+		return errUsage
+	}
+	id, err := idArgument(siswaFlags.flags.Args())
+	if err != nil {
+		return err
+	}
+
+	current := cli.siswaService.FindById(cli.ctx, id)
+	request := web.SiswaUpdateRequest{
+		Id:            id,
+		Nama:          current.Nama,
+		Alamat:        current.Alamat,
+		TanggalLahir:  current.TanggalLahir,
+		TempatLahir:   current.TempatLahir,
+		JenisKelamin:  current.JenisKelamin,
+		Agama:         current.Agama,
+		GolonganDarah: current.GolonganDarah,
+		NoTelepon:     current.NoTelepon,
+		Version:       current.Version,
+	}
+	fields := map[string]*string{
+		"nama":           &request.Nama,
+		"alamat":         &request.Alamat,
+		"tanggal-lahir":  &request.TanggalLahir,
+		"tempat-lahir":   &request.TempatLahir,
+		"jenis-kelamin":  &request.JenisKelamin,
+		"agama":          &request.Agama,
+		"golongan-darah": &request.GolonganDarah,
+		"no-telepon":     &request.NoTelepon,
+	}
+	siswaFlags.flags.Visit(func(f *flag.Flag) {
+		if field, ok := fields[f.Name]; ok {
+			*field = f.Value.String()
+		}
+	})
+	if *version != 0 {
+		request.Version = *version
+	}
+
+	cli.print(helper.ToSiswaResponseV2(cli.siswaService.Update(cli.ctx, request)))
+	return nil
+}
+
+func siswaDelete(cli *cli, args []string) error {
+	flags := flag.NewFlagSet("siswa delete", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	version := flags.Int("version", 0, "expected version")
+	if flags.Parse(args) != nil {
+		return errUsage
+	}
+	id, err := idArgument(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	if *version == 0 {
+		*version = cli.siswaService.FindById(cli.ctx, id).Version
+	}
+	cli.siswaService.Delete(cli.ctx, id, *version)
+	return nil
+}
+
+func siswaRestore(cli *cli, args []string) error {
+	id, err := idArgument(args)
+	if err != nil {
+		return err
+	}
+
+	cli.print(helper.ToSiswaResponseV2(cli.siswaService.Restore(cli.ctx, id)))
+	return nil
+}
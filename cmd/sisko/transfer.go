@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// importBatchSize is the most operations a siswa batch accepts.
+const importBatchSize = 500
+
+// importRecord accepts what export writes: the response only fields are
+// skipped, anything else unknown is an error.
+type importRecord struct {
+	web.SiswaCreateRequest
+	Id        json.RawMessage `json:"id"`
+	DeletedAt json.RawMessage `json:"deleted_at"`
+	DeletedBy json.RawMessage `json:"deleted_by"`
+}
+
+// importSiswa creates every record of the file, importBatchSize at a time.
+// Each batch is atomic unless -best-effort is given; a failed batch stops the
+// import, batches before it stay committed.
+func importSiswa(cli *cli, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	bestEffort := flags.Bool("best-effort", false, "keep going past invalid records")
+	if flags.Parse(args) != nil || flags.NArg() != 1 {
+		return errUsage
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	records, err := readRecords(file, formatOf(flags.Arg(0)))
+	if err != nil {
+		return fmt.Errorf("%s: %w", flags.Arg(0), err)
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("%s: no records", flags.Arg(0))
+	}
+
+	mode := service.BatchModeAtomic
+	if *bestEffort {
+		mode = service.BatchModeBestEffort
+	}
+
+	var results []web.SiswaBatchResultV2
+	var failed error
+	for start := 0; start < len(records); start += importBatchSize {
+		end := start + importBatchSize
+		if end > len(records) {
+			end = len(records)
+		}
+
+		request := web.SiswaBatchRequest{Mode: mode}
+		for i := start; i < end; i++ {
+			data := records[i]
+			request.Operations = append(request.Operations, web.SiswaBatchOperation{Action: "create", Data: &data})
+		}
+
+		batchResponse := helper.ToSiswaBatchResponseV2(cli.siswaService.Batch(cli.ctx, request))
+		for _, result := range batchResponse.Results {
+			result.Index += start
+			results = append(results, result)
+		}
+		if !batchResponse.Committed {
+			failed = fmt.Errorf("records %d to %d were not imported, %d before them were", start, end-1, start)
+			break
+		}
+	}
+
+	cli.print(results)
+	return failed
+}
+
+func exportSiswa(cli *cli, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	format := flags.String("format", "", "json, ndjson or csv, by default from the -o extension or json")
+	output := flags.String("o", "", "file to write, standard output by default")
+	trash := flags.Bool("trash", false, "export soft deleted siswa")
+	if flags.Parse(args) != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+	if *format == "" {
+		*format = formatOf(*output)
+	}
+	if *format != "json" && *format != "ndjson" && *format != "csv" {
+		return errUsage
+	}
+
+	var siswaResponses []web.SiswaResponse
+	if *trash {
+		siswaResponses = cli.siswaService.FindTrash(cli.ctx)
+	} else {
+		siswaResponses = cli.siswaService.FindAll(cli.ctx)
+	}
+	data := helper.ToSiswaResponsesV2(siswaResponses)
+
+	writer := cli.out
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	buffered := bufio.NewWriter(writer)
+	switch *format {
+	case "ndjson":
+		helper.WriteNDJSON(buffered, data)
+	case "csv":
+		helper.WriteCSV(buffered, data)
+	default:
+		if data == nil {
+			data = []web.SiswaResponseV2{}
+		}
+		encoder := json.NewEncoder(buffered)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(data)
+		helper.PanicIfError(err)
+	}
+	return buffered.Flush()
+}
+
+// formatOf picks the format from the file extension, json if unknown.
+func formatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	case ".csv":
+		return "csv"
+	}
+	return "json"
+}
+
+func readRecords(reader io.Reader, format string) ([]web.SiswaCreateRequest, error) {
+	var records []web.SiswaCreateRequest
+	add := func(line int, data []byte) error {
+		record, err := decodeRecord(data)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", line, err)
+		}
+		records = append(records, record)
+		return nil
+	}
+
+	switch format {
+	case "csv":
+		csvReader := csv.NewReader(reader)
+		header, err := csvReader.Read()
+		if err != nil {
+			return nil, err
+		}
+		for line := 1; ; line++ {
+			row, err := csvReader.Read()
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			fields := map[string]string{}
+			for i, name := range header {
+				fields[name] = row[i]
+			}
+			data, err := json.Marshal(fields)
+			helper.PanicIfError(err)
+			if err := add(line, data); err != nil {
+				return nil, err
+			}
+		}
+	case "ndjson":
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(nil, 1<<20)
+		for line := 1; scanner.Scan(); line++ {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			if err := add(line, scanner.Bytes()); err != nil {
+				return nil, err
+			}
+		}
+		return records, scanner.Err()
+	default:
+		var items []json.RawMessage
+		if err := json.NewDecoder(reader).Decode(&items); err != nil {
+			return nil, err
+		}
+		for i, item := range items {
+			if err := add(i+1, item); err != nil {
+				return nil, err
+			}
+		}
+		return records, nil
+	}
+}
+
+func decodeRecord(data []byte) (web.SiswaCreateRequest, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	record := importRecord{}
+	err := decoder.Decode(&record)
+	return record.SiswaCreateRequest, err
+}
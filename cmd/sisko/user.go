@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"github.com/Arraf18/go-sisko/model/web"
+	"io"
+)
+
+// userCreate adds staff of the yayasan, or with -sekolah a user who may only
+// work on that school.
+func userCreate(cli *cli, args []string) error {
+	flags := flag.NewFlagSet("user create", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	sekolah := flags.String("sekolah", "", "kode of the only school the user may work on")
+	if flags.Parse(args) != nil || flags.NArg() != 2 {
+		return errUsage
+	}
+
+	cli.print(cli.userService.Create(cli.ctx, web.UserCreateRequest{Username: flags.Arg(0), Nama: flags.Arg(1), Sekolah: *sekolah}))
+	return nil
+}
+
+func userList(cli *cli, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+
+	cli.print(cli.userService.FindAll(cli.ctx))
+	return nil
+}
+
+// apiKeyCreate prints the key once; only its hash is stored.
+func apiKeyCreate(cli *cli, args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+
+	cli.print(cli.userService.CreateApiKey(cli.ctx, web.ApiKeyCreateRequest{Username: args[0], Name: args[1]}))
+	return nil
+}
+
+func apiKeyList(cli *cli, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+
+	cli.print(cli.userService.FindAllApiKeys(cli.ctx))
+	return nil
+}
+
+func apiKeyRevoke(cli *cli, args []string) error {
+	id, err := idArgument(args)
+	if err != nil {
+		return err
+	}
+
+	cli.userService.RevokeApiKey(cli.ctx, id)
+	return nil
+}
@@ -0,0 +1,11 @@
+package controller
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+type AuditController interface {
+	FindSiswaHistory(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Search(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
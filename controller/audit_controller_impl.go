@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+type AuditControllerImpl struct {
+	AuditService service.AuditService
+}
+
+func NewAuditController(auditService service.AuditService) AuditController {
+	return &AuditControllerImpl{
+		AuditService: auditService,
+	}
+}
+
+func (controller *AuditControllerImpl) FindSiswaHistory(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "siswaId")
+
+	auditResponses := controller.AuditService.FindSiswaHistory(request.Context(), id)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   auditResponses,
+	}
+
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
+
+func (controller *AuditControllerImpl) Search(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	auditSearchRequest := web.AuditSearchRequest{
+		Actor:  query.Get("actor"),
+		Entity: query.Get("entity"),
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+	}
+
+	if entityId := query.Get("entity_id"); entityId != "" {
+		id, err := strconv.Atoi(entityId)
+		if err != nil {
+			panic(exception.NewBadRequestError("entity_id must be a number"))
+		}
+		auditSearchRequest.EntityId = id
+	}
+
+	auditResponses := controller.AuditService.Search(request.Context(), auditSearchRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   auditResponses,
+	}
+
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
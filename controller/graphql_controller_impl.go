@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"github.com/Arraf18/go-sisko/graph"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// GraphqlControllerImpl answers every query with 200 and the GraphQL result,
+// errors included; only a body that is not a GraphQL request gets a 4xx.
+type GraphqlControllerImpl struct {
+	Schema        graphql.Schema
+	SiswaService  service.SiswaService
+	AuditService  service.AuditService
+	MaxComplexity int
+}
+
+func NewGraphqlController(schema graphql.Schema, siswaService service.SiswaService, auditService service.AuditService, maxComplexity int) GraphqlController {
+	return &GraphqlControllerImpl{
+		Schema:        schema,
+		SiswaService:  siswaService,
+		AuditService:  auditService,
+		MaxComplexity: maxComplexity,
+	}
+}
+
+func (controller *GraphqlControllerImpl) Query(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	graphqlRequest := web.GraphqlRequest{}
+	helper.ReadFromRequestBody(request, &graphqlRequest)
+
+	// a query that does not parse is left to graphql.Do to report, the
+	// complexity is checked first because it also bounds the work of Depth
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(graphqlRequest.Query)})})
+	if err == nil {
+		complexity := graph.Complexity(document, graphqlRequest.OperationName, graphqlRequest.Variables, controller.MaxComplexity)
+		if complexity > controller.MaxComplexity {
+			formattedError := gqlerrors.NewFormattedError("query complexity exceeds the limit of " + strconv.Itoa(controller.MaxComplexity))
+			formattedError.Extensions = map[string]interface{}{
+				"code":          "QUERY_TOO_COMPLEX",
+				"maxComplexity": controller.MaxComplexity,
+			}
+			helper.WriteToResponseBody(writer, &graphql.Result{Errors: []gqlerrors.FormattedError{formattedError}})
+			return
+		}
+
+		if graph.Depth(document, graphqlRequest.OperationName) > graph.MaxDepth {
+			formattedError := gqlerrors.NewFormattedError("query nests deeper than " + strconv.Itoa(graph.MaxDepth) + " fields")
+			formattedError.Extensions = map[string]interface{}{
+				"code":     "QUERY_TOO_DEEP",
+				"maxDepth": graph.MaxDepth,
+			}
+			helper.WriteToResponseBody(writer, &graphql.Result{Errors: []gqlerrors.FormattedError{formattedError}})
+			return
+		}
+	}
+
+	ctx := graph.WithSiswaLoader(request.Context(), graph.NewSiswaLoader(controller.SiswaService))
+	ctx = graph.WithHistoryLoader(ctx, graph.NewHistoryLoader(controller.AuditService))
+	result := graphql.Do(graphql.Params{
+		Schema:         controller.Schema,
+		RequestString:  graphqlRequest.Query,
+		VariableValues: graphqlRequest.Variables,
+		OperationName:  graphqlRequest.OperationName,
+		Context:        ctx,
+	})
+
+	helper.WriteToResponseBody(writer, result)
+}
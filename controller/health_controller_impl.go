@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+type HealthControllerImpl struct {
+	HealthService service.HealthService
+}
+
+func NewHealthController(healthService service.HealthService) HealthController {
+	return &HealthControllerImpl{
+		HealthService: healthService,
+	}
+}
+
+func (controller *HealthControllerImpl) Liveness(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *HealthControllerImpl) Readiness(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	healthResponse := controller.HealthService.Readiness(request.Context())
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   healthResponse,
+	}
+
+	if !healthResponse.Ready {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		webResponse.Code = http.StatusServiceUnavailable
+		webResponse.Status = "SERVICE UNAVAILABLE"
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+type JobController interface {
+	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Retry(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
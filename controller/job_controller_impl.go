@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+type JobControllerImpl struct {
+	JobService service.JobService
+}
+
+func NewJobController(jobService service.JobService) JobController {
+	return &JobControllerImpl{
+		JobService: jobService,
+	}
+}
+
+func (controller *JobControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	jobResponses := controller.JobService.FindAll(request.Context(), web.JobSearchRequest{
+		Name:   query.Get("name"),
+		Status: query.Get("status"),
+	})
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   jobResponses,
+	}
+
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
+
+func (controller *JobControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "jobId")
+
+	jobResponse := controller.JobService.FindById(request.Context(), int64(id))
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   jobResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// Retry answers 202: the job is queued, not run yet.
+func (controller *JobControllerImpl) Retry(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "jobId")
+
+	jobResponse := controller.JobService.Retry(request.Context(), int64(id))
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusAccepted)
+	webResponse := web.WebResponse{
+		Code:   http.StatusAccepted,
+		Status: "ACCEPTED",
+		Data:   jobResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+type NotificationController interface {
+	Send(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindPreference(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	SavePreference(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
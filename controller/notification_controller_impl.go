@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+type NotificationControllerImpl struct {
+	NotificationService service.NotificationService
+}
+
+func NewNotificationController(notificationService service.NotificationService) NotificationController {
+	return &NotificationControllerImpl{
+		NotificationService: notificationService,
+	}
+}
+
+// Send answers 202: the notifications are queued, not sent yet.
+func (controller *NotificationControllerImpl) Send(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	notificationSendRequest := web.NotificationSendRequest{}
+	helper.ReadFromRequestBody(request, &notificationSendRequest)
+
+	notificationResponses := controller.NotificationService.Send(request.Context(), notificationSendRequest)
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusAccepted)
+	webResponse := web.WebResponse{
+		Code:   http.StatusAccepted,
+		Status: "ACCEPTED",
+		Data:   notificationResponses,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *NotificationControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	notificationSearchRequest := web.NotificationSearchRequest{
+		Status: query.Get("status"),
+	}
+
+	if siswaId := query.Get("siswa_id"); siswaId != "" {
+		id, err := strconv.Atoi(siswaId)
+		if err != nil {
+			panic(exception.NewBadRequestError("siswa_id must be a number"))
+		}
+		notificationSearchRequest.SiswaId = id
+	}
+
+	notificationResponses := controller.NotificationService.FindAll(request.Context(), notificationSearchRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   notificationResponses,
+	}
+
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
+
+func (controller *NotificationControllerImpl) FindPreference(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "siswaId")
+
+	preferenceResponse := controller.NotificationService.FindPreference(request.Context(), id)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   preferenceResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *NotificationControllerImpl) SavePreference(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	preferenceRequest := web.NotificationPreferenceRequest{}
+	helper.ReadFromRequestBody(request, &preferenceRequest)
+
+	preferenceRequest.SiswaId = intParam(params, "siswaId")
+
+	preferenceResponse := controller.NotificationService.SavePreference(request.Context(), preferenceRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   preferenceResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
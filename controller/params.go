@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/julienschmidt/httprouter"
+	"strconv"
+)
+
+// intParam reads a numeric path parameter such as :siswaId, rejecting
+// anything else with 400 instead of letting strconv fail with a 500.
+func intParam(params httprouter.Params, name string) int {
+	value := params.ByName(name)
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		panic(exception.NewBadRequestError(name + " must be a number, got \"" + value + "\""))
+	}
+	return id
+}
@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+type PendaftarController interface {
+	Register(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UploadDokumen(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindOwn(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAnnounced(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindDokumen(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Verify(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Score(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Reject(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Select(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Announce(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ReRegister(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
@@ -0,0 +1,225 @@
+package controller
+
+import (
+	"errors"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/ppdb"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// PendaftarTokenHeader carries the token a pendaftar got when registering.
+const PendaftarTokenHeader = "X-Ppdb-Token"
+
+type PendaftarControllerImpl struct {
+	PendaftarService service.PendaftarService
+}
+
+func NewPendaftarController(pendaftarService service.PendaftarService) PendaftarController {
+	return &PendaftarControllerImpl{
+		PendaftarService: pendaftarService,
+	}
+}
+
+func (controller *PendaftarControllerImpl) Register(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	pendaftarCreateRequest := web.PendaftarCreateRequest{}
+	helper.ReadFromRequestBody(request, &pendaftarCreateRequest)
+
+	pendaftarResponse := controller.PendaftarService.Register(request.Context(), pendaftarCreateRequest)
+	writer.Header().Set("Location", "/api/v2/ppdb/pendaftars/"+strconv.Itoa(pendaftarResponse.Id))
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	webResponse := web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   pendaftarResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// UploadDokumen takes the file itself as the body, with its Content-Type.
+func (controller *PendaftarControllerImpl) UploadDokumen(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "pendaftarId")
+	jenis := params.ByName("jenis")
+	if !ppdb.IsDokumen(jenis) {
+		panic(exception.NewNotFoundError("dokumen " + jenis + " is not known"))
+	}
+
+	contentType, ok := ppdb.DokumenContentType(request.Header.Get("Content-Type"))
+	if !ok {
+		panic(helper.RequestBodyError{Code: http.StatusUnsupportedMediaType, Message: "Content-Type must be application/pdf, image/jpeg or image/png"})
+	}
+
+	isi, err := io.ReadAll(request.Body)
+	if errors.Is(err, helper.ErrRequestBodyTooLarge) {
+		panic(err)
+	}
+	helper.PanicIfError(err)
+	if len(isi) == 0 {
+		panic(helper.RequestBodyError{Code: http.StatusBadRequest, Message: "request body is empty"})
+	}
+
+	dokumenResponse := controller.PendaftarService.UploadDokumen(request.Context(), web.DokumenUploadRequest{
+		PendaftarId: id,
+		Token:       request.Header.Get(PendaftarTokenHeader),
+		Jenis:       jenis,
+		ContentType: contentType,
+		Isi:         isi,
+	})
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   dokumenResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *PendaftarControllerImpl) FindOwn(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "pendaftarId")
+
+	pendaftarResponse := controller.PendaftarService.FindOwn(request.Context(), id, request.Header.Get(PendaftarTokenHeader))
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   pendaftarResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *PendaftarControllerImpl) FindAnnounced(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	pengumumanResponses := controller.PendaftarService.FindAnnounced(request.Context())
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   pengumumanResponses,
+	}
+
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
+
+func (controller *PendaftarControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "pendaftarId")
+
+	pendaftarResponse := controller.PendaftarService.FindById(request.Context(), id)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   pendaftarResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *PendaftarControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	pendaftarResponses := controller.PendaftarService.FindAll(request.Context(), web.PendaftarSearchRequest{
+		Jalur:  query.Get("jalur"),
+		Status: query.Get("status"),
+	})
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   pendaftarResponses,
+	}
+
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
+
+// FindDokumen answers with the file as it was uploaded.
+func (controller *PendaftarControllerImpl) FindDokumen(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "pendaftarId")
+
+	dokumenResponse := controller.PendaftarService.FindDokumen(request.Context(), id, params.ByName("jenis"))
+	writer.Header().Set("Content-Type", dokumenResponse.ContentType)
+	writer.Header().Set("Content-Length", strconv.Itoa(len(dokumenResponse.Isi)))
+	_, err := writer.Write(dokumenResponse.Isi)
+	helper.PanicIfError(err)
+}
+
+func (controller *PendaftarControllerImpl) Verify(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "pendaftarId")
+
+	pendaftarResponse := controller.PendaftarService.Verify(request.Context(), id)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   pendaftarResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *PendaftarControllerImpl) Score(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	pendaftarScoreRequest := web.PendaftarScoreRequest{}
+	helper.ReadFromRequestBody(request, &pendaftarScoreRequest)
+
+	pendaftarScoreRequest.Id = intParam(params, "pendaftarId")
+
+	pendaftarResponse := controller.PendaftarService.Score(request.Context(), pendaftarScoreRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   pendaftarResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *PendaftarControllerImpl) Reject(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	pendaftarRejectRequest := web.PendaftarRejectRequest{}
+	helper.ReadFromRequestBody(request, &pendaftarRejectRequest)
+
+	pendaftarRejectRequest.Id = intParam(params, "pendaftarId")
+
+	pendaftarResponse := controller.PendaftarService.Reject(request.Context(), pendaftarRejectRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   pendaftarResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *PendaftarControllerImpl) Select(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	pendaftarResponses := controller.PendaftarService.Select(request.Context())
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   pendaftarResponses,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *PendaftarControllerImpl) Announce(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	pendaftarResponses := controller.PendaftarService.Announce(request.Context())
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   pendaftarResponses,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *PendaftarControllerImpl) ReRegister(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "pendaftarId")
+
+	pendaftarResponse := controller.PendaftarService.ReRegister(request.Context(), id)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   pendaftarResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+type SekolahControllerImpl struct {
+	SekolahService service.SekolahService
+}
+
+func NewSekolahController(sekolahService service.SekolahService) SekolahController {
+	return &SekolahControllerImpl{
+		SekolahService: sekolahService,
+	}
+}
+
+func (controller *SekolahControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	sekolahCreateRequest := web.SekolahCreateRequest{}
+	helper.ReadFromRequestBody(request, &sekolahCreateRequest)
+
+	sekolahResponse := controller.SekolahService.Create(request.Context(), sekolahCreateRequest)
+	writer.Header().Set("Location", "/api/v2/sekolahs/"+strconv.Itoa(sekolahResponse.Id))
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	webResponse := web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   sekolahResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *SekolahControllerImpl) Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	sekolahUpdateRequest := web.SekolahUpdateRequest{}
+	helper.ReadFromRequestBody(request, &sekolahUpdateRequest)
+
+	sekolahUpdateRequest.Id = intParam(params, "sekolahId")
+
+	sekolahResponse := controller.SekolahService.Update(request.Context(), sekolahUpdateRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   sekolahResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *SekolahControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "sekolahId")
+
+	sekolahResponse := controller.SekolahService.FindById(request.Context(), id)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   sekolahResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *SekolahControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	sekolahResponses := controller.SekolahService.FindAll(request.Context())
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   sekolahResponses,
+	}
+
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
+
+// FindCurrent shows the school the request works on, which users of any
+// school may read.
+func (controller *SekolahControllerImpl) FindCurrent(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	sekolahResponse := controller.SekolahService.FindCurrent(request.Context())
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   sekolahResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
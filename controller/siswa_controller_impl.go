@@ -6,7 +6,6 @@ import (
 	"github.com/Arraf18/go-sisko/service"
 	"github.com/julienschmidt/httprouter"
 	"net/http"
-	"strconv"
 )
 
 type SiswaControllerImpl struct {
@@ -24,6 +23,7 @@ func (controller *SiswaControllerImpl) Create(writer http.ResponseWriter, reques
 	helper.ReadFromRequestBody(request, &siswaCreateRequest)
 
 	siswaResponse := controller.SiswaService.Create(request.Context(), siswaCreateRequest)
+	writer.Header().Set("ETag", helper.ToETag(siswaResponse.Version))
 	webResponse := web.WebResponse{
 		Code:   200,
 		Status: "OK",
@@ -37,28 +37,40 @@ func (controller *SiswaControllerImpl) Update(writer http.ResponseWriter, reques
 	siswaUpdateRequest := web.SiswaUpdateRequest{}
 	helper.ReadFromRequestBody(request, &siswaUpdateRequest)
 
-	siswaId := params.ByName("siswaId")
-	id, err := strconv.Atoi(siswaId)
-	helper.PanicIfError(err)
+	id := intParam(params, "siswaId")
 
 	siswaUpdateRequest.Id = id
+	siswaUpdateRequest.Version = helper.FromETag(request.Header.Get("If-Match"))
 
-	categoryResponse := controller.SiswaService.Update(request.Context(), siswaUpdateRequest)
+	siswaResponse := controller.SiswaService.Update(request.Context(), siswaUpdateRequest)
+	writer.Header().Set("ETag", helper.ToETag(siswaResponse.Version))
 	webResponse := web.WebResponse{
 		Code:   200,
 		Status: "OK",
-		Data:   categoryResponse,
+		Data:   siswaResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *SiswaControllerImpl) Patch(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	siswaResponse := patchSiswa(controller.SiswaService, request, params)
+	writer.Header().Set("ETag", helper.ToETag(siswaResponse.Version))
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   siswaResponse,
 	}
 
 	helper.WriteToResponseBody(writer, webResponse)
 }
 
 func (controller *SiswaControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	siswaId := params.ByName("siswaId")
-	id, err := strconv.Atoi(siswaId)
-	helper.PanicIfError(err)
+	id := intParam(params, "siswaId")
+
+	version := helper.FromETag(request.Header.Get("If-Match"))
 
-	controller.SiswaService.Delete(request.Context(), id)
+	controller.SiswaService.Delete(request.Context(), id, version)
 	webResponse := web.WebResponse{
 		Code:   200,
 		Status: "OK",
@@ -67,12 +79,37 @@ func (controller *SiswaControllerImpl) Delete(writer http.ResponseWriter, reques
 	helper.WriteToResponseBody(writer, webResponse)
 }
 
+func (controller *SiswaControllerImpl) Batch(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	siswaBatchRequest := web.SiswaBatchRequest{}
+	helper.ReadFromRequestBody(request, &siswaBatchRequest)
+
+	siswaBatchResponse := controller.SiswaService.Batch(request.Context(), siswaBatchRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   siswaBatchResponse,
+	}
+
+	if !siswaBatchResponse.Committed {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusUnprocessableEntity)
+		webResponse.Code = http.StatusUnprocessableEntity
+		webResponse.Status = "UNPROCESSABLE ENTITY"
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
 func (controller *SiswaControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	siswaId := params.ByName("siswaId")
-	id, err := strconv.Atoi(siswaId)
-	helper.PanicIfError(err)
+	id := intParam(params, "siswaId")
 
 	siswaResponse := controller.SiswaService.FindById(request.Context(), id)
+	writer.Header().Set("ETag", helper.ToETag(siswaResponse.Version))
+	if helper.MatchETag(request.Header.Get("If-None-Match"), siswaResponse.Version) {
+		writer.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	webResponse := web.WebResponse{
 		Code:   200,
 		Status: "OK",
@@ -90,5 +127,30 @@ func (controller *SiswaControllerImpl) FindAll(writer http.ResponseWriter, reque
 		Data:   siswaResponses,
 	}
 
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
+
+func (controller *SiswaControllerImpl) FindTrash(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	siswaResponses := controller.SiswaService.FindTrash(request.Context())
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   siswaResponses,
+	}
+
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
+
+func (controller *SiswaControllerImpl) Restore(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "siswaId")
+
+	siswaResponse := controller.SiswaService.Restore(request.Context(), id)
+	writer.Header().Set("ETag", helper.ToETag(siswaResponse.Version))
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   siswaResponse,
+	}
+
 	helper.WriteToResponseBody(writer, webResponse)
 }
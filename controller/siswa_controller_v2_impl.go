@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// SiswaControllerV2Impl serves /api/v2: snake_case fields throughout, 201 and
+// a Location header on create, 204 on delete. Errors are turned into problem
+// details by exception.ErrorHandler based on the path.
+type SiswaControllerV2Impl struct {
+	SiswaService service.SiswaService
+}
+
+func NewSiswaControllerV2(siswaService service.SiswaService) SiswaController {
+	return &SiswaControllerV2Impl{
+		SiswaService: siswaService,
+	}
+}
+
+func (controller *SiswaControllerV2Impl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	siswaCreateRequest := web.SiswaCreateRequest{}
+	helper.ReadFromRequestBody(request, &siswaCreateRequest)
+
+	siswaResponse := controller.SiswaService.Create(request.Context(), siswaCreateRequest)
+	writer.Header().Set("ETag", helper.ToETag(siswaResponse.Version))
+	writer.Header().Set("Location", "/api/v2/siswas/"+strconv.Itoa(siswaResponse.Id))
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	webResponse := web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   helper.ToSiswaResponseV2(siswaResponse),
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *SiswaControllerV2Impl) Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	siswaUpdateRequest := web.SiswaUpdateRequest{}
+	helper.ReadFromRequestBody(request, &siswaUpdateRequest)
+
+	id := intParam(params, "siswaId")
+
+	siswaUpdateRequest.Id = id
+	siswaUpdateRequest.Version = helper.FromETag(request.Header.Get("If-Match"))
+
+	siswaResponse := controller.SiswaService.Update(request.Context(), siswaUpdateRequest)
+	writer.Header().Set("ETag", helper.ToETag(siswaResponse.Version))
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   helper.ToSiswaResponseV2(siswaResponse),
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *SiswaControllerV2Impl) Patch(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	siswaResponse := patchSiswa(controller.SiswaService, request, params)
+	writer.Header().Set("ETag", helper.ToETag(siswaResponse.Version))
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   helper.ToSiswaResponseV2(siswaResponse),
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *SiswaControllerV2Impl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "siswaId")
+
+	version := helper.FromETag(request.Header.Get("If-Match"))
+
+	controller.SiswaService.Delete(request.Context(), id, version)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (controller *SiswaControllerV2Impl) Batch(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	siswaBatchRequest := web.SiswaBatchRequest{}
+	helper.ReadFromRequestBody(request, &siswaBatchRequest)
+
+	siswaBatchResponse := helper.ToSiswaBatchResponseV2(controller.SiswaService.Batch(request.Context(), siswaBatchRequest))
+	if !siswaBatchResponse.Committed {
+		problemResponse := web.ProblemResponse{
+			Type:     "about:blank",
+			Title:    http.StatusText(http.StatusUnprocessableEntity),
+			Status:   http.StatusUnprocessableEntity,
+			Detail:   "the batch was rolled back because an operation failed",
+			Instance: request.URL.RequestURI(),
+			Errors:   siswaBatchResponse.Results,
+		}
+		writer.Header().Set("Content-Type", "application/problem+json")
+		writer.WriteHeader(http.StatusUnprocessableEntity)
+		helper.WriteToResponseBodyAs(writer, "application/problem+json", problemResponse)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   siswaBatchResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *SiswaControllerV2Impl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "siswaId")
+
+	siswaResponse := controller.SiswaService.FindById(request.Context(), id)
+	writer.Header().Set("ETag", helper.ToETag(siswaResponse.Version))
+	if helper.MatchETag(request.Header.Get("If-None-Match"), siswaResponse.Version) {
+		writer.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   helper.ToSiswaResponseV2(siswaResponse),
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *SiswaControllerV2Impl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	siswaResponses := controller.SiswaService.FindAll(request.Context())
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   helper.ToSiswaResponsesV2(siswaResponses),
+	}
+
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
+
+func (controller *SiswaControllerV2Impl) FindTrash(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	siswaResponses := controller.SiswaService.FindTrash(request.Context())
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   helper.ToSiswaResponsesV2(siswaResponses),
+	}
+
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
+
+func (controller *SiswaControllerV2Impl) Restore(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "siswaId")
+
+	siswaResponse := controller.SiswaService.Restore(request.Context(), id)
+	writer.Header().Set("ETag", helper.ToETag(siswaResponse.Version))
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   helper.ToSiswaResponseV2(siswaResponse),
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/pb"
+	"github.com/Arraf18/go-sisko/service"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SiswaGrpcServer serves pb.SiswaService from the same SiswaService as the
+// REST controllers. Like them it lets the service panic; the interceptor
+// turns that into a status.
+type SiswaGrpcServer struct {
+	pb.UnimplementedSiswaServiceServer
+	SiswaService service.SiswaService
+}
+
+func NewSiswaGrpcServer(siswaService service.SiswaService) *SiswaGrpcServer {
+	return &SiswaGrpcServer{
+		SiswaService: siswaService,
+	}
+}
+
+func (server *SiswaGrpcServer) CreateSiswa(ctx context.Context, request *pb.CreateSiswaRequest) (*pb.Siswa, error) {
+	siswaResponse := server.SiswaService.Create(ctx, helper.FromSiswaData(request.GetData()))
+	return helper.ToSiswaMessage(siswaResponse), nil
+}
+
+func (server *SiswaGrpcServer) UpdateSiswa(ctx context.Context, request *pb.UpdateSiswaRequest) (*pb.Siswa, error) {
+	siswaResponse := server.SiswaService.Update(ctx, helper.FromUpdateSiswaRequest(request))
+	return helper.ToSiswaMessage(siswaResponse), nil
+}
+
+func (server *SiswaGrpcServer) DeleteSiswa(ctx context.Context, request *pb.DeleteSiswaRequest) (*emptypb.Empty, error) {
+	server.SiswaService.Delete(ctx, int(request.GetId()), int(request.GetVersion()))
+	return &emptypb.Empty{}, nil
+}
+
+func (server *SiswaGrpcServer) BatchSiswa(ctx context.Context, request *pb.BatchSiswaRequest) (*pb.BatchSiswaResponse, error) {
+	siswaBatchResponse := server.SiswaService.Batch(ctx, helper.FromBatchSiswaRequest(request))
+	return helper.ToBatchSiswaResponseMessage(siswaBatchResponse), nil
+}
+
+func (server *SiswaGrpcServer) GetSiswa(ctx context.Context, request *pb.GetSiswaRequest) (*pb.Siswa, error) {
+	siswaResponse := server.SiswaService.FindById(ctx, int(request.GetId()))
+	return helper.ToSiswaMessage(siswaResponse), nil
+}
+
+func (server *SiswaGrpcServer) ListSiswa(request *pb.ListSiswaRequest, stream pb.SiswaService_ListSiswaServer) error {
+	var siswaResponses []web.SiswaResponse
+	if request.GetTrash() {
+		siswaResponses = server.SiswaService.FindTrash(stream.Context())
+	} else {
+		siswaResponses = server.SiswaService.FindAll(stream.Context())
+	}
+
+	for _, siswaResponse := range siswaResponses {
+		err := stream.Send(helper.ToSiswaMessage(siswaResponse))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (server *SiswaGrpcServer) RestoreSiswa(ctx context.Context, request *pb.RestoreSiswaRequest) (*pb.Siswa, error) {
+	siswaResponse := server.SiswaService.Restore(ctx, int(request.GetId()))
+	return helper.ToSiswaMessage(siswaResponse), nil
+}
+
+func (server *SiswaGrpcServer) GetSiswaStatistics(ctx context.Context, request *emptypb.Empty) (*pb.SiswaStatistics, error) {
+	statistics := server.SiswaService.Statistics(ctx)
+	return helper.ToSiswaStatisticsMessage(statistics), nil
+}
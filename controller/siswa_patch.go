@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+// patchSiswa reads a SiswaPatchRequest and turns it into a full update of
+// the current siswa. The If-Match version still guards the update, so a
+// change made after the siswa was read here fails with 412.
+func patchSiswa(siswaService service.SiswaService, request *http.Request, params httprouter.Params) web.SiswaResponse {
+	patch := web.SiswaPatchRequest{}
+	helper.ReadFromRequestBody(request, &patch)
+
+	id := intParam(params, "siswaId")
+	current := siswaService.FindById(request.Context(), id)
+
+	siswaUpdateRequest := web.SiswaUpdateRequest{
+		Id:            id,
+		Nama:          patched(patch.Nama, current.Nama),
+		Alamat:        patched(patch.Alamat, current.Alamat),
+		TanggalLahir:  patched(patch.TanggalLahir, current.TanggalLahir),
+		TempatLahir:   patched(patch.TempatLahir, current.TempatLahir),
+		JenisKelamin:  patched(patch.JenisKelamin, current.JenisKelamin),
+		Agama:         patched(patch.Agama, current.Agama),
+		GolonganDarah: patched(patch.GolonganDarah, current.GolonganDarah),
+		NoTelepon:     patched(patch.NoTelepon, current.NoTelepon),
+		Version:       helper.FromETag(request.Header.Get("If-Match")),
+	}
+	return siswaService.Update(request.Context(), siswaUpdateRequest)
+}
+
+func patched(value *string, current string) string {
+	if value == nil {
+		return current
+	}
+	return *value
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+)
+
+// WebhookControllerImpl serves /api/v2/webhooks, in the conventions of the
+// rest of /api/v2.
+type WebhookControllerImpl struct {
+	WebhookService service.WebhookService
+}
+
+func NewWebhookController(webhookService service.WebhookService) WebhookController {
+	return &WebhookControllerImpl{
+		WebhookService: webhookService,
+	}
+}
+
+func (controller *WebhookControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webhookCreateRequest := web.WebhookCreateRequest{}
+	helper.ReadFromRequestBody(request, &webhookCreateRequest)
+
+	webhookResponse := controller.WebhookService.Create(request.Context(), webhookCreateRequest)
+	writer.Header().Set("Location", "/api/v2/webhooks/"+strconv.Itoa(webhookResponse.Id))
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	webResponse := web.WebResponse{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   webhookResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *WebhookControllerImpl) Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webhookUpdateRequest := web.WebhookUpdateRequest{}
+	helper.ReadFromRequestBody(request, &webhookUpdateRequest)
+
+	webhookUpdateRequest.Id = intParam(params, "webhookId")
+
+	webhookResponse := controller.WebhookService.Update(request.Context(), webhookUpdateRequest)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *WebhookControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "webhookId")
+
+	controller.WebhookService.Delete(request.Context(), id)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (controller *WebhookControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "webhookId")
+
+	webhookResponse := controller.WebhookService.FindById(request.Context(), id)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *WebhookControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	webhookResponses := controller.WebhookService.FindAll(request.Context())
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   webhookResponses,
+	}
+
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
+
+func (controller *WebhookControllerImpl) FindDeliveries(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "webhookId")
+
+	deliveryResponses := controller.WebhookService.FindDeliveries(request.Context(), id)
+	webResponse := web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data:   deliveryResponses,
+	}
+
+	helper.WriteListToResponseBody(writer, request, webResponse)
+}
+
+// Replay answers 202: the delivery is queued, not sent yet.
+func (controller *WebhookControllerImpl) Replay(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := intParam(params, "webhookId")
+	deliveryId := intParam(params, "deliveryId")
+
+	deliveryResponse := controller.WebhookService.Replay(request.Context(), id, int64(deliveryId))
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusAccepted)
+	webResponse := web.WebResponse{
+		Code:   http.StatusAccepted,
+		Status: "ACCEPTED",
+		Data:   deliveryResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
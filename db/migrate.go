@@ -0,0 +1,40 @@
+package db
+
+import (
+	"database/sql"
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang-migrate/migrate/v4"
+	migratemysql "github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// NewMigrate applies the embedded Migrations to the database at
+// dataSourceName with golang-migrate, which keeps the schema_migrations table
+// read by the readiness probe. It opens its own connection with
+// multiStatements, since golang-migrate sends each file as one statement;
+// closing the returned Migrate closes it.
+func NewMigrate(dataSourceName string) (*migrate.Migrate, error) {
+	source, err := iofs.New(Migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := mysql.ParseDSN(dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	config.MultiStatements = true
+
+	sqlDB, err := sql.Open("mysql", config.FormatDSN())
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := migratemysql.WithInstance(sqlDB, &migratemysql.Config{})
+	if err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+
+	return migrate.NewWithInstance("iofs", source, "mysql", driver)
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"embed"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// Migrations holds the SQL files in golang-migrate layout
+// (<version>_<name>.up.sql / .down.sql), embedded so the binary knows which
+// schema version it expects.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// LatestVersion returns the highest migration version shipped with the binary.
+func LatestVersion() int {
+	entries, err := fs.ReadDir(Migrations, "migrations")
+	if err != nil {
+		panic(err)
+	}
+
+	latest := 0
+	for _, entry := range entries {
+		prefix := strings.SplitN(entry.Name(), "_", 2)[0]
+		version, err := strconv.Atoi(prefix)
+		if err == nil && version > latest {
+			latest = version
+		}
+	}
+	return latest
+}
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS siswa
+(
+    id             INT          NOT NULL AUTO_INCREMENT,
+    nama           VARCHAR(100) NOT NULL,
+    alamat         VARCHAR(200) NOT NULL,
+    tanggal_lahir  VARCHAR(36)  NOT NULL,
+    tempat_lahir   VARCHAR(100) NOT NULL,
+    jenis_kelamin  VARCHAR(10)  NOT NULL,
+    agama          VARCHAR(20)  NOT NULL,
+    golongan_darah VARCHAR(2)   NOT NULL,
+    no_telepon     VARCHAR(20)  NOT NULL,
+    PRIMARY KEY (id)
+) ENGINE = InnoDB;
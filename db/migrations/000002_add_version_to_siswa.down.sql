@@ -0,0 +1,2 @@
+ALTER TABLE siswa
+    DROP COLUMN version;
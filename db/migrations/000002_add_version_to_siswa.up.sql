@@ -0,0 +1,2 @@
+ALTER TABLE siswa
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+ALTER TABLE siswa
+    DROP INDEX idx_siswa_deleted_at,
+    DROP COLUMN deleted_by,
+    DROP COLUMN deleted_at;
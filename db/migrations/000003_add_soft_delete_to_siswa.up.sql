@@ -0,0 +1,4 @@
+ALTER TABLE siswa
+    ADD COLUMN deleted_at DATETIME    NULL,
+    ADD COLUMN deleted_by VARCHAR(100) NULL,
+    ADD INDEX idx_siswa_deleted_at (deleted_at);
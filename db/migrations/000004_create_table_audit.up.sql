@@ -0,0 +1,15 @@
+CREATE TABLE audit
+(
+    id         BIGINT       NOT NULL AUTO_INCREMENT,
+    entity     VARCHAR(50)  NOT NULL,
+    entity_id  INT          NOT NULL,
+    action     VARCHAR(20)  NOT NULL,
+    actor      VARCHAR(100) NOT NULL,
+    request_id VARCHAR(64)  NOT NULL,
+    changes    JSON         NOT NULL,
+    created_at DATETIME     NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_audit_entity (entity, entity_id),
+    INDEX idx_audit_actor (actor),
+    INDEX idx_audit_created_at (created_at)
+) ENGINE = InnoDB;
@@ -0,0 +1,12 @@
+CREATE TABLE idempotency_key
+(
+    actor         VARCHAR(100) NOT NULL,
+    idempotency   VARCHAR(255) NOT NULL,
+    fingerprint   CHAR(64)     NOT NULL,
+    status_code   INT          NOT NULL DEFAULT 0,
+    content_type  VARCHAR(100) NOT NULL DEFAULT '',
+    response_body MEDIUMBLOB   NULL,
+    created_at    DATETIME     NOT NULL,
+    PRIMARY KEY (actor, idempotency),
+    INDEX idx_idempotency_key_created_at (created_at)
+) ENGINE = InnoDB;
@@ -0,0 +1,9 @@
+CREATE TABLE user_account
+(
+    id         INT          NOT NULL AUTO_INCREMENT,
+    username   VARCHAR(100) NOT NULL,
+    nama       VARCHAR(100) NOT NULL,
+    created_at DATETIME     NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_user_account_username (username)
+) ENGINE = InnoDB;
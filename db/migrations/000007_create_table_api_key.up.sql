@@ -0,0 +1,13 @@
+CREATE TABLE api_key
+(
+    id         INT          NOT NULL AUTO_INCREMENT,
+    user_id    INT          NOT NULL,
+    name       VARCHAR(100) NOT NULL,
+    prefix     CHAR(8)      NOT NULL,
+    key_hash   CHAR(64)     NOT NULL,
+    created_at DATETIME     NOT NULL,
+    revoked_at DATETIME     NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_api_key_key_hash (key_hash),
+    CONSTRAINT fk_api_key_user_account FOREIGN KEY (user_id) REFERENCES user_account (id)
+) ENGINE = InnoDB;
@@ -0,0 +1,17 @@
+CREATE TABLE outbox_event
+(
+    id              BIGINT       NOT NULL AUTO_INCREMENT,
+    aggregate_type  VARCHAR(50)  NOT NULL,
+    aggregate_id    INT          NOT NULL,
+    event_type      VARCHAR(50)  NOT NULL,
+    payload         JSON         NOT NULL,
+    actor           VARCHAR(100) NOT NULL,
+    request_id      VARCHAR(64)  NOT NULL,
+    created_at      DATETIME     NOT NULL,
+    published_at    DATETIME     NULL,
+    attempts        INT          NOT NULL DEFAULT 0,
+    last_error      TEXT         NULL,
+    next_attempt_at DATETIME     NULL,
+    PRIMARY KEY (id),
+    INDEX idx_outbox_event_published_at (published_at, id)
+) ENGINE = InnoDB;
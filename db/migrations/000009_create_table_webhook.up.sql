@@ -0,0 +1,34 @@
+CREATE TABLE webhook_subscription
+(
+    id                   INT          NOT NULL AUTO_INCREMENT,
+    url                  VARCHAR(500) NOT NULL,
+    event_types          JSON         NOT NULL,
+    secret               VARCHAR(100) NOT NULL,
+    active               BOOLEAN      NOT NULL DEFAULT TRUE,
+    consecutive_failures INT          NOT NULL DEFAULT 0,
+    disabled_at          DATETIME     NULL,
+    created_at           DATETIME     NOT NULL,
+    updated_at           DATETIME     NOT NULL,
+    PRIMARY KEY (id)
+) ENGINE = InnoDB;
+
+CREATE TABLE webhook_delivery
+(
+    id              BIGINT      NOT NULL AUTO_INCREMENT,
+    subscription_id INT         NOT NULL,
+    event_id        BIGINT      NOT NULL,
+    event_type      VARCHAR(50) NOT NULL,
+    aggregate_id    INT         NOT NULL,
+    payload         JSON        NOT NULL,
+    status          VARCHAR(20) NOT NULL,
+    attempts        INT         NOT NULL DEFAULT 0,
+    response_status INT         NULL,
+    last_error      TEXT        NULL,
+    next_attempt_at DATETIME    NULL,
+    created_at      DATETIME    NOT NULL,
+    delivered_at    DATETIME    NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_webhook_delivery_event (subscription_id, event_id),
+    INDEX idx_webhook_delivery_status (status, id),
+    CONSTRAINT fk_webhook_delivery_subscription FOREIGN KEY (subscription_id) REFERENCES webhook_subscription (id) ON DELETE CASCADE
+) ENGINE = InnoDB;
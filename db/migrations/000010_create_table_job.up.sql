@@ -0,0 +1,20 @@
+CREATE TABLE job
+(
+    id           BIGINT       NOT NULL AUTO_INCREMENT,
+    name         VARCHAR(100) NOT NULL,
+    payload      JSON         NULL,
+    unique_key   VARCHAR(150) NULL,
+    status       VARCHAR(20)  NOT NULL,
+    attempts     INT          NOT NULL DEFAULT 0,
+    max_attempts INT          NOT NULL,
+    run_at       DATETIME     NOT NULL,
+    locked_by    VARCHAR(100) NULL,
+    locked_at    DATETIME     NULL,
+    last_error   TEXT         NULL,
+    created_at   DATETIME     NOT NULL,
+    started_at   DATETIME     NULL,
+    finished_at  DATETIME     NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_job_unique_key (unique_key),
+    INDEX idx_job_status_run_at (status, run_at)
+) ENGINE = InnoDB;
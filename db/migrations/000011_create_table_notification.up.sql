@@ -0,0 +1,34 @@
+CREATE TABLE notification_preference
+(
+    siswa_id    INT          NOT NULL,
+    nama_wali   VARCHAR(100) NOT NULL,
+    email       VARCHAR(200) NULL,
+    no_telepon  VARCHAR(20)  NULL,
+    no_whatsapp VARCHAR(20)  NULL,
+    channels    JSON         NOT NULL,
+    language    CHAR(2)      NOT NULL,
+    updated_at  DATETIME     NOT NULL,
+    PRIMARY KEY (siswa_id)
+) ENGINE = InnoDB;
+
+CREATE TABLE notification
+(
+    id         BIGINT       NOT NULL AUTO_INCREMENT,
+    siswa_id   INT          NOT NULL,
+    template   VARCHAR(50)  NOT NULL,
+    language   CHAR(2)      NOT NULL,
+    subject    VARCHAR(255) NOT NULL,
+    body       TEXT         NOT NULL,
+    routes     JSON         NOT NULL,
+    status     VARCHAR(20)  NOT NULL,
+    channel    VARCHAR(20)  NULL,
+    recipient  VARCHAR(200) NULL,
+    attempts   INT          NOT NULL DEFAULT 0,
+    last_error TEXT         NULL,
+    created_by VARCHAR(100) NOT NULL,
+    created_at DATETIME     NOT NULL,
+    sent_at    DATETIME     NULL,
+    PRIMARY KEY (id),
+    INDEX idx_notification_siswa_id (siswa_id, id),
+    INDEX idx_notification_status (status, id)
+) ENGINE = InnoDB;
@@ -0,0 +1,12 @@
+ALTER TABLE user_account
+    DROP COLUMN sekolah_id;
+
+ALTER TABLE audit
+    DROP INDEX idx_audit_sekolah_id,
+    DROP COLUMN sekolah_id;
+
+ALTER TABLE siswa
+    DROP INDEX idx_siswa_sekolah_id,
+    DROP COLUMN sekolah_id;
+
+DROP TABLE sekolah;
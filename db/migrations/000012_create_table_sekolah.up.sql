@@ -0,0 +1,33 @@
+CREATE TABLE sekolah
+(
+    id          INT          NOT NULL AUTO_INCREMENT,
+    kode        VARCHAR(50)  NOT NULL,
+    nama        VARCHAR(200) NOT NULL,
+    bahasa      CHAR(2)      NOT NULL,
+    kuota_siswa INT          NOT NULL DEFAULT 0,
+    created_at  DATETIME     NOT NULL,
+    updated_at  DATETIME     NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_sekolah_kode (kode)
+) ENGINE = InnoDB;
+
+INSERT INTO sekolah(id, kode, nama, bahasa, created_at)
+VALUES (1, 'default', 'Sekolah', 'id', NOW());
+
+ALTER TABLE siswa
+    ADD COLUMN sekolah_id INT NOT NULL DEFAULT 1,
+    ADD INDEX idx_siswa_sekolah_id (sekolah_id, deleted_at);
+
+ALTER TABLE siswa
+    ALTER COLUMN sekolah_id DROP DEFAULT;
+
+ALTER TABLE audit
+    ADD COLUMN sekolah_id INT NULL,
+    ADD INDEX idx_audit_sekolah_id (sekolah_id, created_at);
+
+UPDATE audit
+SET sekolah_id = 1
+WHERE entity = 'siswa';
+
+ALTER TABLE user_account
+    ADD COLUMN sekolah_id INT NULL;
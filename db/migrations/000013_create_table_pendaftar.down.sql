@@ -0,0 +1,7 @@
+DROP TABLE pendaftar_dokumen;
+DROP TABLE pendaftar;
+
+ALTER TABLE sekolah
+    DROP COLUMN kuota_afirmasi,
+    DROP COLUMN kuota_prestasi,
+    DROP COLUMN kuota_zonasi;
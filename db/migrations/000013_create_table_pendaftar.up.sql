@@ -0,0 +1,43 @@
+ALTER TABLE sekolah
+    ADD COLUMN kuota_zonasi   INT NOT NULL DEFAULT 0,
+    ADD COLUMN kuota_prestasi INT NOT NULL DEFAULT 0,
+    ADD COLUMN kuota_afirmasi INT NOT NULL DEFAULT 0;
+
+CREATE TABLE pendaftar
+(
+    id             INT          NOT NULL AUTO_INCREMENT,
+    sekolah_id     INT          NOT NULL,
+    token_hash     CHAR(64)     NOT NULL,
+    jalur          VARCHAR(20)  NOT NULL,
+    status         VARCHAR(20)  NOT NULL,
+    nama           VARCHAR(100) NOT NULL,
+    alamat         VARCHAR(200) NOT NULL,
+    tanggal_lahir  VARCHAR(36)  NOT NULL,
+    tempat_lahir   VARCHAR(100) NOT NULL,
+    jenis_kelamin  VARCHAR(10)  NOT NULL,
+    agama          VARCHAR(20)  NOT NULL,
+    golongan_darah VARCHAR(2)   NOT NULL,
+    no_telepon     VARCHAR(20)  NOT NULL,
+    asal_sekolah   VARCHAR(200) NOT NULL,
+    jarak          INT          NOT NULL,
+    nilai          DECIMAL(5, 2) NULL,
+    peringkat      INT          NULL,
+    catatan        VARCHAR(500) NULL,
+    siswa_id       INT          NULL,
+    created_at     DATETIME     NOT NULL,
+    updated_at     DATETIME     NOT NULL,
+    announced_at   DATETIME     NULL,
+    PRIMARY KEY (id),
+    INDEX idx_pendaftar_sekolah_id (sekolah_id, jalur, status)
+) ENGINE = InnoDB;
+
+CREATE TABLE pendaftar_dokumen
+(
+    pendaftar_id INT          NOT NULL,
+    jenis        VARCHAR(30)  NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    ukuran       INT          NOT NULL,
+    isi          MEDIUMBLOB   NOT NULL,
+    uploaded_at  DATETIME     NOT NULL,
+    PRIMARY KEY (pendaftar_id, jenis)
+) ENGINE = InnoDB;
@@ -0,0 +1,2 @@
+ALTER TABLE idempotency_key
+    ADD COLUMN response_headers TEXT NULL AFTER content_type;
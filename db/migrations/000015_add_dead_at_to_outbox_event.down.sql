@@ -0,0 +1,3 @@
+ALTER TABLE outbox_event
+    DROP INDEX idx_outbox_event_aggregate,
+    DROP COLUMN dead_at;
@@ -0,0 +1,3 @@
+ALTER TABLE outbox_event
+    ADD COLUMN dead_at DATETIME NULL AFTER next_attempt_at,
+    ADD INDEX idx_outbox_event_aggregate (aggregate_type, aggregate_id, id);
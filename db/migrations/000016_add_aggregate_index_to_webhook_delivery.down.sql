@@ -0,0 +1,2 @@
+ALTER TABLE webhook_delivery
+    DROP INDEX idx_webhook_delivery_aggregate;
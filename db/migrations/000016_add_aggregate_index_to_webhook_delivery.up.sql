@@ -0,0 +1,2 @@
+ALTER TABLE webhook_delivery
+    ADD INDEX idx_webhook_delivery_aggregate (subscription_id, aggregate_id, id);
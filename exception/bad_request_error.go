@@ -0,0 +1,9 @@
+package exception
+
+type BadRequestError struct {
+	Error string
+}
+
+func NewBadRequestError(error string) BadRequestError {
+	return BadRequestError{Error: error}
+}
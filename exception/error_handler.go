@@ -1,18 +1,75 @@
 package exception
 
 import (
+	"errors"
 	"github.com/Arraf18/go-sisko/helper"
 	"github.com/Arraf18/go-sisko/model/web"
 	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
 	"net/http"
+	"runtime/debug"
+	"strings"
 )
 
 func ErrorHandler(writer http.ResponseWriter, request *http.Request, err interface{}) {
+	logPanic(request, err)
+	WriteError(writer, request, err)
+}
+
+// WriteError sends the response for err without logging a recovered panic,
+// for middleware that rejects a request on purpose such as rate limiting.
+func WriteError(writer http.ResponseWriter, request *http.Request, err interface{}) {
+	writer.Header().Set("Cache-Control", "no-store")
+
+	if UsesProblemDetails(request) {
+		problemError(writer, request, err)
+		return
+	}
+
+	if validationErrors(writer, request, err) {
+		return
+	}
+
+	if requestBodyError(writer, request, err) {
+		return
+	}
 
 	if notFoundError(writer, request, err) {
 		return
 	}
 
+	if badRequestError(writer, request, err) {
+		return
+	}
+
+	if forbiddenError(writer, request, err) {
+		return
+	}
+
+	if conflictError(writer, request, err) {
+		return
+	}
+
+	if unprocessableEntityError(writer, request, err) {
+		return
+	}
+
+	if preconditionFailedError(writer, request, err) {
+		return
+	}
+
+	if preconditionRequiredError(writer, request, err) {
+		return
+	}
+
+	if tooManyRequestsError(writer, request, err) {
+		return
+	}
+
+	if requestEntityTooLargeError(writer, request, err) {
+		return
+	}
+
 	internalServerError(writer, request, err)
 }
 
@@ -35,6 +92,25 @@ func validationErrors(writer http.ResponseWriter, request *http.Request, err int
 	}
 }
 
+func requestBodyError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(helper.RequestBodyError)
+	if ok {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(exception.Code)
+
+		webResponse := web.WebResponse{
+			Code:   exception.Code,
+			Status: strings.ToUpper(http.StatusText(exception.Code)),
+			Data:   exception.Message,
+		}
+
+		helper.WriteToResponseBody(writer, webResponse)
+		return true
+	} else {
+		return false
+	}
+}
+
 func notFoundError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
 	exception, ok := err.(NotFoundError)
 	if ok {
@@ -54,6 +130,158 @@ func notFoundError(writer http.ResponseWriter, request *http.Request, err interf
 	}
 }
 
+func badRequestError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(BadRequestError)
+	if ok {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusBadRequest)
+
+		webResponse := web.WebResponse{
+			Code:   http.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   exception.Error,
+		}
+
+		helper.WriteToResponseBody(writer, webResponse)
+		return true
+	} else {
+		return false
+	}
+}
+
+func forbiddenError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(ForbiddenError)
+	if ok {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusForbidden)
+
+		webResponse := web.WebResponse{
+			Code:   http.StatusForbidden,
+			Status: "FORBIDDEN",
+			Data:   exception.Error,
+		}
+
+		helper.WriteToResponseBody(writer, webResponse)
+		return true
+	} else {
+		return false
+	}
+}
+
+func conflictError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(ConflictError)
+	if ok {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusConflict)
+
+		webResponse := web.WebResponse{
+			Code:   http.StatusConflict,
+			Status: "CONFLICT",
+			Data:   exception.Error,
+		}
+
+		helper.WriteToResponseBody(writer, webResponse)
+		return true
+	} else {
+		return false
+	}
+}
+
+func unprocessableEntityError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(UnprocessableEntityError)
+	if ok {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusUnprocessableEntity)
+
+		webResponse := web.WebResponse{
+			Code:   http.StatusUnprocessableEntity,
+			Status: "UNPROCESSABLE ENTITY",
+			Data:   exception.Error,
+		}
+
+		helper.WriteToResponseBody(writer, webResponse)
+		return true
+	} else {
+		return false
+	}
+}
+
+func preconditionFailedError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(PreconditionFailedError)
+	if ok {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusPreconditionFailed)
+
+		webResponse := web.WebResponse{
+			Code:   http.StatusPreconditionFailed,
+			Status: "PRECONDITION FAILED",
+			Data:   exception.Error,
+		}
+
+		helper.WriteToResponseBody(writer, webResponse)
+		return true
+	} else {
+		return false
+	}
+}
+
+func preconditionRequiredError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(PreconditionRequiredError)
+	if ok {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusPreconditionRequired)
+
+		webResponse := web.WebResponse{
+			Code:   http.StatusPreconditionRequired,
+			Status: "PRECONDITION REQUIRED",
+			Data:   exception.Error,
+		}
+
+		helper.WriteToResponseBody(writer, webResponse)
+		return true
+	} else {
+		return false
+	}
+}
+
+func tooManyRequestsError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(TooManyRequestsError)
+	if ok {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusTooManyRequests)
+
+		webResponse := web.WebResponse{
+			Code:   http.StatusTooManyRequests,
+			Status: "TOO MANY REQUESTS",
+			Data:   exception.Error,
+		}
+
+		helper.WriteToResponseBody(writer, webResponse)
+		return true
+	} else {
+		return false
+	}
+}
+
+func requestEntityTooLargeError(writer http.ResponseWriter, request *http.Request, err interface{}) bool {
+	exception, ok := err.(error)
+	if ok && errors.Is(exception, helper.ErrRequestBodyTooLarge) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusRequestEntityTooLarge)
+
+		webResponse := web.WebResponse{
+			Code:   http.StatusRequestEntityTooLarge,
+			Status: "REQUEST ENTITY TOO LARGE",
+			Data:   exception.Error(),
+		}
+
+		helper.WriteToResponseBody(writer, webResponse)
+		return true
+	} else {
+		return false
+	}
+}
+
 func internalServerError(writer http.ResponseWriter, request *http.Request, err interface{}) {
 	writer.Header().Set("Content-Type", "application/json")
 	writer.WriteHeader(http.StatusInternalServerError)
@@ -66,3 +294,21 @@ func internalServerError(writer http.ResponseWriter, request *http.Request, err
 
 	helper.WriteToResponseBody(writer, webResponse)
 }
+
+// logPanic is called while the panic is still being recovered, so the stack
+// trace points at where it was raised rather than at the handler. Client
+// errors are expected and logged as warnings.
+func logPanic(request *http.Request, err interface{}) {
+	webResponse := ToWebResponse(err)
+	logger := helper.Logger(request.Context()).WithFields(logrus.Fields{
+		"error":  webResponse.Data,
+		"status": webResponse.Code,
+		"stack":  string(debug.Stack()),
+	})
+
+	if webResponse.Code >= http.StatusInternalServerError {
+		logger.Error("recovered panic")
+	} else {
+		logger.Warn("recovered panic")
+	}
+}
@@ -0,0 +1,38 @@
+package exception
+
+import (
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+// grpcCodes translates the statuses of ToWebResponse, so a failure is the same
+// failure over REST and gRPC.
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.Aborted,
+	http.StatusRequestEntityTooLarge: codes.ResourceExhausted,
+	http.StatusUnsupportedMediaType:  codes.InvalidArgument,
+	http.StatusUnprocessableEntity:   codes.FailedPrecondition,
+	http.StatusPreconditionFailed:    codes.FailedPrecondition,
+	http.StatusPreconditionRequired:  codes.FailedPrecondition,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+}
+
+// GrpcCode is the gRPC code for an HTTP status.
+func GrpcCode(httpStatus int) codes.Code {
+	if code, ok := grpcCodes[httpStatus]; ok {
+		return code
+	}
+	return codes.Internal
+}
+
+// ToStatus maps a recovered panic to a gRPC status, see ToWebResponse.
+func ToStatus(err interface{}) *status.Status {
+	webResponse := ToWebResponse(err)
+	return status.New(GrpcCode(webResponse.Code), fmt.Sprint(webResponse.Data))
+}
@@ -0,0 +1,9 @@
+package exception
+
+type PreconditionFailedError struct {
+	Error string
+}
+
+func NewPreconditionFailedError(error string) PreconditionFailedError {
+	return PreconditionFailedError{Error: error}
+}
@@ -0,0 +1,9 @@
+package exception
+
+type PreconditionRequiredError struct {
+	Error string
+}
+
+func NewPreconditionRequiredError(error string) PreconditionRequiredError {
+	return PreconditionRequiredError{Error: error}
+}
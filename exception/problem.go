@@ -0,0 +1,37 @@
+package exception
+
+import (
+	"fmt"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"net/http"
+	"strings"
+)
+
+// UsesProblemDetails reports whether errors for request are answered with
+// application/problem+json instead of the WebResponse envelope.
+func UsesProblemDetails(request *http.Request) bool {
+	return strings.HasPrefix(request.URL.Path, "/api/v2/")
+}
+
+func WriteProblem(writer http.ResponseWriter, request *http.Request, status int, detail interface{}) {
+	problemResponse := web.ProblemResponse{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Instance: request.URL.RequestURI(),
+	}
+	if detail != nil {
+		problemResponse.Detail = fmt.Sprint(detail)
+	}
+
+	writer.Header().Set("Content-Type", "application/problem+json")
+	writer.WriteHeader(status)
+
+	helper.WriteToResponseBodyAs(writer, "application/problem+json", problemResponse)
+}
+
+func problemError(writer http.ResponseWriter, request *http.Request, err interface{}) {
+	webResponse := ToWebResponse(err)
+	WriteProblem(writer, request, webResponse.Code, webResponse.Data)
+}
@@ -0,0 +1,9 @@
+package exception
+
+type UnprocessableEntityError struct {
+	Error string
+}
+
+func NewUnprocessableEntityError(error string) UnprocessableEntityError {
+	return UnprocessableEntityError{Error: error}
+}
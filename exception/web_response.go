@@ -0,0 +1,44 @@
+package exception
+
+import (
+	"errors"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/go-playground/validator"
+	"net/http"
+	"strings"
+)
+
+// ToWebResponse maps a recovered panic to the response ErrorHandler would
+// send, for callers such as batch requests that report errors per item.
+func ToWebResponse(err interface{}) web.WebResponse {
+	switch exception := err.(type) {
+	case validator.ValidationErrors:
+		return web.WebResponse{Code: http.StatusBadRequest, Status: "BAD REQUEST", Data: exception.Error()}
+	case helper.RequestBodyError:
+		return web.WebResponse{Code: exception.Code, Status: strings.ToUpper(http.StatusText(exception.Code)), Data: exception.Message}
+	case BadRequestError:
+		return web.WebResponse{Code: http.StatusBadRequest, Status: "BAD REQUEST", Data: exception.Error}
+	case ForbiddenError:
+		return web.WebResponse{Code: http.StatusForbidden, Status: "FORBIDDEN", Data: exception.Error}
+	case NotFoundError:
+		return web.WebResponse{Code: http.StatusNotFound, Status: "NOT FOUND", Data: exception.Error}
+	case ConflictError:
+		return web.WebResponse{Code: http.StatusConflict, Status: "CONFLICT", Data: exception.Error}
+	case UnprocessableEntityError:
+		return web.WebResponse{Code: http.StatusUnprocessableEntity, Status: "UNPROCESSABLE ENTITY", Data: exception.Error}
+	case PreconditionFailedError:
+		return web.WebResponse{Code: http.StatusPreconditionFailed, Status: "PRECONDITION FAILED", Data: exception.Error}
+	case PreconditionRequiredError:
+		return web.WebResponse{Code: http.StatusPreconditionRequired, Status: "PRECONDITION REQUIRED", Data: exception.Error}
+	case TooManyRequestsError:
+		return web.WebResponse{Code: http.StatusTooManyRequests, Status: "TOO MANY REQUESTS", Data: exception.Error}
+	case error:
+		if errors.Is(exception, helper.ErrRequestBodyTooLarge) {
+			return web.WebResponse{Code: http.StatusRequestEntityTooLarge, Status: "REQUEST ENTITY TOO LARGE", Data: exception.Error()}
+		}
+		return web.WebResponse{Code: http.StatusInternalServerError, Status: "INTERNAL SERVER ERROR", Data: exception.Error()}
+	default:
+		return web.WebResponse{Code: http.StatusInternalServerError, Status: "INTERNAL SERVER ERROR", Data: err}
+	}
+}
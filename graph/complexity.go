@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"encoding/json"
+	"github.com/graphql-go/graphql/language/ast"
+	"strconv"
+)
+
+const (
+	// DefaultPageSize is what a list returns without first.
+	DefaultPageSize = 20
+	// MaxPageSize is the most a list returns, whatever first asks for.
+	MaxPageSize = 100
+)
+
+// listFields return up to first items, so whatever is selected below them
+// costs that many times.
+var listFields = map[string]bool{
+	"siswas":  true,
+	"audits":  true,
+	"history": true,
+}
+
+// MaxDepth is how deep fields may nest, whatever the complexity allows.
+const MaxDepth = 10
+
+// Complexity estimates the work of the operation named operationName, or of
+// the costliest one without a name: one per field, with the selection below
+// a list field multiplied by its page size. Nesting lists such as
+// audits { siswa { history } } gets expensive quickly, which is the point.
+// Counting stops past limit, so the result is at most limit+1 however large
+// the query is.
+func Complexity(document *ast.Document, operationName string, variables map[string]interface{}, limit int) int {
+	measure := &complexityMeasure{fragments: fragments(document), variables: variables, visiting: map[string]bool{}, limit: limit}
+	complexity := 0
+	for _, operation := range operations(document, operationName) {
+		if cost := measure.selectionSet(operation.SelectionSet); cost > complexity {
+			complexity = cost
+		}
+	}
+	return complexity
+}
+
+// Depth is how deep fields nest in the operation named operationName, or in
+// the deepest one without a name, fragments included. Counting stops past
+// MaxDepth.
+func Depth(document *ast.Document, operationName string) int {
+	measure := &complexityMeasure{fragments: fragments(document), visiting: map[string]bool{}}
+	depth := 0
+	for _, operation := range operations(document, operationName) {
+		if operationDepth := measure.depth(operation.SelectionSet, 0); operationDepth > depth {
+			depth = operationDepth
+		}
+	}
+	return depth
+}
+
+func fragments(document *ast.Document) map[string]*ast.FragmentDefinition {
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+	return fragments
+}
+
+func operations(document *ast.Document, operationName string) []*ast.OperationDefinition {
+	var operations []*ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName != "" && (operation.Name == nil || operation.Name.Value != operationName) {
+			continue
+		}
+		operations = append(operations, operation)
+	}
+	return operations
+}
+
+type complexityMeasure struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	// visiting stops fragment cycles, which validation rejects only later
+	visiting map[string]bool
+	limit    int
+}
+
+// selectionSet returns as soon as the cost is past limit, so neither the sum
+// nor the product of a list can overflow.
+func (measure *complexityMeasure) selectionSet(selectionSet *ast.SelectionSet) int {
+	if selectionSet == nil {
+		return 0
+	}
+
+	cost := 0
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			below := measure.selectionSet(selection.SelectionSet)
+			if listFields[selection.Name.Value] {
+				if pageSize := measure.pageSize(selection.Arguments); below > measure.limit/pageSize {
+					below = measure.limit + 1
+				} else {
+					below *= pageSize
+				}
+			}
+			cost += 1 + below
+		case *ast.InlineFragment:
+			cost += measure.selectionSet(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := measure.fragments[name]
+			if !ok || measure.visiting[name] {
+				continue
+			}
+			measure.visiting[name] = true
+			cost += measure.selectionSet(fragment.SelectionSet)
+			delete(measure.visiting, name)
+		}
+		if cost > measure.limit {
+			return measure.limit + 1
+		}
+	}
+	return cost
+}
+
+func (measure *complexityMeasure) depth(selectionSet *ast.SelectionSet, depth int) int {
+	if selectionSet == nil || depth > MaxDepth {
+		return depth
+	}
+
+	deepest := depth
+	for _, selection := range selectionSet.Selections {
+		selectionDepth := depth
+		switch selection := selection.(type) {
+		case *ast.Field:
+			selectionDepth = measure.depth(selection.SelectionSet, depth+1)
+		case *ast.InlineFragment:
+			selectionDepth = measure.depth(selection.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := measure.fragments[name]
+			if !ok || measure.visiting[name] {
+				continue
+			}
+			measure.visiting[name] = true
+			selectionDepth = measure.depth(fragment.SelectionSet, depth)
+			delete(measure.visiting, name)
+		}
+		if selectionDepth > deepest {
+			deepest = selectionDepth
+		}
+	}
+	return deepest
+}
+
+// pageSize is first as the resolver will apply it.
+func (measure *complexityMeasure) pageSize(arguments []*ast.Argument) int {
+	for _, argument := range arguments {
+		if argument.Name.Value != "first" {
+			continue
+		}
+
+		var first int
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			first, _ = strconv.Atoi(value.Value)
+		case *ast.Variable:
+			first = intVariable(measure.variables[value.Name.Value])
+		default:
+			return DefaultPageSize
+		}
+		return PageSize(first)
+	}
+	return DefaultPageSize
+}
+
+// PageSize bounds first to 1..MaxPageSize, DefaultPageSize if unset.
+func PageSize(first int) int {
+	if first <= 0 {
+		return DefaultPageSize
+	}
+	if first > MaxPageSize {
+		return MaxPageSize
+	}
+	return first
+}
+
+func intVariable(value interface{}) int {
+	switch value := value.(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	case json.Number:
+		number, _ := value.Int64()
+		return int(number)
+	}
+	return 0
+}
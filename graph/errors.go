@@ -0,0 +1,42 @@
+package graph
+
+import (
+	"fmt"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/graphql-go/graphql"
+	"net/http"
+	"strings"
+)
+
+// Error is a service failure as a GraphQL error, with the status it would
+// have had over REST as extensions.code, e.g. BAD_REQUEST.
+type Error struct {
+	Message string
+	Code    string
+}
+
+func (err *Error) Error() string {
+	return err.Message
+}
+
+func (err *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": err.Code}
+}
+
+// resolver lets resolve panic like the services do, reporting the panic as
+// an Error on the field instead of failing the whole request.
+func resolver(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(params graphql.ResolveParams) (result interface{}, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				webResponse := exception.ToWebResponse(recovered)
+				if webResponse.Code >= http.StatusInternalServerError {
+					helper.Logger(params.Context).WithField("error", webResponse.Data).Error("resolving graphql field failed")
+				}
+				result, err = nil, &Error{Message: fmt.Sprint(webResponse.Data), Code: strings.ReplaceAll(webResponse.Status, " ", "_")}
+			}
+		}()
+		return resolve(params)
+	}
+}
@@ -0,0 +1,146 @@
+package graph
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"sync"
+)
+
+type contextKey string
+
+const (
+	siswaLoaderKey   contextKey = "siswa_loader"
+	historyLoaderKey contextKey = "history_loader"
+)
+
+// SiswaLoader collects the siswa asked for while a level of the query is
+// resolved and fetches them with one SiswaService.FindByIds once the first
+// of them is needed, so a page of audits costs one query instead of one per
+// audit. It lives for one request.
+type SiswaLoader struct {
+	SiswaService service.SiswaService
+	mutex        sync.Mutex
+	pending      []int
+	loaded       map[int]*web.SiswaResponse
+}
+
+func NewSiswaLoader(siswaService service.SiswaService) *SiswaLoader {
+	return &SiswaLoader{SiswaService: siswaService, loaded: map[int]*web.SiswaResponse{}}
+}
+
+func WithSiswaLoader(ctx context.Context, loader *SiswaLoader) context.Context {
+	return context.WithValue(ctx, siswaLoaderKey, loader)
+}
+
+func siswaLoaderFromContext(ctx context.Context) *SiswaLoader {
+	return ctx.Value(siswaLoaderKey).(*SiswaLoader)
+}
+
+// Load returns a thunk for the executor, resolving to nil for a siswa that
+// does not exist or is in the trash.
+func (loader *SiswaLoader) Load(ctx context.Context, siswaId int) func() (interface{}, error) {
+	loader.mutex.Lock()
+	if _, ok := loader.loaded[siswaId]; !ok {
+		loader.pending = append(loader.pending, siswaId)
+	}
+	loader.mutex.Unlock()
+
+	return func() (interface{}, error) {
+		loader.mutex.Lock()
+		defer loader.mutex.Unlock()
+
+		if _, ok := loader.loaded[siswaId]; !ok {
+			loader.flush(ctx)
+		}
+		if siswa := loader.loaded[siswaId]; siswa != nil {
+			return *siswa, nil
+		}
+		return nil, nil
+	}
+}
+
+func (loader *SiswaLoader) flush(ctx context.Context) {
+	var siswaIds []int
+	for _, siswaId := range loader.pending {
+		if _, ok := loader.loaded[siswaId]; !ok {
+			loader.loaded[siswaId] = nil
+			siswaIds = append(siswaIds, siswaId)
+		}
+	}
+	loader.pending = nil
+
+	for _, siswa := range loader.SiswaService.FindByIds(ctx, siswaIds) {
+		siswa := siswa
+		loader.loaded[siswa.Id] = &siswa
+	}
+}
+
+// HistoryLoader does for the history of siswa what SiswaLoader does for
+// siswa, with one AuditService.FindSiswaHistories for a page of siswa and
+// each number of latest audits asked for.
+type HistoryLoader struct {
+	AuditService service.AuditService
+	mutex        sync.Mutex
+	pending      []historyKey
+	loaded       map[historyKey][]web.AuditResponse
+}
+
+type historyKey struct {
+	siswaId int
+	latest  int
+}
+
+func NewHistoryLoader(auditService service.AuditService) *HistoryLoader {
+	return &HistoryLoader{AuditService: auditService, loaded: map[historyKey][]web.AuditResponse{}}
+}
+
+func WithHistoryLoader(ctx context.Context, loader *HistoryLoader) context.Context {
+	return context.WithValue(ctx, historyLoaderKey, loader)
+}
+
+func historyLoaderFromContext(ctx context.Context) *HistoryLoader {
+	return ctx.Value(historyLoaderKey).(*HistoryLoader)
+}
+
+// Load returns a thunk for the executor, resolving to the latest audits of
+// the siswa oldest first.
+func (loader *HistoryLoader) Load(ctx context.Context, siswaId int, latest int) func() []web.AuditResponse {
+	key := historyKey{siswaId: siswaId, latest: latest}
+	loader.mutex.Lock()
+	if _, ok := loader.loaded[key]; !ok {
+		loader.pending = append(loader.pending, key)
+	}
+	loader.mutex.Unlock()
+
+	return func() []web.AuditResponse {
+		loader.mutex.Lock()
+		defer loader.mutex.Unlock()
+
+		if _, ok := loader.loaded[key]; !ok {
+			loader.flush(ctx)
+		}
+		return loader.loaded[key]
+	}
+}
+
+func (loader *HistoryLoader) flush(ctx context.Context) {
+	var latests []int
+	siswaIds := map[int][]int{}
+	for _, key := range loader.pending {
+		if _, ok := loader.loaded[key]; !ok {
+			loader.loaded[key] = nil
+			if _, ok := siswaIds[key.latest]; !ok {
+				latests = append(latests, key.latest)
+			}
+			siswaIds[key.latest] = append(siswaIds[key.latest], key.siswaId)
+		}
+	}
+	loader.pending = nil
+
+	for _, latest := range latests {
+		for siswaId, audits := range loader.AuditService.FindSiswaHistories(ctx, siswaIds[latest], latest) {
+			loader.loaded[historyKey{siswaId: siswaId, latest: latest}] = audits
+		}
+	}
+}
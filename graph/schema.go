@@ -0,0 +1,338 @@
+package graph
+
+import (
+	"encoding/base64"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/graphql-go/graphql"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NewSchema is the read only schema served at /graphql. Lists are pages of
+// nodes ordered by id, continued with the endCursor of the previous page.
+func NewSchema(siswaService service.SiswaService, auditService service.AuditService) graphql.Schema {
+	auditChangeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "AuditChange",
+		Fields: graphql.Fields{
+			"field":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"before": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"after":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	auditType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Audit",
+		Description: "A change to a siswa: who made it, when, and which fields it changed.",
+		Fields: graphql.Fields{
+			"id":        auditField(graphql.Int, func(audit web.AuditResponse) interface{} { return audit.Id }),
+			"entity":    auditField(graphql.String, func(audit web.AuditResponse) interface{} { return audit.Entity }),
+			"entityId":  auditField(graphql.Int, func(audit web.AuditResponse) interface{} { return audit.EntityId }),
+			"action":    auditField(graphql.String, func(audit web.AuditResponse) interface{} { return audit.Action }),
+			"actor":     auditField(graphql.String, func(audit web.AuditResponse) interface{} { return audit.Actor }),
+			"requestId": auditField(graphql.String, func(audit web.AuditResponse) interface{} { return audit.RequestId }),
+			"createdAt": auditField(graphql.DateTime, func(audit web.AuditResponse) interface{} { return audit.CreatedAt }),
+			"changes": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(auditChangeType))),
+				Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+					var changes []map[string]interface{}
+					for _, change := range params.Source.(web.AuditResponse).Changes {
+						changes = append(changes, map[string]interface{}{"field": change.Field, "before": change.Before, "after": change.After})
+					}
+					return changes, nil
+				},
+			},
+		},
+	})
+
+	siswaType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Siswa",
+		Fields: graphql.Fields{
+			"id":            siswaField(graphql.Int, func(siswa web.SiswaResponse) interface{} { return siswa.Id }),
+			"nama":          siswaField(graphql.String, func(siswa web.SiswaResponse) interface{} { return siswa.Nama }),
+			"alamat":        siswaField(graphql.String, func(siswa web.SiswaResponse) interface{} { return siswa.Alamat }),
+			"tanggalLahir":  siswaField(graphql.String, func(siswa web.SiswaResponse) interface{} { return siswa.TanggalLahir }),
+			"tempatLahir":   siswaField(graphql.String, func(siswa web.SiswaResponse) interface{} { return siswa.TempatLahir }),
+			"jenisKelamin":  siswaField(graphql.String, func(siswa web.SiswaResponse) interface{} { return siswa.JenisKelamin }),
+			"agama":         siswaField(graphql.String, func(siswa web.SiswaResponse) interface{} { return siswa.Agama }),
+			"golonganDarah": siswaField(graphql.String, func(siswa web.SiswaResponse) interface{} { return siswa.GolonganDarah }),
+			"noTelepon":     siswaField(graphql.String, func(siswa web.SiswaResponse) interface{} { return siswa.NoTelepon }),
+			"version":       siswaField(graphql.Int, func(siswa web.SiswaResponse) interface{} { return siswa.Version }),
+			"deletedAt": &graphql.Field{
+				Type: graphql.DateTime,
+				Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+					if deletedAt := params.Source.(web.SiswaResponse).DeletedAt; deletedAt != nil {
+						return *deletedAt, nil
+					}
+					return nil, nil
+				},
+			},
+			"deletedBy": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+					if deletedBy := params.Source.(web.SiswaResponse).DeletedBy; deletedBy != "" {
+						return deletedBy, nil
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+
+	siswaType.AddFieldConfig("history", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(auditType))),
+		Description: "The latest changes to the siswa, oldest first.",
+		Args: graphql.FieldConfigArgument{
+			"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: DefaultPageSize},
+		},
+		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+			load := historyLoaderFromContext(params.Context).Load(params.Context, params.Source.(web.SiswaResponse).Id, PageSize(params.Args["first"].(int)))
+			return func() (interface{}, error) {
+				return resolver(func(params graphql.ResolveParams) (interface{}, error) {
+					return load(), nil
+				})(params)
+			}, nil
+		},
+	})
+	auditType.AddFieldConfig("siswa", &graphql.Field{
+		Type:        siswaType,
+		Description: "The audited siswa, null once it is in the trash or purged.",
+		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+			audit := params.Source.(web.AuditResponse)
+			if audit.Entity != "siswa" {
+				return nil, nil
+			}
+			return siswaLoaderFromContext(params.Context).Load(params.Context, audit.EntityId), nil
+		},
+	})
+
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"endCursor":   &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	siswaFilterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "SiswaFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"nama":             &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Part of the nama, any case."},
+			"jenisKelamin":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"agama":            &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"golonganDarah":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"tempatLahir":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"tanggalLahirFrom": &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Earliest tanggal lahir, 2006-01-02."},
+			"tanggalLahirTo":   &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Latest tanggal lahir, 2006-01-02."},
+			"trash":            &graphql.InputObjectFieldConfig{Type: graphql.Boolean, Description: "Search the soft deleted siswa instead."},
+		},
+	})
+
+	auditFilterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "AuditFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"actor":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"entity":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"entityId": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"from":     &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "A date (2006-01-02) or RFC 3339 timestamp."},
+			"to":       &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "A date (2006-01-02) or RFC 3339 timestamp."},
+		},
+	})
+
+	pageArgs := func(filterType *graphql.InputObject) graphql.FieldConfigArgument {
+		return graphql.FieldConfigArgument{
+			"filter": &graphql.ArgumentConfig{Type: filterType},
+			"first":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: DefaultPageSize},
+			"after":  &graphql.ArgumentConfig{Type: graphql.String},
+		}
+	}
+
+	jenisKelaminCountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "JenisKelaminCount",
+		Fields: graphql.Fields{
+			"jenisKelamin": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"count":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"siswa": &graphql.Field{
+				Type:        siswaType,
+				Description: "An active siswa, null if there is none with the id.",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: resolver(func(params graphql.ResolveParams) (interface{}, error) {
+					return siswaLoaderFromContext(params.Context).Load(params.Context, params.Args["id"].(int)), nil
+				}),
+			},
+			"siswas": &graphql.Field{
+				Type: connectionType("Siswa", siswaType, pageInfoType),
+				Args: pageArgs(siswaFilterType),
+				Resolve: resolver(func(params graphql.ResolveParams) (interface{}, error) {
+					filter, _ := params.Args["filter"].(map[string]interface{})
+					request := web.SiswaSearchRequest{First: PageSize(params.Args["first"].(int))}
+					request.Nama, _ = filter["nama"].(string)
+					request.JenisKelamin, _ = filter["jenisKelamin"].(string)
+					request.Agama, _ = filter["agama"].(string)
+					request.GolonganDarah, _ = filter["golonganDarah"].(string)
+					request.TempatLahir, _ = filter["tempatLahir"].(string)
+					if from, ok := filter["tanggalLahirFrom"].(string); ok {
+						request.TanggalLahirFrom = parseDate("tanggalLahirFrom", from)
+					}
+					if to, ok := filter["tanggalLahirTo"].(string); ok {
+						request.TanggalLahirTo = parseDate("tanggalLahirTo", to)
+					}
+					request.Trash, _ = filter["trash"].(bool)
+					if after, ok := params.Args["after"].(string); ok {
+						request.After = decodeCursor("siswa", after)
+					}
+
+					siswaPage := siswaService.Search(params.Context, request)
+					nodes := []interface{}{}
+					for _, siswa := range siswaPage.Siswas {
+						nodes = append(nodes, siswa)
+					}
+					pageInfo := map[string]interface{}{"hasNextPage": siswaPage.HasNextPage}
+					if len(siswaPage.Siswas) > 0 {
+						pageInfo["endCursor"] = encodeCursor("siswa", siswaPage.Siswas[len(siswaPage.Siswas)-1].Id)
+					}
+					return map[string]interface{}{"totalCount": siswaPage.TotalCount, "nodes": nodes, "pageInfo": pageInfo}, nil
+				}),
+			},
+			"audits": &graphql.Field{
+				Type: connectionType("Audit", auditType, pageInfoType),
+				Args: pageArgs(auditFilterType),
+				Resolve: resolver(func(params graphql.ResolveParams) (interface{}, error) {
+					filter, _ := params.Args["filter"].(map[string]interface{})
+					request := web.AuditSearchRequest{}
+					request.Actor, _ = filter["actor"].(string)
+					request.Entity, _ = filter["entity"].(string)
+					request.EntityId, _ = filter["entityId"].(int)
+					request.From, _ = filter["from"].(string)
+					request.To, _ = filter["to"].(string)
+
+					var nodes []interface{}
+					var ids []int
+					for _, audit := range auditService.Search(params.Context, request) {
+						nodes = append(nodes, audit)
+						ids = append(ids, audit.Id)
+					}
+					return page("audit", nodes, ids, params.Args), nil
+				}),
+			},
+			"statistics": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewObject(graphql.ObjectConfig{
+					Name: "SiswaStatistics",
+					Fields: graphql.Fields{
+						"activeByJenisKelamin": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(jenisKelaminCountType)))},
+						"trash":                &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+					},
+				})),
+				Resolve: resolver(func(params graphql.ResolveParams) (interface{}, error) {
+					statistics := siswaService.Statistics(params.Context)
+					var counts []map[string]interface{}
+					for jenisKelamin, count := range statistics.ActiveByJenisKelamin {
+						counts = append(counts, map[string]interface{}{"jenisKelamin": jenisKelamin, "count": count})
+					}
+					sort.Slice(counts, func(i, j int) bool {
+						return counts[i]["jenisKelamin"].(string) < counts[j]["jenisKelamin"].(string)
+					})
+					return map[string]interface{}{"activeByJenisKelamin": counts, "trash": statistics.Trash}, nil
+				}),
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+	helper.PanicIfError(err)
+	return schema
+}
+
+func siswaField(fieldType graphql.Output, value func(siswa web.SiswaResponse) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewNonNull(fieldType),
+		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+			return value(params.Source.(web.SiswaResponse)), nil
+		},
+	}
+}
+
+func auditField(fieldType graphql.Output, value func(audit web.AuditResponse) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewNonNull(fieldType),
+		Resolve: func(params graphql.ResolveParams) (interface{}, error) {
+			return value(params.Source.(web.AuditResponse)), nil
+		},
+	}
+}
+
+func connectionType(name string, nodeType *graphql.Object, pageInfoType *graphql.Object) *graphql.NonNull {
+	return graphql.NewNonNull(graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Connection",
+		Fields: graphql.Fields{
+			"totalCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Matches on every page together."},
+			"nodes":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(nodeType)))},
+			"pageInfo":   &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+		},
+	}))
+}
+
+func parseDate(name string, value string) string {
+	_, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(exception.NewBadRequestError(name + " must be a date (2006-01-02)"))
+	}
+	return value
+}
+
+// page orders nodes by ids and cuts out the page args ask for.
+func page(kind string, nodes []interface{}, ids []int, args map[string]interface{}) map[string]interface{} {
+	order := make([]int, len(nodes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return ids[order[i]] < ids[order[j]] })
+
+	start := 0
+	if after, ok := args["after"].(string); ok {
+		afterId := decodeCursor(kind, after)
+		for start < len(order) && ids[order[start]] <= afterId {
+			start++
+		}
+	}
+	end := start + PageSize(args["first"].(int))
+	if end > len(order) {
+		end = len(order)
+	}
+
+	pageNodes := []interface{}{}
+	for _, i := range order[start:end] {
+		pageNodes = append(pageNodes, nodes[i])
+	}
+	pageInfo := map[string]interface{}{"hasNextPage": end < len(order)}
+	if end > start {
+		pageInfo["endCursor"] = encodeCursor(kind, ids[order[end-1]])
+	}
+	return map[string]interface{}{"totalCount": len(nodes), "nodes": pageNodes, "pageInfo": pageInfo}
+}
+
+func encodeCursor(kind string, id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(kind + ":" + strconv.Itoa(id)))
+}
+
+func decodeCursor(kind string, cursor string) int {
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil && strings.HasPrefix(string(value), kind+":") {
+		if id, err := strconv.Atoi(strings.TrimPrefix(string(value), kind+":")); err == nil {
+			return id
+		}
+	}
+	panic(exception.NewBadRequestError("after is not a " + kind + " cursor"))
+}
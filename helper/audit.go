@@ -0,0 +1,86 @@
+package helper
+
+import (
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"strconv"
+	"time"
+)
+
+func DiffSiswa(before domain.Siswa, after domain.Siswa) []domain.AuditChange {
+	beforeFields := siswaFields(before)
+	afterFields := siswaFields(after)
+
+	var changes []domain.AuditChange
+	for i, field := range beforeFields {
+		if field[1] != afterFields[i][1] {
+			changes = append(changes, domain.AuditChange{
+				Field:  field[0],
+				Before: field[1],
+				After:  afterFields[i][1],
+			})
+		}
+	}
+	return changes
+}
+
+func siswaFields(siswa domain.Siswa) [][2]string {
+	deletedAt := ""
+	if siswa.DeletedAt != nil {
+		deletedAt = siswa.DeletedAt.Format(time.RFC3339)
+	}
+
+	id := ""
+	if siswa.Id != 0 {
+		id = strconv.Itoa(siswa.Id)
+	}
+
+	return [][2]string{
+		{"id", id},
+		{"nama", siswa.Nama},
+		{"alamat", siswa.Alamat},
+		{"tanggal_lahir", siswa.TanggalLahir},
+		{"tempat_lahir", siswa.TempatLahir},
+		{"jenis_kelamin", siswa.JenisKelamin},
+		{"agama", siswa.Agama},
+		{"golongan_darah", siswa.GolonganDarah},
+		{"no_telepon", siswa.NoTelepon},
+		{"deleted_at", deletedAt},
+		{"deleted_by", siswa.DeletedBy},
+	}
+}
+
+func ToAuditChangeResponses(auditChanges []domain.AuditChange) []web.AuditChangeResponse {
+	changes := []web.AuditChangeResponse{}
+	for _, change := range auditChanges {
+		changes = append(changes, web.AuditChangeResponse{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+	return changes
+}
+
+func ToAuditResponse(audit domain.Audit) web.AuditResponse {
+	changes := ToAuditChangeResponses(audit.Changes)
+
+	return web.AuditResponse{
+		Id:        audit.Id,
+		Entity:    audit.Entity,
+		EntityId:  audit.EntityId,
+		Action:    audit.Action,
+		Actor:     audit.Actor,
+		RequestId: audit.RequestId,
+		Changes:   changes,
+		CreatedAt: audit.CreatedAt,
+	}
+}
+
+func ToAuditResponses(audits []domain.Audit) []web.AuditResponse {
+	var auditResponses []web.AuditResponse
+	for _, audit := range audits {
+		auditResponses = append(auditResponses, ToAuditResponse(audit))
+	}
+	return auditResponses
+}
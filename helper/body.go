@@ -0,0 +1,42 @@
+package helper
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrRequestBodyTooLarge is returned while reading a request body that is
+// longer than allowed by LimitBody. ErrorHandler answers it with 413.
+var ErrRequestBodyTooLarge = errors.New("request body too large")
+
+type limitedBody struct {
+	io.ReadCloser
+	limit int64
+	read  int64
+}
+
+// LimitBody fails reads of body once more than limit bytes have been read.
+func LimitBody(body io.ReadCloser, limit int64) io.ReadCloser {
+	return &limitedBody{ReadCloser: body, limit: limit}
+}
+
+// Read never hands out bytes beyond the limit, otherwise a decoder could
+// finish a value from them before looking at the error.
+func (body *limitedBody) Read(p []byte) (int, error) {
+	if body.read > body.limit {
+		return 0, ErrRequestBodyTooLarge
+	}
+	if remaining := body.limit - body.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := body.ReadCloser.Read(p)
+	if body.read+int64(n) <= body.limit {
+		body.read += int64(n)
+		return n, err
+	}
+
+	n = int(body.limit - body.read)
+	body.read = body.limit + 1
+	return n, ErrRequestBodyTooLarge
+}
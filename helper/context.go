@@ -0,0 +1,60 @@
+package helper
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+type contextKey string
+
+const (
+	actorKey     contextKey = "actor"
+	requestIdKey contextKey = "request_id"
+	sekolahKey   contextKey = "sekolah"
+)
+
+// tenant is the school a request works on. restricted is set when the actor
+// belongs to that school only.
+type tenant struct {
+	sekolah    domain.Sekolah
+	restricted bool
+}
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+func ActorFromContext(ctx context.Context) string {
+	actor, ok := ctx.Value(actorKey).(string)
+	if !ok {
+		return "system"
+	}
+	return actor
+}
+
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey, requestId)
+}
+
+func RequestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey).(string)
+	return requestId
+}
+
+// WithSekolah scopes ctx to sekolah. Pass restricted for an actor of that
+// school only, who must not see what spans the whole yayasan.
+func WithSekolah(ctx context.Context, sekolah domain.Sekolah, restricted bool) context.Context {
+	return context.WithValue(ctx, sekolahKey, tenant{sekolah: sekolah, restricted: restricted})
+}
+
+func SekolahFromContext(ctx context.Context) (domain.Sekolah, bool) {
+	tenant, ok := ctx.Value(sekolahKey).(tenant)
+	return tenant.sekolah, ok
+}
+
+// RestrictedToSekolah reports whether the actor of ctx may only work on the
+// school of ctx.
+func RestrictedToSekolah(ctx context.Context) bool {
+	tenant, _ := ctx.Value(sekolahKey).(tenant)
+	return tenant.restricted
+}
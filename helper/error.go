@@ -1,7 +1,19 @@
 package helper
 
+import (
+	"errors"
+	"github.com/go-sql-driver/mysql"
+)
+
 func PanicIfError(err error) {
 	if err != nil {
 		panic(err)
 	}
 }
+
+// IsDuplicateKey reports whether err is MySQL refusing a row that collides
+// with a unique index, the one error a Save answers with a conflict.
+func IsDuplicateKey(err error) bool {
+	var mysqlError *mysql.MySQLError
+	return errors.As(err, &mysqlError) && mysqlError.Number == 1062
+}
@@ -0,0 +1,43 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AnyVersion is what FromETag returns for "If-Match: *", which matches
+// whatever version is current.
+const AnyVersion = -1
+
+func ToETag(version int) string {
+	return "\"" + strconv.Itoa(version) + "\""
+}
+
+// FromETag returns the version carried by an If-Match / If-None-Match value,
+// AnyVersion for "*", or 0 when the header is empty or not an ETag issued by
+// ToETag.
+func FromETag(header string) int {
+	if strings.TrimSpace(header) == "*" {
+		return AnyVersion
+	}
+	value := strings.TrimPrefix(strings.TrimSpace(header), "W/")
+	if len(value) < 2 || !strings.HasPrefix(value, "\"") || !strings.HasSuffix(value, "\"") {
+		return 0
+	}
+
+	version, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+func MatchETag(header string, version int) bool {
+	for _, value := range strings.Split(header, ",") {
+		value = strings.TrimSpace(value)
+		if value == "*" || FromETag(value) == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package helper
+
+import (
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+)
+
+func ToJobResponse(job domain.Job) web.JobResponse {
+	return web.JobResponse{
+		Id:          job.Id,
+		Name:        job.Name,
+		Status:      job.Status,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+		LastError:   job.LastError,
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		FinishedAt:  job.FinishedAt,
+	}
+}
+
+func ToJobResponses(jobs []domain.Job) []web.JobResponse {
+	var jobResponses []web.JobResponse
+	for _, job := range jobs {
+		jobResponses = append(jobResponses, ToJobResponse(job))
+	}
+	return jobResponses
+}
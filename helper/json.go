@@ -2,18 +2,86 @@ package helper
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strings"
 )
 
+// RequestBodyError rejects a request body the client got wrong. ErrorHandler
+// answers it with Code, 400 or 415, and Message.
+type RequestBodyError struct {
+	Code    int
+	Message string
+}
+
+func (err RequestBodyError) Error() string {
+	return err.Message
+}
+
+// ReadFromRequestBody decodes exactly one JSON value into result, rejecting
+// other content types, fields result does not have and data after the value.
 func ReadFromRequestBody(request *http.Request, result interface{}) {
+	mediaType, _, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		panic(RequestBodyError{Code: http.StatusUnsupportedMediaType, Message: "Content-Type must be application/json"})
+	}
+
 	decoder := json.NewDecoder(request.Body)
-	err := decoder.Decode(result)
-	PanicIfError(err)
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(result)
+	if err != nil {
+		panic(decodeError(err, decoder))
+	}
+
+	err = decoder.Decode(&json.RawMessage{})
+	if err != io.EOF {
+		if errors.Is(err, ErrRequestBodyTooLarge) {
+			panic(err)
+		}
+		panic(RequestBodyError{Code: http.StatusBadRequest, Message: fmt.Sprintf("unexpected data after the JSON value at offset %d", decoder.InputOffset())})
+	}
+}
+
+func decodeError(err error, decoder *json.Decoder) error {
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+
+	switch {
+	case errors.Is(err, ErrRequestBodyTooLarge):
+		return err
+	case errors.As(err, &syntaxError):
+		return RequestBodyError{Code: http.StatusBadRequest, Message: fmt.Sprintf("malformed JSON at offset %d: %s", syntaxError.Offset, strings.TrimPrefix(syntaxError.Error(), "json: "))}
+	case errors.As(err, &typeError) && typeError.Field != "":
+		return RequestBodyError{Code: http.StatusBadRequest, Message: fmt.Sprintf("field %q must be %s, got %s at offset %d", typeError.Field, jsonType(typeError.Type.Kind().String()), typeError.Value, typeError.Offset)}
+	case errors.As(err, &typeError):
+		return RequestBodyError{Code: http.StatusBadRequest, Message: fmt.Sprintf("body must be %s, got %s", jsonType(typeError.Type.Kind().String()), typeError.Value)}
+	case errors.Is(err, io.EOF):
+		return RequestBodyError{Code: http.StatusBadRequest, Message: "request body is empty"}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return RequestBodyError{Code: http.StatusBadRequest, Message: fmt.Sprintf("request body ends inside a JSON value at offset %d", decoder.InputOffset())}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no type for this one
+		return RequestBodyError{Code: http.StatusBadRequest, Message: fmt.Sprintf("unknown field %s at offset %d", strings.TrimPrefix(err.Error(), "json: unknown field "), decoder.InputOffset())}
+	default:
+		return err
+	}
 }
 
-func WriteToResponseBody(writer http.ResponseWriter, response interface{}) {
-	writer.Header().Add("Content-Type", "application/json")
-	encoder := json.NewEncoder(writer)
-	err := encoder.Encode(response)
-	PanicIfError(err)
+// jsonType names a Go kind the way a JSON client knows it.
+func jsonType(kind string) string {
+	switch {
+	case kind == "string":
+		return "a string"
+	case kind == "bool":
+		return "a boolean"
+	case strings.HasPrefix(kind, "int"), strings.HasPrefix(kind, "uint"), strings.HasPrefix(kind, "float"):
+		return "a number"
+	case kind == "slice", kind == "array":
+		return "an array"
+	default:
+		return "an object"
+	}
 }
@@ -0,0 +1,22 @@
+package helper
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+)
+
+const loggerKey contextKey = "logger"
+
+func WithLogger(ctx context.Context, logger *logrus.Entry) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// Logger returns the request scoped logger, already carrying the request id,
+// or the standard logger outside of a request.
+func Logger(ctx context.Context) *logrus.Entry {
+	logger, ok := ctx.Value(loggerKey).(*logrus.Entry)
+	if !ok {
+		return logrus.NewEntry(logrus.StandardLogger())
+	}
+	return logger
+}
@@ -0,0 +1,109 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ToSiswaMessage(siswaResponse web.SiswaResponse) *pb.Siswa {
+	siswa := &pb.Siswa{
+		Id:            int64(siswaResponse.Id),
+		Nama:          siswaResponse.Nama,
+		Alamat:        siswaResponse.Alamat,
+		TanggalLahir:  siswaResponse.TanggalLahir,
+		TempatLahir:   siswaResponse.TempatLahir,
+		JenisKelamin:  siswaResponse.JenisKelamin,
+		Agama:         siswaResponse.Agama,
+		GolonganDarah: siswaResponse.GolonganDarah,
+		NoTelepon:     siswaResponse.NoTelepon,
+		Version:       int64(siswaResponse.Version),
+		DeletedBy:     siswaResponse.DeletedBy,
+	}
+	if siswaResponse.DeletedAt != nil {
+		siswa.DeletedAt = timestamppb.New(*siswaResponse.DeletedAt)
+	}
+	return siswa
+}
+
+// FromSiswaData is the create request for data, which may be nil.
+func FromSiswaData(data *pb.SiswaData) web.SiswaCreateRequest {
+	return web.SiswaCreateRequest{
+		Nama:          data.GetNama(),
+		Alamat:        data.GetAlamat(),
+		TanggalLahir:  data.GetTanggalLahir(),
+		TempatLahir:   data.GetTempatLahir(),
+		JenisKelamin:  data.GetJenisKelamin(),
+		Agama:         data.GetAgama(),
+		GolonganDarah: data.GetGolonganDarah(),
+		NoTelepon:     data.GetNoTelepon(),
+	}
+}
+
+func FromUpdateSiswaRequest(request *pb.UpdateSiswaRequest) web.SiswaUpdateRequest {
+	data := FromSiswaData(request.GetData())
+	return web.SiswaUpdateRequest{
+		Id:            int(request.GetId()),
+		Nama:          data.Nama,
+		Alamat:        data.Alamat,
+		TanggalLahir:  data.TanggalLahir,
+		TempatLahir:   data.TempatLahir,
+		JenisKelamin:  data.JenisKelamin,
+		Agama:         data.Agama,
+		GolonganDarah: data.GolonganDarah,
+		NoTelepon:     data.NoTelepon,
+		Version:       int(request.GetVersion()),
+	}
+}
+
+func FromBatchSiswaRequest(request *pb.BatchSiswaRequest) web.SiswaBatchRequest {
+	siswaBatchRequest := web.SiswaBatchRequest{Mode: request.GetMode()}
+	for _, operation := range request.GetOperations() {
+		batchOperation := web.SiswaBatchOperation{
+			Action:  operation.GetAction(),
+			Id:      int(operation.GetId()),
+			Version: int(operation.GetVersion()),
+		}
+		if operation.GetData() != nil {
+			data := FromSiswaData(operation.GetData())
+			batchOperation.Data = &data
+		}
+		siswaBatchRequest.Operations = append(siswaBatchRequest.Operations, batchOperation)
+	}
+	return siswaBatchRequest
+}
+
+func ToBatchSiswaResponseMessage(siswaBatchResponse web.SiswaBatchResponse) *pb.BatchSiswaResponse {
+	response := &pb.BatchSiswaResponse{
+		Mode:      siswaBatchResponse.Mode,
+		Committed: siswaBatchResponse.Committed,
+	}
+	for _, result := range siswaBatchResponse.Results {
+		resultMessage := &pb.BatchSiswaResult{
+			Index:  int32(result.Index),
+			Action: result.Action,
+			Code:   int32(result.Code),
+			Status: result.Status,
+		}
+		if result.Data != nil {
+			resultMessage.Data = ToSiswaMessage(*result.Data)
+		}
+		if result.Error != nil {
+			resultMessage.Error = fmt.Sprint(result.Error)
+		}
+		response.Results = append(response.Results, resultMessage)
+	}
+	return response
+}
+
+func ToSiswaStatisticsMessage(statistics web.SiswaStatisticsResponse) *pb.SiswaStatistics {
+	message := &pb.SiswaStatistics{
+		ActiveByJenisKelamin: map[string]int64{},
+		Trash:                int64(statistics.Trash),
+	}
+	for jenisKelamin, count := range statistics.ActiveByJenisKelamin {
+		message.ActiveByJenisKelamin[jenisKelamin] = int64(count)
+	}
+	return message
+}
@@ -16,6 +16,9 @@ func ToSiswaResponse(siswa domain.Siswa) web.SiswaResponse {
 		Agama:         siswa.Agama,
 		GolonganDarah: siswa.GolonganDarah,
 		NoTelepon:     siswa.NoTelepon,
+		Version:       siswa.Version,
+		DeletedAt:     siswa.DeletedAt,
+		DeletedBy:     siswa.DeletedBy,
 	}
 }
 
@@ -26,3 +29,37 @@ func ToSiswaResponses(siswas []domain.Siswa) []web.SiswaResponse {
 	}
 	return siswaResponses
 }
+
+func ToSiswaResponseV2(siswaResponse web.SiswaResponse) web.SiswaResponseV2 {
+	return web.SiswaResponseV2(siswaResponse)
+}
+
+func ToSiswaResponsesV2(siswaResponses []web.SiswaResponse) []web.SiswaResponseV2 {
+	var siswaResponsesV2 []web.SiswaResponseV2
+	for _, siswaResponse := range siswaResponses {
+		siswaResponsesV2 = append(siswaResponsesV2, ToSiswaResponseV2(siswaResponse))
+	}
+	return siswaResponsesV2
+}
+
+func ToSiswaBatchResponseV2(siswaBatchResponse web.SiswaBatchResponse) web.SiswaBatchResponseV2 {
+	siswaBatchResponseV2 := web.SiswaBatchResponseV2{
+		Mode:      siswaBatchResponse.Mode,
+		Committed: siswaBatchResponse.Committed,
+	}
+	for _, result := range siswaBatchResponse.Results {
+		resultV2 := web.SiswaBatchResultV2{
+			Index:  result.Index,
+			Action: result.Action,
+			Code:   result.Code,
+			Status: result.Status,
+			Error:  result.Error,
+		}
+		if result.Data != nil {
+			data := ToSiswaResponseV2(*result.Data)
+			resultV2.Data = &data
+		}
+		siswaBatchResponseV2.Results = append(siswaBatchResponseV2.Results, resultV2)
+	}
+	return siswaBatchResponseV2
+}
@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+)
+
+func ToNotificationResponse(notification domain.Notification) web.NotificationResponse {
+	return web.NotificationResponse{
+		Id:        notification.Id,
+		SiswaId:   notification.SiswaId,
+		Template:  notification.Template,
+		Language:  notification.Language,
+		Subject:   notification.Subject,
+		Body:      notification.Body,
+		Status:    notification.Status,
+		Channel:   notification.Channel,
+		Recipient: notification.Recipient,
+		Attempts:  notification.Attempts,
+		LastError: notification.LastError,
+		CreatedBy: notification.CreatedBy,
+		CreatedAt: notification.CreatedAt,
+		SentAt:    notification.SentAt,
+	}
+}
+
+func ToNotificationResponses(notifications []domain.Notification) []web.NotificationResponse {
+	var notificationResponses []web.NotificationResponse
+	for _, notification := range notifications {
+		notificationResponses = append(notificationResponses, ToNotificationResponse(notification))
+	}
+	return notificationResponses
+}
+
+// ToNotificationPreferenceResponse leaves UpdatedAt out of a preference that
+// was never saved, i.e. the defaults.
+func ToNotificationPreferenceResponse(preference domain.NotificationPreference) web.NotificationPreferenceResponse {
+	preferenceResponse := web.NotificationPreferenceResponse{
+		SiswaId:    preference.SiswaId,
+		NamaWali:   preference.NamaWali,
+		Email:      preference.Email,
+		NoTelepon:  preference.NoTelepon,
+		NoWhatsapp: preference.NoWhatsapp,
+		Channels:   preference.Channels,
+		Language:   preference.Language,
+	}
+	if !preference.UpdatedAt.IsZero() {
+		updatedAt := preference.UpdatedAt
+		preferenceResponse.UpdatedAt = &updatedAt
+	}
+	return preferenceResponse
+}
@@ -0,0 +1,68 @@
+package helper
+
+import (
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+)
+
+func ToPendaftarResponse(pendaftar domain.Pendaftar) web.PendaftarResponse {
+	dokumenResponses := []web.DokumenResponse{}
+	for _, dokumen := range pendaftar.Dokumen {
+		dokumenResponses = append(dokumenResponses, ToDokumenResponse(dokumen))
+	}
+
+	return web.PendaftarResponse{
+		Id:            pendaftar.Id,
+		Jalur:         pendaftar.Jalur,
+		Status:        pendaftar.Status,
+		Nama:          pendaftar.Nama,
+		Alamat:        pendaftar.Alamat,
+		TanggalLahir:  pendaftar.TanggalLahir,
+		TempatLahir:   pendaftar.TempatLahir,
+		JenisKelamin:  pendaftar.JenisKelamin,
+		Agama:         pendaftar.Agama,
+		GolonganDarah: pendaftar.GolonganDarah,
+		NoTelepon:     pendaftar.NoTelepon,
+		AsalSekolah:   pendaftar.AsalSekolah,
+		Jarak:         pendaftar.Jarak,
+		Nilai:         pendaftar.Nilai,
+		Peringkat:     pendaftar.Peringkat,
+		Catatan:       pendaftar.Catatan,
+		SiswaId:       pendaftar.SiswaId,
+		Dokumen:       dokumenResponses,
+		CreatedAt:     pendaftar.CreatedAt,
+		UpdatedAt:     pendaftar.UpdatedAt,
+		AnnouncedAt:   pendaftar.AnnouncedAt,
+	}
+}
+
+func ToPendaftarResponses(pendaftars []domain.Pendaftar) []web.PendaftarResponse {
+	var pendaftarResponses []web.PendaftarResponse
+	for _, pendaftar := range pendaftars {
+		pendaftarResponses = append(pendaftarResponses, ToPendaftarResponse(pendaftar))
+	}
+	return pendaftarResponses
+}
+
+func ToDokumenResponse(dokumen domain.Dokumen) web.DokumenResponse {
+	return web.DokumenResponse{
+		Jenis:       dokumen.Jenis,
+		ContentType: dokumen.ContentType,
+		Ukuran:      dokumen.Ukuran,
+		UploadedAt:  dokumen.UploadedAt,
+		Isi:         dokumen.Isi,
+	}
+}
+
+func ToPengumumanResponses(pendaftars []domain.Pendaftar) []web.PengumumanResponse {
+	var pengumumanResponses []web.PengumumanResponse
+	for _, pendaftar := range pendaftars {
+		pengumumanResponses = append(pengumumanResponses, web.PengumumanResponse{
+			Id:        pendaftar.Id,
+			Nama:      pendaftar.Nama,
+			Jalur:     pendaftar.Jalur,
+			Peringkat: pendaftar.Peringkat,
+		})
+	}
+	return pengumumanResponses
+}
@@ -0,0 +1,214 @@
+package helper
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/vmihailenco/msgpack/v5"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MediaTypeJSON        = "application/json"
+	MediaTypeNDJSON      = "application/x-ndjson"
+	MediaTypeCSV         = "text/csv"
+	MediaTypeMessagePack = "application/msgpack"
+)
+
+// listMediaTypes are offered by list endpoints, the first one is the default.
+var listMediaTypes = []string{MediaTypeJSON, MediaTypeNDJSON, MediaTypeCSV, MediaTypeMessagePack}
+
+// mediaTypeAliases are names clients use for the same format.
+var mediaTypeAliases = map[string]string{
+	"application/x-msgpack":     MediaTypeMessagePack,
+	"application/vnd.msgpack":   MediaTypeMessagePack,
+	"application/jsonlines":     MediaTypeNDJSON,
+	"application/jsonl":         MediaTypeNDJSON,
+	"application/x-json-stream": MediaTypeNDJSON,
+}
+
+func WriteToResponseBody(writer http.ResponseWriter, response interface{}) {
+	WriteToResponseBodyAs(writer, MediaTypeJSON, response)
+}
+
+// WriteToResponseBodyAs writes response as JSON under another JSON media
+// type, such as application/problem+json.
+func WriteToResponseBodyAs(writer http.ResponseWriter, mediaType string, response interface{}) {
+	writer.Header().Set("Content-Type", mediaType)
+	err := json.NewEncoder(writer).Encode(response)
+	PanicIfError(err)
+}
+
+// WriteListToResponseBody writes a response whose Data is a slice in the
+// format picked from the Accept header: the JSON or MessagePack envelope, or
+// only the items as NDJSON or CSV. Unknown formats fall back to JSON.
+func WriteListToResponseBody(writer http.ResponseWriter, request *http.Request, response web.WebResponse) {
+	writer.Header().Add("Vary", "Accept")
+
+	switch NegotiateMediaType(request.Header.Get("Accept"), listMediaTypes) {
+	case MediaTypeNDJSON:
+		writer.Header().Set("Content-Type", MediaTypeNDJSON)
+		WriteNDJSON(writer, response.Data)
+	case MediaTypeCSV:
+		writer.Header().Set("Content-Type", MediaTypeCSV+"; charset=utf-8")
+		WriteCSV(writer, response.Data)
+	case MediaTypeMessagePack:
+		writer.Header().Set("Content-Type", MediaTypeMessagePack)
+		encoder := msgpack.NewEncoder(writer)
+		encoder.SetCustomStructTag("json")
+		err := encoder.Encode(response)
+		PanicIfError(err)
+	default:
+		WriteToResponseBody(writer, response)
+	}
+}
+
+// NegotiateMediaType returns the offered media type the Accept header
+// prefers most, honoring q values and wildcards, or the first offer when
+// nothing matches.
+func NegotiateMediaType(accept string, offers []string) string {
+	best, bestQuality, bestSpecificity := offers[0], 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if alias, ok := mediaTypeAliases[mediaType]; ok {
+			mediaType = alias
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		for _, offer := range offers {
+			specificity := mediaTypeSpecificity(mediaType, offer)
+			if specificity < 0 {
+				continue
+			}
+			if quality > bestQuality || (quality == bestQuality && specificity > bestSpecificity) {
+				best, bestQuality, bestSpecificity = offer, quality, specificity
+			}
+		}
+	}
+	return best
+}
+
+func mediaTypeSpecificity(pattern string, mediaType string) int {
+	switch {
+	case pattern == mediaType:
+		return 2
+	case pattern == "*/*":
+		return 0
+	case strings.HasSuffix(pattern, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(pattern, "*")):
+		return 1
+	default:
+		return -1
+	}
+}
+
+// WriteNDJSON writes each item of the slice data as one line of JSON.
+func WriteNDJSON(writer io.Writer, data interface{}) {
+	items := reflect.ValueOf(data)
+	encoder := json.NewEncoder(writer)
+	for i := 0; items.IsValid() && i < items.Len(); i++ {
+		err := encoder.Encode(items.Index(i).Interface())
+		PanicIfError(err)
+	}
+}
+
+// WriteCSV writes the slice data as CSV, see ToTable.
+func WriteCSV(writer io.Writer, data interface{}) {
+	header, rows := ToTable(data)
+
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write(header)
+	PanicIfError(err)
+	err = csvWriter.WriteAll(rows)
+	PanicIfError(err)
+}
+
+// ToTable turns the slice data into one row per item, with the json names of
+// the item's fields as header. Nested values are written as JSON.
+func ToTable(data interface{}) ([]string, [][]string) {
+	items := reflect.ValueOf(data)
+	itemType := reflect.TypeOf(data).Elem()
+	for itemType.Kind() == reflect.Ptr {
+		itemType = itemType.Elem()
+	}
+
+	var header []string
+	var fields [][]int
+	collectColumns(itemType, nil, &header, &fields)
+
+	var rows [][]string
+	for i := 0; items.IsValid() && i < items.Len(); i++ {
+		item := reflect.Indirect(items.Index(i))
+		row := make([]string, len(fields))
+		for column, field := range fields {
+			row[column] = csvValue(item.FieldByIndex(field))
+		}
+		rows = append(rows, row)
+	}
+	return header, rows
+}
+
+// collectColumns flattens embedded structs the way encoding/json does.
+func collectColumns(itemType reflect.Type, index []int, header *[]string, fields *[][]int) {
+	for i := 0; i < itemType.NumField(); i++ {
+		field := itemType.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			collectColumns(field.Type, fieldIndex, header, fields)
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+		*header = append(*header, name)
+		*fields = append(*fields, fieldIndex)
+	}
+}
+
+// csvValue quotes strings a spreadsheet would evaluate as a formula, such as
+// a nama of "=HYPERLINK(...)", by prefixing them with an apostrophe.
+func csvValue(value reflect.Value) string {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		text := value.String()
+		if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+			return "'" + text
+		}
+		return text
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return fmt.Sprint(value.Interface())
+	}
+	if timestamp, ok := value.Interface().(time.Time); ok {
+		return timestamp.Format(time.RFC3339)
+	}
+	bytes, err := json.Marshal(value.Interface())
+	PanicIfError(err)
+	return string(bytes)
+}
@@ -0,0 +1,34 @@
+package helper
+
+import "context"
+
+const routeKey contextKey = "route"
+
+// Route is filled in by the router with the pattern that matched, such as
+// /api/siswas/:siswaId, for middlewares that run outside of the router.
+type Route struct {
+	Pattern string
+}
+
+func WithRoute(ctx context.Context, route *Route) context.Context {
+	return context.WithValue(ctx, routeKey, route)
+}
+
+func RouteFromContext(ctx context.Context) *Route {
+	route, ok := ctx.Value(routeKey).(*Route)
+	if !ok {
+		return &Route{}
+	}
+	return route
+}
+
+// EnsureRoute reuses the Route already attached by an outer middleware, so
+// every middleware sees the pattern set by the router.
+func EnsureRoute(ctx context.Context) (context.Context, *Route) {
+	route, ok := ctx.Value(routeKey).(*Route)
+	if !ok {
+		route = &Route{}
+		ctx = WithRoute(ctx, route)
+	}
+	return ctx, route
+}
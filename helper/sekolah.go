@@ -0,0 +1,29 @@
+package helper
+
+import (
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+)
+
+func ToSekolahResponse(sekolah domain.Sekolah) web.SekolahResponse {
+	return web.SekolahResponse{
+		Id:            sekolah.Id,
+		Kode:          sekolah.Kode,
+		Nama:          sekolah.Nama,
+		Bahasa:        sekolah.Bahasa,
+		KuotaSiswa:    sekolah.KuotaSiswa,
+		KuotaZonasi:   sekolah.KuotaZonasi,
+		KuotaPrestasi: sekolah.KuotaPrestasi,
+		KuotaAfirmasi: sekolah.KuotaAfirmasi,
+		CreatedAt:     sekolah.CreatedAt,
+		UpdatedAt:     sekolah.UpdatedAt,
+	}
+}
+
+func ToSekolahResponses(sekolahs []domain.Sekolah) []web.SekolahResponse {
+	var sekolahResponses []web.SekolahResponse
+	for _, sekolah := range sekolahs {
+		sekolahResponses = append(sekolahResponses, ToSekolahResponse(sekolah))
+	}
+	return sekolahResponses
+}
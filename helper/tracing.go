@@ -0,0 +1,29 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const TracerName = "github.com/Arraf18/go-sisko"
+
+// StartSpan starts a span and returns the function that ends it. The end
+// function must be deferred directly so a panic can be recorded on the span
+// before it continues up to the error handler.
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, func()) {
+	ctx, span := otel.Tracer(TracerName).Start(ctx, name)
+	span.SetAttributes(attributes...)
+
+	return ctx, func() {
+		if err := recover(); err != nil {
+			span.RecordError(fmt.Errorf("%v", err))
+			span.SetStatus(codes.Error, fmt.Sprint(err))
+			span.End()
+			panic(err)
+		}
+		span.End()
+	}
+}
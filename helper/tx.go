@@ -1,6 +1,9 @@
 package helper
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+)
 
 func CommitOrRollback(tx *sql.Tx) {
 	err := recover()
@@ -13,3 +16,44 @@ func CommitOrRollback(tx *sql.Tx) {
 		PanicIfError(errorCommit)
 	}
 }
+
+// OnCommit collects what must wait until a transaction is visible to
+// others, such as dropping cache entries a concurrent read would otherwise
+// fill from the old rows. Whoever commits the transaction calls Run.
+type OnCommit []func()
+
+func (onCommit *OnCommit) Add(fn func()) {
+	*onCommit = append(*onCommit, fn)
+}
+
+func (onCommit OnCommit) Run() {
+	for _, fn := range onCommit {
+		fn()
+	}
+}
+
+// Savepoint runs fn inside a SAVEPOINT of tx. A panic in fn only rolls back
+// the work done since the savepoint and is returned instead of propagated,
+// leaving the surrounding transaction usable.
+func Savepoint(ctx context.Context, tx *sql.Tx, name string, fn func()) (err interface{}) {
+	_, errorSavepoint := tx.ExecContext(ctx, "SAVEPOINT "+name)
+	PanicIfError(errorSavepoint)
+
+	defer func() {
+		err = recover()
+		if err != nil {
+			RollbackTo(ctx, tx, name)
+		} else {
+			_, errorRelease := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+			PanicIfError(errorRelease)
+		}
+	}()
+
+	fn()
+	return nil
+}
+
+func RollbackTo(ctx context.Context, tx *sql.Tx, name string) {
+	_, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+	PanicIfError(err)
+}
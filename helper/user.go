@@ -0,0 +1,43 @@
+package helper
+
+import (
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+)
+
+func ToUserResponse(user domain.User) web.UserResponse {
+	return web.UserResponse{
+		Id:        user.Id,
+		Username:  user.Username,
+		Nama:      user.Nama,
+		Sekolah:   user.Sekolah,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+func ToUserResponses(users []domain.User) []web.UserResponse {
+	var userResponses []web.UserResponse
+	for _, user := range users {
+		userResponses = append(userResponses, ToUserResponse(user))
+	}
+	return userResponses
+}
+
+func ToApiKeyResponse(apiKey domain.ApiKey) web.ApiKeyResponse {
+	return web.ApiKeyResponse{
+		Id:        apiKey.Id,
+		Username:  apiKey.Username,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		CreatedAt: apiKey.CreatedAt,
+		RevokedAt: apiKey.RevokedAt,
+	}
+}
+
+func ToApiKeyResponses(apiKeys []domain.ApiKey) []web.ApiKeyResponse {
+	var apiKeyResponses []web.ApiKeyResponse
+	for _, apiKey := range apiKeys {
+		apiKeyResponses = append(apiKeyResponses, ToApiKeyResponse(apiKey))
+	}
+	return apiKeyResponses
+}
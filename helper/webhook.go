@@ -0,0 +1,66 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"strconv"
+)
+
+// SignWebhook returns the X-Sisko-Signature of a delivery: the hex HMAC-SHA256
+// of "<timestamp>.<body>" keyed with the subscription secret. Receivers
+// compute the same and compare, and reject old timestamps against replays.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func ToWebhookResponse(subscription domain.WebhookSubscription) web.WebhookResponse {
+	return web.WebhookResponse{
+		Id:                  subscription.Id,
+		Url:                 subscription.Url,
+		EventTypes:          subscription.EventTypes,
+		Active:              subscription.Active,
+		ConsecutiveFailures: subscription.ConsecutiveFailures,
+		DisabledAt:          subscription.DisabledAt,
+		CreatedAt:           subscription.CreatedAt,
+		UpdatedAt:           subscription.UpdatedAt,
+	}
+}
+
+func ToWebhookResponses(subscriptions []domain.WebhookSubscription) []web.WebhookResponse {
+	var webhookResponses []web.WebhookResponse
+	for _, subscription := range subscriptions {
+		webhookResponses = append(webhookResponses, ToWebhookResponse(subscription))
+	}
+	return webhookResponses
+}
+
+func ToWebhookDeliveryResponse(delivery domain.WebhookDelivery) web.WebhookDeliveryResponse {
+	return web.WebhookDeliveryResponse{
+		Id:             delivery.Id,
+		WebhookId:      delivery.SubscriptionId,
+		EventId:        delivery.EventId,
+		EventType:      delivery.EventType,
+		SiswaId:        delivery.AggregateId,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+func ToWebhookDeliveryResponses(deliveries []domain.WebhookDelivery) []web.WebhookDeliveryResponse {
+	var deliveryResponses []web.WebhookDeliveryResponse
+	for _, delivery := range deliveries {
+		deliveryResponses = append(deliveryResponses, ToWebhookDeliveryResponse(delivery))
+	}
+	return deliveryResponses
+}
@@ -0,0 +1,148 @@
+package job
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression: minute, hour, day of month, month
+// and day of week, each a *, a number, a range a-b or a list of those, with
+// an optional /step. Days of the week go from 0 (Sunday) to 6. The
+// descriptors @hourly, @daily, @weekly, @monthly and @yearly are accepted
+// too. As in cron, a day matches when either day field does if both are
+// restricted.
+type Schedule struct {
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+	anyDay   bool
+	anyWeek  bool
+}
+
+var descriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+	"@yearly":  "0 0 1 1 *",
+}
+
+func ParseSchedule(spec string) (Schedule, error) {
+	if expanded, ok := descriptors[spec]; ok {
+		spec = expanded
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return Schedule{}, errors.New("cron: expected 5 fields in " + strconv.Quote(spec))
+	}
+
+	schedule := Schedule{anyDay: fields[2] == "*", anyWeek: fields[4] == "*"}
+	var err error
+	for i, field := range []struct {
+		bits     *uint64
+		min, max int
+	}{
+		{&schedule.minutes, 0, 59},
+		{&schedule.hours, 0, 23},
+		{&schedule.days, 1, 31},
+		{&schedule.months, 1, 12},
+		{&schedule.weekdays, 0, 6},
+	} {
+		*field.bits, err = parseField(fields[i], field.min, field.max)
+		if err != nil {
+			return Schedule{}, err
+		}
+	}
+	return schedule, nil
+}
+
+func parseField(field string, min int, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if index := strings.Index(part, "/"); index >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[index+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.New("cron: bad step in " + strconv.Quote(field))
+			}
+			part = part[:index]
+		}
+
+		from, to := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			from, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, errors.New("cron: bad value in " + strconv.Quote(field))
+			}
+			to = from
+			if len(bounds) == 2 {
+				if to, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, errors.New("cron: bad range in " + strconv.Quote(field))
+				}
+			} else if step > 1 {
+				to = max
+			}
+		}
+		if from < min || to > max || from > to {
+			return 0, errors.New("cron: " + strconv.Quote(field) + " is out of range " + strconv.Itoa(min) + "-" + strconv.Itoa(max))
+		}
+
+		for value := from; value <= to; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// Next returns the first time after t the schedule fires, to the minute.
+func (schedule Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// every schedule fires within 5 years, when 29 February is a Monday
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !has(schedule.months, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !schedule.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(schedule.hours, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(schedule.minutes, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (schedule Schedule) dayMatches(t time.Time) bool {
+	day, weekday := has(schedule.days, t.Day()), has(schedule.weekdays, int(t.Weekday()))
+	switch {
+	case schedule.anyDay && schedule.anyWeek:
+		return true
+	case schedule.anyDay:
+		return weekday
+	case schedule.anyWeek:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
@@ -0,0 +1,288 @@
+// Package job runs background work from a queue kept in the job table:
+// jobs queued by the application and jobs fired by cron schedules, handled
+// by a pool of workers with retries.
+package job
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/sirupsen/logrus"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// jobLease is how long a job may run. A job running longer is taken to
+	// belong to a worker that went away and is run again.
+	jobLease        = 30 * time.Minute
+	jobMinBackoff   = time.Minute
+	jobMaxBackoff   = time.Hour
+	jobPollInterval = time.Second
+)
+
+// Handler does the work of a job. An error, or a panic, has the job retried
+// until it used up its attempts.
+type Handler func(ctx context.Context, payload []byte) error
+
+type definition struct {
+	Handler     Handler
+	MaxAttempts int
+}
+
+type schedule struct {
+	Name     string
+	Spec     string
+	Schedule Schedule
+}
+
+// Runner queues jobs and runs them on Workers goroutines. Several instances
+// may share the queue: a job is claimed by one worker only, and a schedule
+// fires once per time however many instances see it.
+type Runner struct {
+	DB            *sql.DB
+	JobRepository repository.JobRepository
+	Workers       int
+	worker        string
+	definitions   map[string]definition
+	schedules     []schedule
+	wait          sync.WaitGroup
+}
+
+func NewRunner(DB *sql.DB, jobRepository repository.JobRepository, workers int) *Runner {
+	hostname, _ := os.Hostname()
+	return &Runner{
+		DB:            DB,
+		JobRepository: jobRepository,
+		Workers:       workers,
+		worker:        hostname + "-" + strconv.Itoa(os.Getpid()),
+		definitions:   map[string]definition{},
+	}
+}
+
+// Register makes name runnable, tried up to maxAttempts times.
+func (runner *Runner) Register(name string, maxAttempts int, handler Handler) {
+	runner.definitions[name] = definition{Handler: handler, MaxAttempts: maxAttempts}
+}
+
+// Schedule queues the registered job name whenever the cron expression spec
+// fires. It panics on an invalid spec, like regexp.MustCompile.
+func (runner *Runner) Schedule(name string, spec string) {
+	parsed, err := ParseSchedule(spec)
+	helper.PanicIfError(err)
+	if _, ok := runner.definitions[name]; !ok {
+		panic("job: schedule of unregistered job " + name)
+	}
+	runner.schedules = append(runner.schedules, schedule{Name: name, Spec: spec, Schedule: parsed})
+}
+
+// Enqueue queues a run of the registered job name at runAt.
+func (runner *Runner) Enqueue(ctx context.Context, name string, payload []byte, runAt time.Time) domain.Job {
+	tx, err := runner.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	job, _ := runner.enqueue(ctx, tx, name, payload, "", runAt)
+	return job
+}
+
+// EnqueueTx is Enqueue within tx, so that the job is only queued if the
+// work that asks for it is committed.
+func (runner *Runner) EnqueueTx(ctx context.Context, tx *sql.Tx, name string, payload []byte, runAt time.Time) domain.Job {
+	job, _ := runner.enqueue(ctx, tx, name, payload, "", runAt)
+	return job
+}
+
+func (runner *Runner) enqueue(ctx context.Context, tx *sql.Tx, name string, payload []byte, uniqueKey string, runAt time.Time) (domain.Job, bool) {
+	definition, ok := runner.definitions[name]
+	if !ok {
+		panic("job: enqueue of unregistered job " + name)
+	}
+
+	return runner.JobRepository.Save(ctx, tx, domain.Job{
+		Name:        name,
+		Payload:     payload,
+		UniqueKey:   uniqueKey,
+		Status:      domain.JobQueued,
+		MaxAttempts: definition.MaxAttempts,
+		RunAt:       runAt,
+		CreatedAt:   time.Now(),
+	})
+}
+
+// Start runs the scheduler and the workers until ctx is cancelled. Wait
+// returns once they all stopped.
+func (runner *Runner) Start(ctx context.Context) {
+	runner.wait.Add(1)
+	go func() {
+		defer runner.wait.Done()
+		runner.schedule(ctx)
+	}()
+
+	for i := 0; i < runner.Workers; i++ {
+		runner.wait.Add(1)
+		go func() {
+			defer runner.wait.Done()
+			runner.work(ctx)
+		}()
+	}
+}
+
+// Wait blocks until the goroutines of Start returned, which lets running
+// jobs finish on shutdown.
+func (runner *Runner) Wait() {
+	runner.wait.Wait()
+}
+
+func (runner *Runner) schedule(ctx context.Context) {
+	next := make([]time.Time, len(runner.schedules))
+	for i, schedule := range runner.schedules {
+		next[i] = schedule.Schedule.Next(time.Now())
+	}
+
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for i, schedule := range runner.schedules {
+				if now.Before(next[i]) {
+					continue
+				}
+				runner.fire(ctx, schedule, next[i])
+				next[i] = schedule.Schedule.Next(now)
+			}
+		}
+	}
+}
+
+func (runner *Runner) fire(ctx context.Context, schedule schedule, at time.Time) {
+	logger := logrus.WithFields(logrus.Fields{"job": schedule.Name, "schedule": schedule.Spec})
+	defer func() {
+		if err := recover(); err != nil {
+			logger.WithField("error", err).Error("queueing scheduled job failed")
+		}
+	}()
+
+	tx, err := runner.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	// the key makes the instances firing the same time queue a single job
+	if job, queued := runner.enqueue(ctx, tx, schedule.Name, nil, schedule.Name+"@"+at.UTC().Format(time.RFC3339), at); queued {
+		logger.WithField("job_id", job.Id).Debug("scheduled job queued")
+	}
+}
+
+func (runner *Runner) work(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		if runner.RunOnce(ctx) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(jobPollInterval):
+		}
+	}
+}
+
+// RunOnce runs the job due the longest, if any, and reports whether there
+// was one.
+func (runner *Runner) RunOnce(ctx context.Context) (ran bool) {
+	defer func() {
+		if err := recover(); err != nil {
+			logrus.WithField("error", err).Error("claiming job failed")
+			ran = false
+		}
+	}()
+
+	job, ok := runner.claim(ctx)
+	if !ok {
+		return false
+	}
+
+	logger := logrus.WithFields(logrus.Fields{"job": job.Name, "job_id": job.Id, "attempt": job.Attempts})
+	start := time.Now()
+	// a job that started is let finish on shutdown
+	err := runner.run(context.Background(), job)
+	logger = logger.WithField("duration", time.Since(start).String())
+
+	finishedAt := time.Now()
+	job.LockedBy = ""
+	job.LockedAt = nil
+	if err == nil {
+		logger.Info("job succeeded")
+		job.Status = domain.JobSucceeded
+		job.LastError = ""
+		job.FinishedAt = &finishedAt
+	} else if job.Attempts >= job.MaxAttempts {
+		logger.WithField("error", err).Error("job failed, no attempts left")
+		job.Status = domain.JobFailed
+		job.LastError = err.Error()
+		job.FinishedAt = &finishedAt
+	} else {
+		backoff := jobBackoff(job.Attempts)
+		logger.WithFields(logrus.Fields{"error": err, "retry_in": backoff.String()}).Warn("job failed, retrying")
+		job.Status = domain.JobQueued
+		job.LastError = err.Error()
+		job.RunAt = finishedAt.Add(backoff)
+	}
+
+	tx, err := runner.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+	runner.JobRepository.Update(ctx, tx, job)
+	return true
+}
+
+func (runner *Runner) claim(ctx context.Context) (domain.Job, bool) {
+	tx, err := runner.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	now := time.Now()
+	job, err := runner.JobRepository.Claim(ctx, tx, runner.worker, now, now.Add(-jobLease))
+	return job, err == nil
+}
+
+// run calls the handler with a deadline of jobLease, so that a job is given
+// up before another worker takes it over.
+func (runner *Runner) run(ctx context.Context, job domain.Job) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+
+	definition, ok := runner.definitions[job.Name]
+	if !ok {
+		return fmt.Errorf("job %s is not registered", job.Name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jobLease)
+	defer cancel()
+	return definition.Handler(ctx, job.Payload)
+}
+
+func jobBackoff(attempts int) time.Duration {
+	backoff := jobMinBackoff
+	for i := 1; i < attempts && backoff < jobMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > jobMaxBackoff {
+		return jobMaxBackoff
+	}
+	return backoff
+}
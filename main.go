@@ -1,31 +1,177 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"github.com/Arraf18/go-sisko/app"
 	"github.com/Arraf18/go-sisko/controller"
+	"github.com/Arraf18/go-sisko/graph"
 	"github.com/Arraf18/go-sisko/helper"
 	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/Arraf18/go-sisko/outbox"
 	"github.com/Arraf18/go-sisko/repository"
 	"github.com/Arraf18/go-sisko/service"
 	"github.com/go-playground/validator"
 	_ "github.com/go-sql-driver/mysql"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := app.NewLogger()
+	shutdownTracing := app.NewTracerProvider()
+
 	db := app.NewDB()
 	validate := validator.New()
 	siswaRepository := repository.NewSiswaRepository()
-	siswaService := service.NewSiswaService(siswaRepository, db, validate)
+	auditRepository := repository.NewAuditRepository()
+	siswaCache, closeCache := app.NewCache()
+	siswaService := service.NewSiswaServiceCache(service.NewSiswaService(siswaRepository, auditRepository, repository.NewOutboxEventRepository(), db, validate), siswaCache, app.NewCacheTTL())
+	auditService := service.NewAuditService(auditRepository, db)
+	idempotencyService := service.NewIdempotencyService(repository.NewIdempotencyKeyRepository(), db, app.NewIdempotencyTTL())
+	sekolahRepository := repository.NewSekolahRepository()
+	userService := service.NewUserService(repository.NewUserRepository(), repository.NewApiKeyRepository(), sekolahRepository, db, validate, app.NewBootstrapApiKey())
+	sekolahService := service.NewSekolahService(sekolahRepository, repository.NewUserRepository(), db, validate, app.NewDefaultSekolah())
+	sekolahController := controller.NewSekolahController(sekolahService)
+	healthService := service.NewHealthService(repository.NewMigrationRepository(), db, app.NewMigrationCheck())
 	siswaController := controller.NewSiswaController(siswaService)
-	router := app.NewRouter(siswaController)
+	siswaControllerV2 := controller.NewSiswaControllerV2(siswaService)
+	auditController := controller.NewAuditController(auditService)
+	healthController := controller.NewHealthController(healthService)
+	graphqlController := controller.NewGraphqlController(graph.NewSchema(siswaService, auditService), siswaService, auditService, app.NewGraphqlMaxComplexity())
+	webhookService := service.NewWebhookService(repository.NewWebhookSubscriptionRepository(), repository.NewWebhookDeliveryRepository(), db, validate, app.NewWebhookClient(), app.NewWebhookDisableAfter())
+	webhookController := controller.NewWebhookController(webhookService)
+	jobService := service.NewJobService(repository.NewJobRepository(), db, validate)
+	jobController := controller.NewJobController(jobService)
 
-	server := http.Server{
-		Addr:    "localhost:3000",
-		Handler: middleware.NewAuthMiddleware(router),
-	}
+	bus := outbox.NewBus()
+	bus.Subscribe(app.NewWebhookHandler(webhookService))
+	outboxSinks, closeOutboxSinks := app.NewOutboxSinks(bus)
+	outboxRelay := outbox.NewRelay(db, repository.NewOutboxEventRepository(), outboxSinks, app.NewOutboxRetention(), app.NewOutboxMaxAttempts())
+	outboxRelay.Start(ctx, app.NewOutboxInterval())
+	app.StartWebhookDispatcher(ctx, webhookService, app.NewWebhookInterval())
+
+	jobRunner := app.NewJobRunner(db, siswaService, sekolahService, idempotencyService, jobService, outboxRelay)
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(), repository.NewNotificationPreferenceRepository(), siswaRepository, db, validate, jobRunner, app.NewNotificationProviders())
+	notificationController := controller.NewNotificationController(notificationService)
+	app.RegisterNotificationJobs(jobRunner, notificationService)
+	jobRunner.Start(ctx)
+
+	pendaftarService := service.NewPendaftarService(repository.NewPendaftarRepository(), siswaService, db, validate)
+	pendaftarController := controller.NewPendaftarController(pendaftarService)
+
+	router := app.NewRouter(siswaController, siswaControllerV2, auditController, graphqlController, webhookController, jobController, notificationController, sekolahController, pendaftarController)
+
+	registry := app.NewMetricsRegistry(db, siswaService, sekolahService)
+	publicRouter := app.NewPublicRouter(healthController, registry)
+	ppdbRouter := middleware.NewTenantMiddleware(app.NewPpdbRouter(pendaftarController), sekolahService, app.NewTenantDomain())
 
-	err := server.ListenAndServe()
+	var handler http.Handler = router
+	handler = middleware.NewIdempotencyMiddleware(handler, idempotencyService)
+	handler = middleware.NewTenantMiddleware(handler, sekolahService, app.NewTenantDomain())
+	authLockout := app.NewAuthLockout()
+	handler = middleware.NewAuthMiddleware(handler, authLockout, userService)
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", publicRouter)
+	mux.Handle("/readyz", publicRouter)
+	mux.Handle("/metrics", publicRouter)
+	mux.Handle("/api/openapi.json", publicRouter)
+	mux.Handle("/api/docs", publicRouter)
+	mux.Handle("/api/v2/ppdb/", ppdbRouter)
+	mux.Handle("/", handler)
+
+	handler = middleware.NewBodyLimitMiddleware(mux, app.NewMaxBodySize())
+	handler = middleware.NewCorsMiddleware(handler, app.NewCorsConfig())
+	handler = middleware.NewSecurityHeadersMiddleware(handler)
+	handler = middleware.NewCompressionMiddleware(handler, app.NewCompressionMinSize())
+	handler = middleware.NewMetricsMiddleware(handler, registry)
+	handler = middleware.NewTracingMiddleware(handler)
+	handler = middleware.NewLoggingMiddleware(handler, logger)
+
+	server := app.NewServer(handler)
+
+	// a server that cannot serve shuts the other one down cleanly too
+	serveErrors := make(chan error, 2)
+	go func() {
+		logger.WithField("addr", server.Addr).Info("server started")
+		err := server.ListenAndServe()
+		if !errors.Is(err, http.ErrServerClosed) {
+			logger.WithField("error", err).Error("server failed")
+			serveErrors <- err
+			stop()
+		}
+	}()
+
+	// the same failed attempts lock a client out of both servers
+	grpcServer := app.NewGrpcServer(controller.NewSiswaGrpcServer(siswaService), middleware.NewGrpcInterceptor(logger, authLockout, userService, sekolahService))
+	grpcListener, err := net.Listen("tcp", app.NewGrpcAddr())
 	helper.PanicIfError(err)
+
+	go func() {
+		logger.WithField("addr", grpcListener.Addr().String()).Info("grpc server started")
+		err := grpcServer.Serve(grpcListener)
+		if err != nil {
+			logger.WithField("error", err).Error("grpc server failed")
+			serveErrors <- err
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	logger.Info("shutting down, draining in-flight requests")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), app.NewShutdownTimeout())
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	err = server.Shutdown(shutdownCtx)
+	if err != nil {
+		logger.WithField("error", err).Error("server did not shut down cleanly")
+	}
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		grpcServer.Stop()
+	}
+	jobsStopped := make(chan struct{})
+	go func() {
+		jobRunner.Wait()
+		close(jobsStopped)
+	}()
+	select {
+	case <-jobsStopped:
+	case <-shutdownCtx.Done():
+		logger.Warn("running jobs did not finish, they will be run again")
+	}
+	err = shutdownTracing(shutdownCtx)
+	if err != nil {
+		logger.WithField("error", err).Error("flushing traces failed")
+	}
+	err = closeCache(shutdownCtx)
+	if err != nil {
+		logger.WithField("error", err).Error("closing cache failed")
+	}
+	err = closeOutboxSinks()
+	if err != nil {
+		logger.WithField("error", err).Error("closing outbox sinks failed")
+	}
+	err = db.Close()
+	helper.PanicIfError(err)
+
+	logger.Info("server stopped")
+	if len(serveErrors) > 0 {
+		os.Exit(1)
+	}
 }
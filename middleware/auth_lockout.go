@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+)
+
+// AuthLockout blocks a client for Duration after MaxFailures failed
+// authentication attempts within Window. A client is an address, which is
+// refused before its key is looked up, so a locked out client can neither
+// guess further nor cost a database lookup per guess.
+type AuthLockout struct {
+	MaxFailures int
+	Window      time.Duration
+	Duration    time.Duration
+	mutex       sync.Mutex
+	clients     map[string]*authFailures
+	lastSweep   time.Time
+}
+
+type authFailures struct {
+	count       int
+	since       time.Time
+	lockedUntil time.Time
+}
+
+func NewAuthLockout(maxFailures int, window time.Duration, duration time.Duration) *AuthLockout {
+	return &AuthLockout{MaxFailures: maxFailures, Window: window, Duration: duration, clients: map[string]*authFailures{}, lastSweep: time.Now()}
+}
+
+// LockedFor returns how long client is still locked out, or 0.
+func (lockout *AuthLockout) LockedFor(client string) time.Duration {
+	lockout.mutex.Lock()
+	defer lockout.mutex.Unlock()
+
+	failures, ok := lockout.clients[client]
+	if !ok {
+		return 0
+	}
+	remaining := time.Until(failures.lockedUntil)
+	if remaining <= 0 {
+		return 0
+	}
+	return remaining
+}
+
+// Fail records a failed attempt and returns the lockout it triggered, or 0.
+func (lockout *AuthLockout) Fail(client string) time.Duration {
+	lockout.mutex.Lock()
+	defer lockout.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(lockout.lastSweep) >= lockout.Window {
+		for key, failures := range lockout.clients {
+			if lockout.expired(failures, now) {
+				delete(lockout.clients, key)
+			}
+		}
+		lockout.lastSweep = now
+	}
+
+	failures, ok := lockout.clients[client]
+	if !ok || lockout.expired(failures, now) {
+		failures = &authFailures{since: now}
+		lockout.clients[client] = failures
+	}
+	failures.count++
+	if failures.count < lockout.MaxFailures {
+		return 0
+	}
+
+	failures.count = 0
+	failures.since = now
+	failures.lockedUntil = now.Add(lockout.Duration)
+	return lockout.Duration
+}
+
+func (lockout *AuthLockout) expired(failures *authFailures, now time.Time) bool {
+	return now.Sub(failures.since) > lockout.Window && now.After(failures.lockedUntil)
+}
+
+// Succeed forgets earlier failures of client.
+func (lockout *AuthLockout) Succeed(client string) {
+	lockout.mutex.Lock()
+	defer lockout.mutex.Unlock()
+
+	delete(lockout.clients, client)
+}
@@ -1,25 +1,57 @@
 package middleware
 
 import (
+	"errors"
+	"github.com/Arraf18/go-sisko/exception"
 	"github.com/Arraf18/go-sisko/helper"
 	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
 	"net/http"
 )
 
+var errAuthenticationAnswered = errors.New("authentication failed and was answered")
+
 type AuthMiddleware struct {
-	Handler http.Handler
+	Handler     http.Handler
+	Lockout     *AuthLockout
+	UserService service.UserService
 }
 
-func NewAuthMiddleware(handler http.Handler) *AuthMiddleware {
-	return &AuthMiddleware{Handler: handler}
+func NewAuthMiddleware(handler http.Handler, lockout *AuthLockout, userService service.UserService) *AuthMiddleware {
+	return &AuthMiddleware{Handler: handler, Lockout: lockout, UserService: userService}
 }
 
 func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
-	if "RAHASIA" == request.Header.Get("X-API-Key") {
+	client := ClientIP(request)
+	if lockedFor := middleware.Lockout.LockedFor(client); lockedFor > 0 {
+		writer.Header().Set("Retry-After", seconds(lockedFor))
+		exception.WriteError(writer, request, exception.NewTooManyRequestsError("too many failed authentication attempts, retry in "+seconds(lockedFor)+" seconds"))
+		return
+	}
+
+	actor, err := middleware.authenticate(writer, request)
+	if err == nil {
 		// ok
-		middleware.Handler.ServeHTTP(writer, request)
-	} else {
+		middleware.Lockout.Succeed(client)
+		setAccessLogActor(request.Context(), actor)
+
+		ctx := helper.WithActor(request.Context(), actor)
+		ctx = helper.WithLogger(ctx, helper.Logger(ctx).WithField("actor", actor))
+		middleware.Handler.ServeHTTP(writer, request.WithContext(ctx))
+	} else if errors.Is(err, service.ErrInvalidApiKey) {
 		//error
+		logger := helper.Logger(request.Context()).WithField("client_ip", client)
+		if lockedFor := middleware.Lockout.Fail(client); lockedFor > 0 {
+			logger.WithField("locked_for", lockedFor.String()).Warn("authentication failed, client locked out")
+		} else {
+			logger.Info("authentication failed")
+		}
+
+		if exception.UsesProblemDetails(request) {
+			exception.WriteProblem(writer, request, http.StatusUnauthorized, err.Error())
+			return
+		}
+
 		writer.Header().Set("Content-Type", "application/json")
 		writer.WriteHeader(http.StatusUnauthorized)
 
@@ -31,3 +63,16 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 		helper.WriteToResponseBody(writer, webResponse)
 	}
 }
+
+// authenticate answers the request itself when looking up the key fails,
+// which happens outside the router and its PanicHandler.
+func (middleware *AuthMiddleware) authenticate(writer http.ResponseWriter, request *http.Request) (actor string, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			exception.ErrorHandler(writer, request, recovered)
+			err = errAuthenticationAnswered
+		}
+	}()
+
+	return middleware.UserService.Authenticate(request.Context(), request.Header.Get("X-API-Key"))
+}
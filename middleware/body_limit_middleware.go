@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"net/http"
+)
+
+// BodyLimitMiddleware answers 413 to bodies larger than MaxBytes: at once
+// when Content-Length says so, otherwise as soon as the handler reads past
+// the limit, which is before helper.ReadFromRequestBody decodes anything.
+type BodyLimitMiddleware struct {
+	Handler  http.Handler
+	MaxBytes int64
+}
+
+func NewBodyLimitMiddleware(handler http.Handler, maxBytes int64) *BodyLimitMiddleware {
+	return &BodyLimitMiddleware{Handler: handler, MaxBytes: maxBytes}
+}
+
+func (middleware *BodyLimitMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.ContentLength > middleware.MaxBytes {
+		exception.WriteError(writer, request, helper.ErrRequestBodyTooLarge)
+		return
+	}
+
+	request.Body = helper.LimitBody(request.Body, middleware.MaxBytes)
+	middleware.Handler.ServeHTTP(writer, request)
+}
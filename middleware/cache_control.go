@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+// CacheControl sets the Cache-Control header of a route. Error responses
+// replace it with no-store, see exception.WriteError.
+func CacheControl(value string, handle httprouter.Handle) httprouter.Handle {
+	return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		writer.Header().Set("Cache-Control", value)
+		handle(writer, request, params)
+	}
+}
@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"github.com/andybalholm/brotli"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CompressionMiddleware compresses responses of at least MinSize bytes with
+// brotli or gzip, whichever Accept-Encoding prefers. Smaller responses are
+// sent as they are, compressing them costs more than it saves.
+type CompressionMiddleware struct {
+	Handler http.Handler
+	MinSize int
+}
+
+func NewCompressionMiddleware(handler http.Handler, minSize int) *CompressionMiddleware {
+	return &CompressionMiddleware{Handler: handler, MinSize: minSize}
+}
+
+var gzipWriters = sync.Pool{New: func() interface{} {
+	return gzip.NewWriter(io.Discard)
+}}
+
+// incompressibleMediaTypes are already compressed.
+var incompressibleMediaTypes = []string{"image/", "video/", "audio/", "application/zip", "application/gzip"}
+
+func (middleware *CompressionMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Add("Vary", "Accept-Encoding")
+
+	encoding := negotiateEncoding(request.Header.Get("Accept-Encoding"))
+	if encoding == "" || request.Method == http.MethodHead {
+		middleware.Handler.ServeHTTP(writer, request)
+		return
+	}
+
+	compressWriter := &compressWriter{ResponseWriter: writer, encoding: encoding, minSize: middleware.MinSize}
+	defer compressWriter.Close()
+	middleware.Handler.ServeHTTP(compressWriter, request)
+}
+
+// negotiateEncoding picks br or gzip from Accept-Encoding, preferring br
+// when both have the same q value, or returns "" for identity.
+func negotiateEncoding(acceptEncoding string) string {
+	best, bestQuality := "", 0.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, quality := strings.TrimSpace(part), 1.0
+		if index := strings.Index(name, ";"); index >= 0 {
+			param := strings.TrimSpace(name[index+1:])
+			name = strings.TrimSpace(name[:index])
+			if strings.HasPrefix(param, "q=") {
+				parsed, err := strconv.ParseFloat(param[2:], 64)
+				if err != nil {
+					continue
+				}
+				quality = parsed
+			}
+		}
+
+		name = strings.ToLower(name)
+		if name == "*" {
+			name = "br"
+		}
+		if (name != "br" && name != "gzip") || quality <= 0 {
+			continue
+		}
+		if quality > bestQuality || (quality == bestQuality && name == "br") {
+			best, bestQuality = name, quality
+		}
+	}
+	return best
+}
+
+// compressWriter holds back the status and the first MinSize bytes until it
+// knows whether the response is worth compressing.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+	status   int
+	buffer   []byte
+	started  bool
+	encoder  io.WriteCloser
+}
+
+func (writer *compressWriter) WriteHeader(status int) {
+	if writer.started || writer.status != 0 {
+		return
+	}
+	writer.status = status
+}
+
+func (writer *compressWriter) Write(p []byte) (int, error) {
+	if writer.started {
+		if writer.encoder != nil {
+			return writer.encoder.Write(p)
+		}
+		return writer.ResponseWriter.Write(p)
+	}
+
+	writer.buffer = append(writer.buffer, p...)
+	if len(writer.buffer) >= writer.minSize {
+		if err := writer.start(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush starts compressing right away, so streamed responses such as NDJSON
+// reach the client without waiting for MinSize bytes.
+func (writer *compressWriter) Flush() {
+	if !writer.started {
+		writer.start(true)
+	}
+	if flusher, ok := writer.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	if flusher, ok := writer.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (writer *compressWriter) Close() error {
+	if !writer.started {
+		if writer.status == 0 && len(writer.buffer) == 0 {
+			return nil
+		}
+		if err := writer.start(false); err != nil {
+			return err
+		}
+	}
+	if writer.encoder == nil {
+		return nil
+	}
+
+	err := writer.encoder.Close()
+	if gzipWriter, ok := writer.encoder.(*gzip.Writer); ok {
+		gzipWriters.Put(gzipWriter)
+	}
+	return err
+}
+
+func (writer *compressWriter) start(compress bool) error {
+	writer.started = true
+	if writer.status == 0 {
+		writer.status = http.StatusOK
+	}
+
+	header := writer.Header()
+	if compress && writer.compressible() {
+		header.Set("Content-Encoding", writer.encoding)
+		header.Del("Content-Length")
+		// the compressed bytes differ, so a strong validator would lie
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+
+		if writer.encoding == "br" {
+			writer.encoder = brotli.NewWriterLevel(writer.ResponseWriter, brotli.DefaultCompression)
+		} else {
+			gzipWriter := gzipWriters.Get().(*gzip.Writer)
+			gzipWriter.Reset(writer.ResponseWriter)
+			writer.encoder = gzipWriter
+		}
+	}
+
+	writer.ResponseWriter.WriteHeader(writer.status)
+	if len(writer.buffer) == 0 {
+		return nil
+	}
+
+	var err error
+	if writer.encoder != nil {
+		_, err = writer.encoder.Write(writer.buffer)
+	} else {
+		_, err = writer.ResponseWriter.Write(writer.buffer)
+	}
+	writer.buffer = nil
+	return err
+}
+
+func (writer *compressWriter) compressible() bool {
+	header := writer.Header()
+	if header.Get("Content-Encoding") != "" || writer.status == http.StatusNoContent || writer.status == http.StatusNotModified {
+		return false
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	for _, incompressible := range incompressibleMediaTypes {
+		if strings.HasPrefix(mediaType, incompressible) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CorsConfig lists what browsers on other origins may do. An AllowedOrigins
+// entry of "*" allows every origin.
+type CorsConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// CorsMiddleware answers preflight requests itself, in front of
+// AuthMiddleware, because browsers never send X-API-Key with them.
+type CorsMiddleware struct {
+	Handler http.Handler
+	Config  CorsConfig
+}
+
+func NewCorsMiddleware(handler http.Handler, config CorsConfig) *CorsMiddleware {
+	return &CorsMiddleware{Handler: handler, Config: config}
+}
+
+func (middleware *CorsMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	origin := request.Header.Get("Origin")
+	if origin == "" {
+		middleware.Handler.ServeHTTP(writer, request)
+		return
+	}
+
+	header := writer.Header()
+	header.Add("Vary", "Origin")
+	preflight := request.Method == http.MethodOptions && request.Header.Get("Access-Control-Request-Method") != ""
+	if preflight {
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+	}
+
+	if !middleware.allowedOrigin(origin) {
+		if preflight {
+			writer.WriteHeader(http.StatusForbidden)
+			return
+		}
+		middleware.Handler.ServeHTTP(writer, request)
+		return
+	}
+
+	if middleware.Config.AllowCredentials || !contains(middleware.Config.AllowedOrigins, "*") {
+		header.Set("Access-Control-Allow-Origin", origin)
+	} else {
+		header.Set("Access-Control-Allow-Origin", "*")
+	}
+	if middleware.Config.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if !preflight {
+		if len(middleware.Config.ExposedHeaders) > 0 {
+			header.Set("Access-Control-Expose-Headers", strings.Join(middleware.Config.ExposedHeaders, ", "))
+		}
+		middleware.Handler.ServeHTTP(writer, request)
+		return
+	}
+
+	if !contains(middleware.Config.AllowedMethods, request.Header.Get("Access-Control-Request-Method")) {
+		writer.WriteHeader(http.StatusForbidden)
+		return
+	}
+	for _, requested := range strings.Split(request.Header.Get("Access-Control-Request-Headers"), ",") {
+		requested = strings.TrimSpace(requested)
+		if requested != "" && !containsFold(middleware.Config.AllowedHeaders, requested) {
+			writer.WriteHeader(http.StatusForbidden)
+			return
+		}
+	}
+
+	header.Set("Access-Control-Allow-Methods", strings.Join(middleware.Config.AllowedMethods, ", "))
+	header.Set("Access-Control-Allow-Headers", strings.Join(middleware.Config.AllowedHeaders, ", "))
+	if middleware.Config.MaxAge > 0 {
+		header.Set("Access-Control-Max-Age", strconv.Itoa(int(middleware.Config.MaxAge.Seconds())))
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (middleware *CorsMiddleware) allowedOrigin(origin string) bool {
+	return contains(middleware.Config.AllowedOrigins, "*") || contains(middleware.Config.AllowedOrigins, origin)
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DeprecationMiddleware marks responses of a superseded API version with the
+// Deprecation, Sunset and successor Link headers.
+type DeprecationMiddleware struct {
+	Handler   http.Handler
+	Sunset    time.Time
+	Successor string
+}
+
+func NewDeprecationMiddleware(handler http.Handler, sunset time.Time, successor string) *DeprecationMiddleware {
+	return &DeprecationMiddleware{Handler: handler, Sunset: sunset, Successor: successor}
+}
+
+func (middleware *DeprecationMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if strings.HasPrefix(request.URL.Path, "/api/") {
+		writer.Header().Set("Deprecation", "true")
+		writer.Header().Set("Sunset", middleware.Sunset.UTC().Format(http.TimeFormat))
+		writer.Header().Set("Link", "<"+middleware.Successor+">; rel=\"successor-version\"")
+	}
+	middleware.Handler.ServeHTTP(writer, request)
+}
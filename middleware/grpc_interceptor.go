@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// GrpcInterceptor does for gRPC calls what LoggingMiddleware, AuthMiddleware
+// and exception.ErrorHandler do for HTTP requests: a request id and access
+// log line, the x-api-key check with the same lockout, and panics turned into
+// status codes. Like TenantMiddleware it scopes calls to the school named by
+// x-sekolah.
+type GrpcInterceptor struct {
+	Logger         *logrus.Logger
+	Lockout        *AuthLockout
+	UserService    service.UserService
+	SekolahService service.SekolahService
+}
+
+func NewGrpcInterceptor(logger *logrus.Logger, lockout *AuthLockout, userService service.UserService, sekolahService service.SekolahService) *GrpcInterceptor {
+	return &GrpcInterceptor{Logger: logger, Lockout: lockout, UserService: userService, SekolahService: sekolahService}
+}
+
+func (interceptor *GrpcInterceptor) Unary(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (response interface{}, err error) {
+	err = interceptor.serve(ctx, info.FullMethod, func(ctx context.Context) error {
+		var err error
+		response, err = handler(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (interceptor *GrpcInterceptor) Stream(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return interceptor.serve(stream.Context(), info.FullMethod, func(ctx context.Context) error {
+		return handler(server, &grpcServerStream{ServerStream: stream, ctx: ctx})
+	})
+}
+
+func (interceptor *GrpcInterceptor) serve(ctx context.Context, method string, call func(ctx context.Context) error) (err error) {
+	start := time.Now()
+
+	requestId := firstMetadata(ctx, "x-request-id")
+	if requestId == "" || len(requestId) > 64 {
+		requestId = newRequestId()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs("x-request-id", requestId))
+
+	logger := interceptor.Logger.WithField("request_id", requestId)
+	ctx = helper.WithRequestId(ctx, requestId)
+	ctx = helper.WithLogger(ctx, logger)
+
+	client := grpcClientIP(ctx)
+	actor := ""
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = exception.ToStatus(recovered).Err()
+			entry := logger.WithFields(logrus.Fields{"error": recovered, "stack": string(debug.Stack())})
+			if status.Code(err) == codes.Internal {
+				entry.Error("recovered panic")
+			} else {
+				entry.Warn("recovered panic")
+			}
+		}
+
+		logger.WithFields(logrus.Fields{
+			"method":     method,
+			"code":       status.Code(err).String(),
+			"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+			"actor":      actor,
+			"remote":     client,
+		}).Info("call completed")
+	}()
+
+	if lockedFor := interceptor.Lockout.LockedFor(client); lockedFor > 0 {
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", seconds(lockedFor)))
+		return status.Error(codes.ResourceExhausted, "too many failed authentication attempts, retry in "+seconds(lockedFor)+" seconds")
+	}
+
+	actor, err = interceptor.UserService.Authenticate(ctx, firstMetadata(ctx, "x-api-key"))
+	if err != nil {
+		logger := logger.WithField("client_ip", client)
+		if lockedFor := interceptor.Lockout.Fail(client); lockedFor > 0 {
+			logger.WithField("locked_for", lockedFor.String()).Warn("authentication failed, client locked out")
+		} else {
+			logger.Info("authentication failed")
+		}
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	interceptor.Lockout.Succeed(client)
+
+	sekolah, restricted := interceptor.SekolahService.Resolve(ctx, actor, strings.ToLower(firstMetadata(ctx, "x-sekolah")))
+	ctx = helper.WithActor(ctx, actor)
+	ctx = helper.WithSekolah(ctx, sekolah, restricted)
+	ctx = helper.WithLogger(ctx, logger.WithFields(logrus.Fields{"actor": actor, "sekolah": sekolah.Kode}))
+	return call(ctx)
+}
+
+// grpcServerStream hands the authenticated context to streaming handlers.
+type grpcServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *grpcServerStream) Context() context.Context {
+	return stream.ctx
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	values := metadata.ValueFromIncomingContext(ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func grpcClientIP(ctx context.Context) string {
+	client, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(client.Addr.String())
+	if err != nil {
+		return client.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"io"
+	"net/http"
+)
+
+type IdempotencyMiddleware struct {
+	Handler            http.Handler
+	IdempotencyService service.IdempotencyService
+}
+
+func NewIdempotencyMiddleware(handler http.Handler, idempotencyService service.IdempotencyService) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{Handler: handler, IdempotencyService: idempotencyService}
+}
+
+// replayedHeaders are the headers of a response, besides Content-Type, that
+// a replay repeats, so a retried create still tells where the siswa is and
+// which version it has.
+var replayedHeaders = []string{"Location", "ETag", "Cache-Control"}
+
+func (middleware *IdempotencyMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	key := request.Header.Get("Idempotency-Key")
+	if request.Method != http.MethodPost || key == "" {
+		middleware.Handler.ServeHTTP(writer, request)
+		return
+	}
+
+	ctx := request.Context()
+	started, responded := false, false
+	defer func() {
+		if err := recover(); err != nil {
+			// a claimed key would answer 409 until it expires
+			if started {
+				middleware.abort(request, key)
+			}
+			if responded {
+				helper.Logger(ctx).WithField("error", err).Error("storing idempotent response failed")
+				return
+			}
+			exception.ErrorHandler(writer, request, err)
+		}
+	}()
+
+	if len(key) > 255 {
+		panic(exception.NewBadRequestError("Idempotency-Key must be at most 255 characters"))
+	}
+
+	body, err := io.ReadAll(request.Body)
+	helper.PanicIfError(err)
+	request.Body = io.NopCloser(bytes.NewReader(body))
+
+	stored := middleware.IdempotencyService.Start(ctx, key, fingerprint(request, body))
+	if stored != nil {
+		writer.Header().Set("Content-Type", stored.ContentType)
+		for name, value := range stored.Headers {
+			writer.Header().Set(name, value)
+		}
+		writer.Header().Set("Idempotent-Replayed", "true")
+		writer.WriteHeader(stored.StatusCode)
+		writer.Write(stored.Body)
+		return
+	}
+	started = true
+
+	recorder := newResponseWriter(writer)
+	recorder.Body = &bytes.Buffer{}
+	middleware.Handler.ServeHTTP(recorder, request)
+	responded = true
+
+	// server errors and rate limiting are not remembered so the client can
+	// safely retry them
+	if recorder.StatusCode >= http.StatusInternalServerError || recorder.StatusCode == http.StatusTooManyRequests {
+		middleware.IdempotencyService.Abort(ctx, key)
+		return
+	}
+
+	headers := map[string]string{}
+	for _, name := range replayedHeaders {
+		if value := recorder.Header().Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+	middleware.IdempotencyService.Finish(ctx, key, web.IdempotentResponse{
+		StatusCode:  recorder.StatusCode,
+		ContentType: recorder.Header().Get("Content-Type"),
+		Headers:     headers,
+		Body:        recorder.Body.Bytes(),
+	})
+}
+
+// abort releases key after a panic. When that fails too, the key is only
+// released once it expires.
+func (middleware *IdempotencyMiddleware) abort(request *http.Request, key string) {
+	defer func() {
+		if err := recover(); err != nil {
+			helper.Logger(request.Context()).WithField("error", err).Error("releasing Idempotency-Key failed")
+		}
+	}()
+	middleware.IdempotencyService.Abort(request.Context(), key)
+}
+
+// fingerprint covers the school too, so a key reused on another school is
+// refused instead of replaying a response of the first.
+func fingerprint(request *http.Request, body []byte) string {
+	sekolah, _ := helper.SekolahFromContext(request.Context())
+	hash := sha256.New()
+	hash.Write([]byte(sekolah.Kode + "\n"))
+	hash.Write([]byte(request.Method + " " + request.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
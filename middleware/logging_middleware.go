@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+)
+
+type LoggingMiddleware struct {
+	Handler http.Handler
+	Logger  *logrus.Logger
+}
+
+func NewLoggingMiddleware(handler http.Handler, logger *logrus.Logger) *LoggingMiddleware {
+	return &LoggingMiddleware{Handler: handler, Logger: logger}
+}
+
+type accessLogKey struct{}
+
+// accessLog collects what inner middlewares learn about a request, such as
+// the authenticated caller, so it ends up in the access log line.
+type accessLog struct {
+	Actor string
+}
+
+func (middleware *LoggingMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	start := time.Now()
+
+	requestId := request.Header.Get("X-Request-ID")
+	if requestId == "" || len(requestId) > 64 {
+		requestId = newRequestId()
+	}
+	writer.Header().Set("X-Request-ID", requestId)
+
+	logger := middleware.Logger.WithField("request_id", requestId)
+	log := &accessLog{}
+
+	ctx := helper.WithRequestId(request.Context(), requestId)
+	ctx = helper.WithLogger(ctx, logger)
+	ctx = context.WithValue(ctx, accessLogKey{}, log)
+
+	recorder := newResponseWriter(writer)
+	middleware.Handler.ServeHTTP(recorder, request.WithContext(ctx))
+
+	logger.WithFields(logrus.Fields{
+		"method":     request.Method,
+		"path":       request.URL.Path,
+		"status":     recorder.Status(),
+		"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+		"actor":      log.Actor,
+		"size":       recorder.Size,
+		"remote":     request.RemoteAddr,
+	}).Info("request completed")
+}
+
+func setAccessLogActor(ctx context.Context, actor string) {
+	if log, ok := ctx.Value(accessLogKey{}).(*accessLog); ok {
+		log.Actor = actor
+	}
+}
+
+func newRequestId() string {
+	bytes := make([]byte, 16)
+	_, err := rand.Read(bytes)
+	helper.PanicIfError(err)
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/prometheus/client_golang/prometheus"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type MetricsMiddleware struct {
+	Handler         http.Handler
+	RequestTotal    *prometheus.CounterVec
+	RequestDuration *prometheus.HistogramVec
+}
+
+func NewMetricsMiddleware(handler http.Handler, registerer prometheus.Registerer) *MetricsMiddleware {
+	middleware := &MetricsMiddleware{
+		Handler: handler,
+		RequestTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sisko_http_requests_total",
+			Help: "Number of HTTP requests by route, method and status code.",
+		}, []string{"route", "method", "code"}),
+		RequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "sisko_http_request_duration_seconds",
+			Help:    "Latency of HTTP requests by route and method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+	}
+
+	registerer.MustRegister(middleware.RequestTotal, middleware.RequestDuration)
+	return middleware
+}
+
+func (middleware *MetricsMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	start := time.Now()
+
+	ctx, route := helper.EnsureRoute(request.Context())
+	recorder := newResponseWriter(writer)
+	middleware.Handler.ServeHTTP(recorder, request.WithContext(ctx))
+
+	// raw paths would create a time series per siswa id
+	pattern := route.Pattern
+	if pattern == "" {
+		pattern = "unmatched"
+	}
+
+	middleware.RequestTotal.WithLabelValues(pattern, request.Method, strconv.Itoa(recorder.Status())).Inc()
+	middleware.RequestDuration.WithLabelValues(pattern, request.Method).Observe(time.Since(start).Seconds())
+}
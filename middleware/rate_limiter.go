@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/julienschmidt/httprouter"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit allows a burst of Requests which refills evenly over Per.
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// RateLimiter is an in-memory token bucket per client, see RateLimitKey.
+type RateLimiter struct {
+	Limit     RateLimit
+	mutex     sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	filled time.Time
+}
+
+type rateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	return &RateLimiter{Limit: limit, buckets: map[string]*tokenBucket{}, lastSweep: time.Now()}
+}
+
+// Handle rejects the request with 429 once the client has used up its
+// bucket, and reports the bucket in the RateLimit-* headers either way.
+func (limiter *RateLimiter) Handle(handle httprouter.Handle) httprouter.Handle {
+	return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		result := limiter.take(RateLimitKey(request), time.Now())
+
+		header := writer.Header()
+		header.Set("RateLimit-Limit", strconv.Itoa(limiter.Limit.Requests))
+		header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		header.Set("RateLimit-Reset", seconds(result.Reset))
+		header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%s", limiter.Limit.Requests, seconds(limiter.Limit.Per)))
+
+		if !result.Allowed {
+			helper.Logger(request.Context()).WithField("route", helper.RouteFromContext(request.Context()).Pattern).Warn("rate limit exceeded")
+			header.Set("Retry-After", seconds(result.RetryAfter))
+			exception.WriteError(writer, request, exception.NewTooManyRequestsError("rate limit exceeded, retry in "+seconds(result.RetryAfter)+" seconds"))
+			return
+		}
+
+		handle(writer, request, params)
+	}
+}
+
+func (limiter *RateLimiter) take(key string, now time.Time) rateLimitResult {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	capacity := float64(limiter.Limit.Requests)
+	rate := capacity / limiter.Limit.Per.Seconds()
+
+	// forget clients whose bucket has refilled completely, they are
+	// indistinguishable from clients never seen before
+	if now.Sub(limiter.lastSweep) >= limiter.Limit.Per {
+		for bucketKey, bucket := range limiter.buckets {
+			if bucket.tokens+now.Sub(bucket.filled).Seconds()*rate >= capacity {
+				delete(limiter.buckets, bucketKey)
+			}
+		}
+		limiter.lastSweep = now
+	}
+
+	bucket, ok := limiter.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, filled: now}
+		limiter.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(capacity, bucket.tokens+now.Sub(bucket.filled).Seconds()*rate)
+	bucket.filled = now
+
+	result := rateLimitResult{Allowed: bucket.tokens >= 1}
+	if result.Allowed {
+		bucket.tokens--
+	} else {
+		result.RetryAfter = secondsDuration((1 - bucket.tokens) / rate)
+	}
+	result.Remaining = int(bucket.tokens)
+	result.Reset = secondsDuration((capacity - bucket.tokens) / rate)
+	return result
+}
+
+// RateLimitKey identifies the client by API key, then by authenticated user
+// and finally by IP address. The API key is hashed so it is not kept in
+// memory in the clear.
+func RateLimitKey(request *http.Request) string {
+	if apiKey := request.Header.Get("X-API-Key"); apiKey != "" {
+		hash := sha256.Sum256([]byte(apiKey))
+		return "key:" + hex.EncodeToString(hash[:])
+	}
+	if actor := helper.ActorFromContext(request.Context()); actor != "system" {
+		return "user:" + actor
+	}
+	return "ip:" + ClientIP(request)
+}
+
+// ClientIP is the address of the peer. X-Forwarded-For is ignored because
+// any client can set it; terminate proxies in front of this server instead.
+func ClientIP(request *http.Request) string {
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		return request.RemoteAddr
+	}
+	return host
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// seconds rounds up, so a client waiting that long is always allowed again.
+func seconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// responseWriter remembers the status code and size of a response, and can
+// keep a copy of the body for middlewares that need to store it.
+type responseWriter struct {
+	http.ResponseWriter
+	StatusCode int
+	Size       int
+	Body       *bytes.Buffer
+}
+
+func newResponseWriter(writer http.ResponseWriter) *responseWriter {
+	return &responseWriter{ResponseWriter: writer}
+}
+
+func (writer *responseWriter) WriteHeader(statusCode int) {
+	if writer.StatusCode == 0 {
+		writer.StatusCode = statusCode
+	}
+	writer.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (writer *responseWriter) Write(bytes []byte) (int, error) {
+	if writer.StatusCode == 0 {
+		writer.StatusCode = http.StatusOK
+	}
+	if writer.Body != nil {
+		writer.Body.Write(bytes)
+	}
+	n, err := writer.ResponseWriter.Write(bytes)
+	writer.Size += n
+	return n, err
+}
+
+// Status returns the status sent to the client, which is 200 when the
+// handler never called WriteHeader or Write.
+func (writer *responseWriter) Status() int {
+	if writer.StatusCode == 0 {
+		return http.StatusOK
+	}
+	return writer.StatusCode
+}
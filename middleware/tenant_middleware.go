@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/service"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TenantMiddleware scopes a request to one school, named by the X-Sekolah
+// header or else by the subdomain of Domain, such as smpn1 in
+// smpn1.sisko.example.id. Users of one school are always scoped to theirs,
+// see service.SekolahService.Resolve. It runs after AuthMiddleware, which
+// puts the actor in the context.
+type TenantMiddleware struct {
+	Handler        http.Handler
+	SekolahService service.SekolahService
+	Domain         string
+}
+
+func NewTenantMiddleware(handler http.Handler, sekolahService service.SekolahService, domain string) *TenantMiddleware {
+	return &TenantMiddleware{Handler: handler, SekolahService: sekolahService, Domain: domain}
+}
+
+func (middleware *TenantMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	ctx, ok := middleware.resolve(writer, request)
+	if !ok {
+		return
+	}
+	middleware.Handler.ServeHTTP(writer, request.WithContext(ctx))
+}
+
+// resolve answers the request itself when the school cannot be resolved,
+// which happens outside the router and its PanicHandler.
+func (middleware *TenantMiddleware) resolve(writer http.ResponseWriter, request *http.Request) (ctx context.Context, ok bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			exception.ErrorHandler(writer, request, recovered)
+			ok = false
+		}
+	}()
+
+	kode := request.Header.Get("X-Sekolah")
+	if kode == "" {
+		kode = Subdomain(request.Host, middleware.Domain)
+	}
+
+	actor := helper.ActorFromContext(request.Context())
+	sekolah, restricted := middleware.SekolahService.Resolve(request.Context(), actor, strings.ToLower(kode))
+	ctx = helper.WithSekolah(request.Context(), sekolah, restricted)
+	ctx = helper.WithLogger(ctx, helper.Logger(ctx).WithField("sekolah", sekolah.Kode))
+	return ctx, true
+}
+
+// Subdomain returns the label of host in front of domain, or "" when host
+// is not directly under domain or domain is empty.
+func Subdomain(host string, domain string) string {
+	if domain == "" {
+		return ""
+	}
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	host = strings.ToLower(host)
+	label := strings.TrimSuffix(host, "."+strings.ToLower(domain))
+	if label == host || label == "" || strings.Contains(label, ".") {
+		return ""
+	}
+	return label
+}
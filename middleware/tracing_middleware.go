@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"github.com/Arraf18/go-sisko/helper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+type TracingMiddleware struct {
+	Handler http.Handler
+}
+
+func NewTracingMiddleware(handler http.Handler) *TracingMiddleware {
+	return &TracingMiddleware{Handler: handler}
+}
+
+func (middleware *TracingMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	ctx := otel.GetTextMapPropagator().Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+	ctx, span := otel.Tracer(helper.TracerName).Start(ctx, request.Method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPMethod(request.Method),
+			semconv.HTTPTarget(request.URL.Path),
+		))
+	defer span.End()
+
+	if span.SpanContext().HasTraceID() {
+		ctx = helper.WithLogger(ctx, helper.Logger(ctx).WithField("trace_id", span.SpanContext().TraceID().String()))
+	}
+
+	ctx, route := helper.EnsureRoute(ctx)
+	recorder := newResponseWriter(writer)
+	middleware.Handler.ServeHTTP(recorder, request.WithContext(ctx))
+
+	if route.Pattern != "" {
+		span.SetName(request.Method + " " + route.Pattern)
+		span.SetAttributes(semconv.HTTPRoute(route.Pattern))
+	}
+	span.SetAttributes(semconv.HTTPStatusCode(recorder.Status()))
+	if recorder.Status() >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(recorder.Status()))
+	}
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+)
+
+// YayasanOnly refuses a route to users of one school, for what spans every
+// school of the yayasan such as webhooks and background jobs.
+func YayasanOnly(handle httprouter.Handle) httprouter.Handle {
+	return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		if helper.RestrictedToSekolah(request.Context()) {
+			panic(exception.NewForbiddenError("only staff of the yayasan may use " + request.URL.Path))
+		}
+		handle(writer, request, params)
+	}
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+type Audit struct {
+	Id        int
+	SekolahId int
+	Entity    string
+	EntityId  int
+	Action    string
+	Actor     string
+	RequestId string
+	Changes   []AuditChange
+	CreatedAt time.Time
+}
+
+type AuditChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+type AuditFilter struct {
+	SekolahId int
+	Actor     string
+	Entity    string
+	EntityId  int
+	EntityIds []int
+	From      *time.Time
+	To        *time.Time
+	// Latest keeps only the latest audits of each entity when not 0.
+	Latest int
+}
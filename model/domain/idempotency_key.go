@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+type IdempotencyKey struct {
+	Actor        string
+	Key          string
+	Fingerprint  string
+	StatusCode   int
+	ContentType  string
+	Headers      map[string]string
+	ResponseBody []byte
+	CreatedAt    time.Time
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// Job is one run of a registered job. UniqueKey, when set, keeps a job from
+// being queued twice, e.g. by two instances firing the same schedule.
+type Job struct {
+	Id          int64
+	Name        string
+	Payload     []byte
+	UniqueKey   string
+	Status      string
+	Attempts    int
+	MaxAttempts int
+	RunAt       time.Time
+	LockedBy    string
+	LockedAt    *time.Time
+	LastError   string
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+}
+
+type JobFilter struct {
+	Name   string
+	Status string
+	Limit  int
+}
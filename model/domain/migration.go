@@ -0,0 +1,6 @@
+package domain
+
+type Migration struct {
+	Version int
+	Dirty   bool
+}
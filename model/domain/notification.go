@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+const (
+	NotificationPending = "pending"
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+	NotificationSkipped = "skipped"
+)
+
+// NotificationPreference says how the parent of a siswa wants to be
+// reached: Channels in the order they are tried, and the language.
+type NotificationPreference struct {
+	SiswaId    int
+	NamaWali   string
+	Email      string
+	NoTelepon  string
+	NoWhatsapp string
+	Channels   []string
+	Language   string
+	UpdatedAt  time.Time
+}
+
+// NotificationRoute is one way of reaching a recipient.
+type NotificationRoute struct {
+	Channel string `json:"channel"`
+	To      string `json:"to"`
+}
+
+// Notification is a rendered message to the parent of a siswa and, once
+// tried, the route it went out on or the last error. A notification without
+// any route is skipped.
+type Notification struct {
+	Id        int64
+	SiswaId   int
+	Template  string
+	Language  string
+	Subject   string
+	Body      string
+	Routes    []NotificationRoute
+	Status    string
+	Channel   string
+	Recipient string
+	Attempts  int
+	LastError string
+	CreatedBy string
+	CreatedAt time.Time
+	SentAt    *time.Time
+}
+
+type NotificationFilter struct {
+	SekolahId int
+	SiswaId   int
+	Status    string
+	Limit     int
+}
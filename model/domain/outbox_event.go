@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+const (
+	EventSiswaCreated  = "SiswaCreated"
+	EventSiswaUpdated  = "SiswaUpdated"
+	EventSiswaDeleted  = "SiswaDeleted"
+	EventSiswaRestored = "SiswaRestored"
+	EventSiswaPurged   = "SiswaPurged"
+)
+
+type OutboxEvent struct {
+	Id            int64
+	AggregateType string
+	AggregateId   int
+	EventType     string
+	Payload       []byte
+	Actor         string
+	RequestId     string
+	CreatedAt     time.Time
+	PublishedAt   *time.Time
+	Attempts      int
+	LastError     string
+	NextAttemptAt *time.Time
+}
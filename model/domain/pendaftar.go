@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// Jalur are the tracks a pendaftar is admitted through: by the distance of
+// home to the school, by score, or for children of poor families.
+const (
+	JalurZonasi   = "zonasi"
+	JalurPrestasi = "prestasi"
+	JalurAfirmasi = "afirmasi"
+)
+
+const (
+	PendaftarRegistered   = "registered"
+	PendaftarVerified     = "verified"
+	PendaftarTested       = "tested"
+	PendaftarAccepted     = "accepted"
+	PendaftarRejected     = "rejected"
+	PendaftarReRegistered = "re-registered"
+)
+
+// Pendaftar is an applicant of the PPDB, the yearly admission of new siswa,
+// of one school. Jarak is the distance from home to the school in meters and
+// Nilai the score of the admission test. Peringkat is the rank within the
+// jalur once selected; a re-registered pendaftar became the siswa SiswaId.
+type Pendaftar struct {
+	Id            int
+	SekolahId     int
+	TokenHash     string
+	Jalur         string
+	Status        string
+	Nama          string
+	Alamat        string
+	TanggalLahir  string
+	TempatLahir   string
+	JenisKelamin  string
+	Agama         string
+	GolonganDarah string
+	NoTelepon     string
+	AsalSekolah   string
+	Jarak         int
+	Nilai         *float64
+	Peringkat     int
+	Catatan       string
+	SiswaId       int
+	Dokumen       []Dokumen
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	AnnouncedAt   *time.Time
+}
+
+// Dokumen is a file a pendaftar uploaded, such as the birth certificate.
+// Isi is only loaded when the file itself is asked for.
+type Dokumen struct {
+	PendaftarId int
+	Jenis       string
+	ContentType string
+	Ukuran      int
+	Isi         []byte
+	UploadedAt  time.Time
+}
+
+type PendaftarFilter struct {
+	Jalur  string
+	Status string
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Sekolah is a school of the yayasan and the tenant every siswa belongs to.
+// Bahasa is the language parents are notified in unless they chose one, and
+// KuotaSiswa caps the number of active siswa, 0 for no cap. The PPDB kuota
+// are the seats admission offers per jalur.
+type Sekolah struct {
+	Id            int
+	Kode          string
+	Nama          string
+	Bahasa        string
+	KuotaSiswa    int
+	KuotaZonasi   int
+	KuotaPrestasi int
+	KuotaAfirmasi int
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
+}
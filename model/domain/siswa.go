@@ -1,7 +1,10 @@
 package domain
 
+import "time"
+
 type Siswa struct {
 	Id            int
+	SekolahId     int
 	Nama          string
 	Alamat        string
 	TanggalLahir  string
@@ -10,4 +13,22 @@ type Siswa struct {
 	Agama         string
 	GolonganDarah string
 	NoTelepon     string
+	Version       int
+	DeletedAt     *time.Time
+	DeletedBy     string
+}
+
+// SiswaFilter leaves out the fields that are empty. A page holds up to Limit
+// siswa with an id above AfterId, ordered by id.
+type SiswaFilter struct {
+	Nama             string
+	JenisKelamin     string
+	Agama            string
+	GolonganDarah    string
+	TempatLahir      string
+	TanggalLahirFrom string
+	TanggalLahirTo   string
+	Trash            bool
+	AfterId          int
+	Limit            int
 }
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// User works on one school when SekolahId is set, Sekolah being its kode,
+// or on every school of the yayasan otherwise.
+type User struct {
+	Id        int
+	Username  string
+	Nama      string
+	SekolahId int
+	Sekolah   string
+	CreatedAt time.Time
+}
+
+// ApiKey only keeps the SHA-256 of the key; Prefix, its first characters,
+// lets an admin tell keys apart.
+type ApiKey struct {
+	Id        int
+	UserId    int
+	Username  string
+	Name      string
+	Prefix    string
+	KeyHash   string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookSubscription receives the events listed in EventTypes. Secret
+// signs every delivery; it is kept as is because signing needs it.
+type WebhookSubscription struct {
+	Id                  int
+	Url                 string
+	EventTypes          []string
+	Secret              string
+	Active              bool
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// WebhookDelivery is one event for one subscription, with the outcome of
+// its last attempt.
+type WebhookDelivery struct {
+	Id             int64
+	SubscriptionId int
+	EventId        int64
+	EventType      string
+	AggregateId    int
+	Payload        []byte
+	Status         string
+	Attempts       int
+	ResponseStatus int
+	LastError      string
+	NextAttemptAt  *time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
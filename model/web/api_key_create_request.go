@@ -0,0 +1,6 @@
+package web
+
+type ApiKeyCreateRequest struct {
+	Username string `validate:"required,max=100" json:"username"`
+	Name     string `validate:"required,min=1,max=100" json:"name"`
+}
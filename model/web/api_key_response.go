@@ -0,0 +1,18 @@
+package web
+
+import "time"
+
+type ApiKeyResponse struct {
+	Id        int        `json:"id"`
+	Username  string     `json:"username"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// ApiKeyCreateResponse is the only time Key is shown, it is not stored.
+type ApiKeyCreateResponse struct {
+	ApiKeyResponse
+	Key string `json:"key"`
+}
@@ -0,0 +1,20 @@
+package web
+
+import "time"
+
+type AuditResponse struct {
+	Id        int                   `json:"id"`
+	Entity    string                `json:"entity"`
+	EntityId  int                   `json:"entity_id"`
+	Action    string                `json:"action"`
+	Actor     string                `json:"actor"`
+	RequestId string                `json:"request_id"`
+	Changes   []AuditChangeResponse `json:"changes"`
+	CreatedAt time.Time             `json:"created_at"`
+}
+
+type AuditChangeResponse struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
@@ -0,0 +1,9 @@
+package web
+
+type AuditSearchRequest struct {
+	Actor    string
+	Entity   string
+	EntityId int
+	From     string
+	To       string
+}
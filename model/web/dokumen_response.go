@@ -0,0 +1,13 @@
+package web
+
+import "time"
+
+// DokumenResponse describes an uploaded document; Isi, the file itself, is
+// only filled when it is downloaded.
+type DokumenResponse struct {
+	Jenis       string    `json:"jenis"`
+	ContentType string    `json:"content_type"`
+	Ukuran      int       `json:"ukuran"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+	Isi         []byte    `json:"-"`
+}
@@ -0,0 +1,9 @@
+package web
+
+type DokumenUploadRequest struct {
+	PendaftarId int    `validate:"required"`
+	Token       string `validate:"required"`
+	Jenis       string `validate:"required,oneof=akta_kelahiran kartu_keluarga rapor sertifikat_prestasi kip"`
+	ContentType string `validate:"required,oneof=application/pdf image/jpeg image/png"`
+	Isi         []byte `validate:"required"`
+}
@@ -0,0 +1,8 @@
+package web
+
+type HealthResponse struct {
+	Ready     bool              `json:"ready"`
+	Checks    map[string]string `json:"checks"`
+	Migration int               `json:"migration"`
+	Expected  int               `json:"expected_migration"`
+}
@@ -0,0 +1,10 @@
+package web
+
+// IdempotentResponse is a response kept to be replayed. Headers holds those
+// of its headers a client may rely on, such as Location and ETag.
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Headers     map[string]string
+	Body        []byte
+}
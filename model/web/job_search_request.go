@@ -0,0 +1,6 @@
+package web
+
+type JobSearchRequest struct {
+	Name   string
+	Status string `validate:"omitempty,oneof=queued running succeeded failed"`
+}
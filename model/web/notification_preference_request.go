@@ -0,0 +1,13 @@
+package web
+
+// NotificationPreferenceRequest replaces how the parent of a siswa is
+// notified. Channels are tried in order; an empty list stops notifications.
+type NotificationPreferenceRequest struct {
+	SiswaId    int      `validate:"required"`
+	NamaWali   string   `validate:"required,min=1,max=100" json:"nama_wali"`
+	Email      string   `validate:"omitempty,email,max=200" json:"email"`
+	NoTelepon  string   `validate:"omitempty,max=20" json:"no_telepon"`
+	NoWhatsapp string   `validate:"omitempty,max=20" json:"no_whatsapp"`
+	Channels   []string `validate:"required,max=3,dive,oneof=email sms whatsapp" json:"channels"`
+	Language   string   `validate:"required,oneof=id en" json:"language"`
+}
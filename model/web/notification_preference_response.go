@@ -0,0 +1,14 @@
+package web
+
+import "time"
+
+type NotificationPreferenceResponse struct {
+	SiswaId    int        `json:"siswa_id"`
+	NamaWali   string     `json:"nama_wali"`
+	Email      string     `json:"email,omitempty"`
+	NoTelepon  string     `json:"no_telepon,omitempty"`
+	NoWhatsapp string     `json:"no_whatsapp,omitempty"`
+	Channels   []string   `json:"channels"`
+	Language   string     `json:"language"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,20 @@
+package web
+
+import "time"
+
+type NotificationResponse struct {
+	Id        int64      `json:"id"`
+	SiswaId   int        `json:"siswa_id"`
+	Template  string     `json:"template"`
+	Language  string     `json:"language"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	Status    string     `json:"status"`
+	Channel   string     `json:"channel,omitempty"`
+	Recipient string     `json:"recipient,omitempty"`
+	Attempts  int        `json:"attempts"`
+	LastError string     `json:"last_error,omitempty"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+}
@@ -0,0 +1,6 @@
+package web
+
+type NotificationSearchRequest struct {
+	SiswaId int
+	Status  string `validate:"omitempty,oneof=pending sent failed skipped"`
+}
@@ -0,0 +1,9 @@
+package web
+
+// NotificationSendRequest notifies the parents of every siswa in SiswaIds
+// with Template, filled with Data.
+type NotificationSendRequest struct {
+	SiswaIds []int             `validate:"required,min=1,max=500" json:"siswa_ids"`
+	Template string            `validate:"required,oneof=absence unpaid_fee announcement" json:"template"`
+	Data     map[string]string `json:"data"`
+}
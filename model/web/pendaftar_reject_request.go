@@ -0,0 +1,8 @@
+package web
+
+// PendaftarRejectRequest turns a pendaftar down before selection, telling
+// why in Catatan.
+type PendaftarRejectRequest struct {
+	Id      int    `validate:"required"`
+	Catatan string `validate:"required,min=1,max=500" json:"catatan"`
+}
@@ -0,0 +1,42 @@
+package web
+
+import "time"
+
+type PendaftarResponse struct {
+	Id            int               `json:"id"`
+	Jalur         string            `json:"jalur"`
+	Status        string            `json:"status"`
+	Nama          string            `json:"nama"`
+	Alamat        string            `json:"alamat"`
+	TanggalLahir  string            `json:"tanggal_lahir"`
+	TempatLahir   string            `json:"tempat_lahir"`
+	JenisKelamin  string            `json:"jenis_kelamin"`
+	Agama         string            `json:"agama"`
+	GolonganDarah string            `json:"golongan_darah"`
+	NoTelepon     string            `json:"no_telepon"`
+	AsalSekolah   string            `json:"asal_sekolah"`
+	Jarak         int               `json:"jarak"`
+	Nilai         *float64          `json:"nilai,omitempty"`
+	Peringkat     int               `json:"peringkat,omitempty"`
+	Catatan       string            `json:"catatan,omitempty"`
+	SiswaId       int               `json:"siswa_id,omitempty"`
+	Dokumen       []DokumenResponse `json:"dokumen"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	AnnouncedAt   *time.Time        `json:"announced_at,omitempty"`
+}
+
+// PendaftarRegisterResponse is the only time Token is shown. The pendaftar
+// needs it to upload documents and to see the result.
+type PendaftarRegisterResponse struct {
+	PendaftarResponse
+	Token string `json:"token"`
+}
+
+// PengumumanResponse is one admitted pendaftar on the public announcement.
+type PengumumanResponse struct {
+	Id        int    `json:"id"`
+	Nama      string `json:"nama"`
+	Jalur     string `json:"jalur"`
+	Peringkat int    `json:"peringkat"`
+}
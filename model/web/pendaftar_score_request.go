@@ -0,0 +1,7 @@
+package web
+
+// PendaftarScoreRequest records the score of the admission test, 0 to 100.
+type PendaftarScoreRequest struct {
+	Id    int      `validate:"required"`
+	Nilai *float64 `validate:"required,min=0,max=100" json:"nilai"`
+}
@@ -0,0 +1,6 @@
+package web
+
+type PendaftarSearchRequest struct {
+	Jalur  string `validate:"omitempty,oneof=zonasi prestasi afirmasi"`
+	Status string `validate:"omitempty,oneof=registered verified tested accepted rejected re-registered"`
+}
@@ -0,0 +1,11 @@
+package web
+
+// ProblemResponse is the RFC 7807 error body returned by /api/v2.
+type ProblemResponse struct {
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Status   int         `json:"status"`
+	Detail   string      `json:"detail,omitempty"`
+	Instance string      `json:"instance"`
+	Errors   interface{} `json:"errors,omitempty"`
+}
@@ -0,0 +1,13 @@
+package web
+
+// SekolahCreateRequest adds a school. Kode is how requests name it, in the
+// X-Sekolah header or as the subdomain, and cannot change afterwards.
+type SekolahCreateRequest struct {
+	Kode          string `validate:"required,min=2,max=50,alphanum,lowercase" json:"kode"`
+	Nama          string `validate:"required,min=1,max=200" json:"nama"`
+	Bahasa        string `validate:"required,oneof=id en" json:"bahasa"`
+	KuotaSiswa    int    `validate:"min=0" json:"kuota_siswa"`
+	KuotaZonasi   int    `validate:"min=0" json:"kuota_zonasi"`
+	KuotaPrestasi int    `validate:"min=0" json:"kuota_prestasi"`
+	KuotaAfirmasi int    `validate:"min=0" json:"kuota_afirmasi"`
+}
@@ -0,0 +1,16 @@
+package web
+
+import "time"
+
+type SekolahResponse struct {
+	Id            int        `json:"id"`
+	Kode          string     `json:"kode"`
+	Nama          string     `json:"nama"`
+	Bahasa        string     `json:"bahasa"`
+	KuotaSiswa    int        `json:"kuota_siswa"`
+	KuotaZonasi   int        `json:"kuota_zonasi"`
+	KuotaPrestasi int        `json:"kuota_prestasi"`
+	KuotaAfirmasi int        `json:"kuota_afirmasi"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
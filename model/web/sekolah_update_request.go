@@ -0,0 +1,11 @@
+package web
+
+type SekolahUpdateRequest struct {
+	Id            int    `validate:"required"`
+	Nama          string `validate:"required,min=1,max=200" json:"nama"`
+	Bahasa        string `validate:"required,oneof=id en" json:"bahasa"`
+	KuotaSiswa    int    `validate:"min=0" json:"kuota_siswa"`
+	KuotaZonasi   int    `validate:"min=0" json:"kuota_zonasi"`
+	KuotaPrestasi int    `validate:"min=0" json:"kuota_prestasi"`
+	KuotaAfirmasi int    `validate:"min=0" json:"kuota_afirmasi"`
+}
@@ -0,0 +1,13 @@
+package web
+
+type SiswaBatchRequest struct {
+	Mode       string                `validate:"omitempty,oneof=atomic best_effort" json:"mode"`
+	Operations []SiswaBatchOperation `validate:"required,min=1,max=500,dive" json:"operations"`
+}
+
+type SiswaBatchOperation struct {
+	Action  string              `validate:"required,oneof=create update delete" json:"action"`
+	Id      int                 `json:"id"`
+	Version int                 `validate:"min=0" json:"version"`
+	Data    *SiswaCreateRequest `json:"data"`
+}
@@ -0,0 +1,16 @@
+package web
+
+type SiswaBatchResponse struct {
+	Mode      string             `json:"mode"`
+	Committed bool               `json:"committed"`
+	Results   []SiswaBatchResult `json:"results"`
+}
+
+type SiswaBatchResult struct {
+	Index  int            `json:"index"`
+	Action string         `json:"action"`
+	Code   int            `json:"code"`
+	Status string         `json:"status"`
+	Data   *SiswaResponse `json:"data,omitempty"`
+	Error  interface{}    `json:"error,omitempty"`
+}
@@ -0,0 +1,10 @@
+package web
+
+// SiswaEventPayload is the data of a siswa event: the siswa after the change
+// and the fields the change touched, as in the audit trail. Sekolah is the
+// kode of the school of the siswa.
+type SiswaEventPayload struct {
+	Sekolah string                `json:"sekolah"`
+	Siswa   SiswaResponseV2       `json:"siswa"`
+	Changes []AuditChangeResponse `json:"changes"`
+}
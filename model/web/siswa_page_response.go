@@ -0,0 +1,7 @@
+package web
+
+type SiswaPageResponse struct {
+	TotalCount  int             `json:"total_count"`
+	Siswas      []SiswaResponse `json:"siswas"`
+	HasNextPage bool            `json:"has_next_page"`
+}
@@ -0,0 +1,14 @@
+package web
+
+// SiswaPatchRequest changes only the fields that are present; the others
+// keep their current value.
+type SiswaPatchRequest struct {
+	Nama          *string `validate:"omitempty,max=200,min=1" json:"nama"`
+	Alamat        *string `validate:"omitempty,max=100,min=1" json:"alamat"`
+	TanggalLahir  *string `validate:"omitempty,max=36,min=10" json:"tanggal_lahir"`
+	TempatLahir   *string `validate:"omitempty,max=100,min=1" json:"tempat_lahir"`
+	JenisKelamin  *string `validate:"omitempty,max=10,min=1" json:"jenis_kelamin"`
+	Agama         *string `validate:"omitempty,max=20,min=1" json:"agama"`
+	GolonganDarah *string `validate:"omitempty,max=2,min=1" json:"golongan_darah"`
+	NoTelepon     *string `validate:"omitempty,max=20,min=1" json:"no_telepon"`
+}
@@ -1,13 +1,18 @@
 package web
 
+import "time"
+
 type SiswaResponse struct {
-	Id            int    `json:"id"`
-	Nama          string `json:"nama"`
-	Alamat        string `json:"alamat"`
-	TanggalLahir  string `json:"tanggal_lahir"`
-	TempatLahir   string `json:"tempat_lahir"`
-	JenisKelamin  string `json:"jenis_kelamin"`
-	Agama         string `json:"Agama"`
-	GolonganDarah string `json:"golongan_darah"`
-	NoTelepon     string `json:"no_telepon"`
+	Id            int        `json:"id"`
+	Nama          string     `json:"nama"`
+	Alamat        string     `json:"alamat"`
+	TanggalLahir  string     `json:"tanggal_lahir"`
+	TempatLahir   string     `json:"tempat_lahir"`
+	JenisKelamin  string     `json:"jenis_kelamin"`
+	Agama         string     `json:"Agama"`
+	GolonganDarah string     `json:"golongan_darah"`
+	NoTelepon     string     `json:"no_telepon"`
+	Version       int        `json:"-"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy     string     `json:"deleted_by,omitempty"`
 }
@@ -0,0 +1,34 @@
+package web
+
+import "time"
+
+// SiswaResponseV2 is SiswaResponse with consistent snake_case field names.
+type SiswaResponseV2 struct {
+	Id            int        `json:"id"`
+	Nama          string     `json:"nama"`
+	Alamat        string     `json:"alamat"`
+	TanggalLahir  string     `json:"tanggal_lahir"`
+	TempatLahir   string     `json:"tempat_lahir"`
+	JenisKelamin  string     `json:"jenis_kelamin"`
+	Agama         string     `json:"agama"`
+	GolonganDarah string     `json:"golongan_darah"`
+	NoTelepon     string     `json:"no_telepon"`
+	Version       int        `json:"-"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy     string     `json:"deleted_by,omitempty"`
+}
+
+type SiswaBatchResponseV2 struct {
+	Mode      string               `json:"mode"`
+	Committed bool                 `json:"committed"`
+	Results   []SiswaBatchResultV2 `json:"results"`
+}
+
+type SiswaBatchResultV2 struct {
+	Index  int              `json:"index"`
+	Action string           `json:"action"`
+	Code   int              `json:"code"`
+	Status string           `json:"status"`
+	Data   *SiswaResponseV2 `json:"data,omitempty"`
+	Error  interface{}      `json:"error,omitempty"`
+}
@@ -0,0 +1,14 @@
+package web
+
+type SiswaSearchRequest struct {
+	Nama             string
+	JenisKelamin     string
+	Agama            string
+	GolonganDarah    string
+	TempatLahir      string
+	TanggalLahirFrom string
+	TanggalLahirTo   string
+	Trash            bool
+	After            int
+	First            int
+}
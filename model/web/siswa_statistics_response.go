@@ -0,0 +1,6 @@
+package web
+
+type SiswaStatisticsResponse struct {
+	ActiveByJenisKelamin map[string]int `json:"active_by_jenis_kelamin"`
+	Trash                int            `json:"trash"`
+}
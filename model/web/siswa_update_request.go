@@ -10,4 +10,5 @@ type SiswaUpdateRequest struct {
 	Agama         string `validate:"required,max=20,min=1" json:"agama"`
 	GolonganDarah string `validate:"required,max=2,min=1" json:"golongan_darah"`
 	NoTelepon     string `validate:"required,max=20,min=1" json:"no_telepon"`
+	Version       int    `json:"-"`
 }
@@ -0,0 +1,9 @@
+package web
+
+type UserCreateRequest struct {
+	Username string `validate:"required,min=3,max=100,alphanum" json:"username"`
+	Nama     string `validate:"required,min=1,max=100" json:"nama"`
+	// Sekolah is the kode of the only school the user may work on; empty
+	// for staff of the yayasan
+	Sekolah string `validate:"max=50" json:"sekolah"`
+}
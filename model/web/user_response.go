@@ -0,0 +1,11 @@
+package web
+
+import "time"
+
+type UserResponse struct {
+	Id        int       `json:"id"`
+	Username  string    `json:"username"`
+	Nama      string    `json:"nama"`
+	Sekolah   string    `json:"sekolah,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
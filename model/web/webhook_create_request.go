@@ -0,0 +1,7 @@
+package web
+
+type WebhookCreateRequest struct {
+	Url        string   `validate:"required,url,max=500" json:"url"`
+	EventTypes []string `validate:"required,min=1,dive,oneof=SiswaCreated SiswaUpdated SiswaDeleted SiswaRestored SiswaPurged" json:"event_types"`
+	Secret     string   `validate:"omitempty,min=16,max=100" json:"secret"`
+}
@@ -0,0 +1,18 @@
+package web
+
+import "time"
+
+type WebhookDeliveryResponse struct {
+	Id             int64      `json:"id"`
+	WebhookId      int        `json:"webhook_id"`
+	EventId        int64      `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	SiswaId        int        `json:"siswa_id"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
@@ -0,0 +1,20 @@
+package web
+
+import "time"
+
+type WebhookResponse struct {
+	Id                  int        `json:"id"`
+	Url                 string     `json:"url"`
+	EventTypes          []string   `json:"event_types"`
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// WebhookCreateResponse is the only time Secret is shown.
+type WebhookCreateResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
@@ -0,0 +1,11 @@
+package web
+
+// WebhookUpdateRequest replaces a subscription. Secret is only changed when
+// given; setting Active again re-enables a disabled subscription.
+type WebhookUpdateRequest struct {
+	Id         int      `validate:"required"`
+	Url        string   `validate:"required,url,max=500" json:"url"`
+	EventTypes []string `validate:"required,min=1,dive,oneof=SiswaCreated SiswaUpdated SiswaDeleted SiswaRestored SiswaPurged" json:"event_types"`
+	Secret     string   `validate:"omitempty,min=16,max=100" json:"secret"`
+	Active     *bool    `validate:"required" json:"active"`
+}
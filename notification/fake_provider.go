@@ -0,0 +1,44 @@
+package notification
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+// FakeProvider keeps the messages it is asked to send instead of sending
+// them, and logs them, for tests and local development. Setting Err makes
+// every Send fail with it.
+type FakeProvider struct {
+	Name  string
+	Err   error
+	mutex sync.Mutex
+	sent  []Message
+}
+
+func NewFakeProvider(channel string) *FakeProvider {
+	return &FakeProvider{Name: channel}
+}
+
+func (provider *FakeProvider) Channel() string {
+	return provider.Name
+}
+
+func (provider *FakeProvider) Send(ctx context.Context, message Message) error {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	if provider.Err != nil {
+		return provider.Err
+	}
+	provider.sent = append(provider.sent, message)
+	logrus.WithFields(logrus.Fields{"channel": provider.Name, "to": message.To, "subject": message.Subject}).Info("fake notification sent")
+	return nil
+}
+
+// Sent returns the messages sent so far.
+func (provider *FakeProvider) Sent() []Message {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+	return append([]Message(nil), provider.sent...)
+}
@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// postJSON sends body to url and fails unless the answer is a 2xx.
+func postJSON(ctx context.Context, client *http.Client, url string, token string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("%s answered %s: %s", url, response.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// NormalizePhone turns an Indonesian number as people write it, such as
+// 0812-3456-789 or +62 812 3456 789, into the international 628123456789
+// the gateways expect.
+func NormalizePhone(phone string) string {
+	digits := strings.Builder{}
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+
+	number := digits.String()
+	if strings.HasPrefix(number, "0") {
+		return "62" + number[1:]
+	}
+	return number
+}
@@ -0,0 +1,27 @@
+// Package notification sends messages to the parents of siswa over email,
+// SMS and WhatsApp, rendered from templates in Indonesian or English.
+package notification
+
+import "context"
+
+const (
+	ChannelEmail    = "email"
+	ChannelSms      = "sms"
+	ChannelWhatsapp = "whatsapp"
+)
+
+// Message is what a provider sends: To is an email address or a phone
+// number, depending on the channel. Subject is only used by email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Provider sends messages over one channel. Send returns once the message
+// was handed over to the provider; an error has it tried on the next
+// channel of the recipient.
+type Provider interface {
+	Channel() string
+	Send(ctx context.Context, message Message) error
+}
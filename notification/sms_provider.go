@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const providerTimeout = 10 * time.Second
+
+// SmsProvider sends SMS through an HTTP gateway that takes
+// {"to", "from", "message"} as JSON with the API key as bearer token, which
+// most Indonesian gateways offer besides their own formats.
+type SmsProvider struct {
+	URL    string
+	ApiKey string
+	Sender string
+	Client *http.Client
+}
+
+func NewSmsProvider(url string, apiKey string, sender string) *SmsProvider {
+	return &SmsProvider{URL: url, ApiKey: apiKey, Sender: sender, Client: &http.Client{Timeout: providerTimeout}}
+}
+
+func (provider *SmsProvider) Channel() string {
+	return ChannelSms
+}
+
+type smsRequest struct {
+	To      string `json:"to"`
+	From    string `json:"from,omitempty"`
+	Message string `json:"message"`
+}
+
+func (provider *SmsProvider) Send(ctx context.Context, message Message) error {
+	return postJSON(ctx, provider.Client, provider.URL, provider.ApiKey, smsRequest{
+		To:      NormalizePhone(message.To),
+		From:    provider.Sender,
+		Message: message.Body,
+	})
+}
@@ -0,0 +1,50 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// SmtpProvider sends email through an SMTP server, with STARTTLS when the
+// server offers it and PLAIN authentication when Username is set.
+type SmtpProvider struct {
+	Addr     string
+	Username string
+	Password string
+	From     string
+}
+
+func NewSmtpProvider(addr string, username string, password string, from string) *SmtpProvider {
+	return &SmtpProvider{Addr: addr, Username: username, Password: password, From: from}
+}
+
+func (provider *SmtpProvider) Channel() string {
+	return ChannelEmail
+}
+
+func (provider *SmtpProvider) Send(ctx context.Context, message Message) error {
+	var auth smtp.Auth
+	if provider.Username != "" {
+		host, _, err := net.SplitHostPort(provider.Addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", provider.Username, provider.Password, host)
+	}
+
+	body := bytes.Buffer{}
+	body.WriteString("From: " + provider.From + "\r\n")
+	body.WriteString("To: " + message.To + "\r\n")
+	body.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", message.Subject) + "\r\n")
+	body.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	body.WriteString("\r\n")
+	body.WriteString(message.Body)
+
+	return smtp.SendMail(provider.Addr, auth, provider.From, []string{message.To}, body.Bytes())
+}
@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"errors"
+	"strings"
+	"text/template"
+)
+
+const (
+	LanguageIndonesian = "id"
+	LanguageEnglish    = "en"
+)
+
+const (
+	TemplateAbsence      = "absence"
+	TemplateUnpaidFee    = "unpaid_fee"
+	TemplateAnnouncement = "announcement"
+)
+
+// TemplateData is what a template is rendered with: the name of the siswa
+// and the fields given by the sender. The fields each template needs are:
+//
+//	absence:      tanggal
+//	unpaid_fee:   bulan, jumlah, jatuh_tempo
+//	announcement: judul, isi
+type TemplateData struct {
+	Nama string
+	Data map[string]string
+}
+
+type messageTemplate struct {
+	Subject *template.Template
+	Body    *template.Template
+}
+
+var templates = map[string]map[string]messageTemplate{
+	TemplateAbsence: {
+		LanguageIndonesian: newMessageTemplate("Ketidakhadiran {{.Nama}}",
+			"Yth. Bapak/Ibu wali {{.Nama}},\n\n{{.Nama}} tercatat tidak hadir di sekolah pada {{.Data.tanggal}}. Mohon hubungi wali kelas bila ada pertanyaan.\n\nTerima kasih."),
+		LanguageEnglish: newMessageTemplate("Absence of {{.Nama}}",
+			"Dear parent or guardian of {{.Nama}},\n\n{{.Nama}} was recorded absent from school on {{.Data.tanggal}}. Please contact the homeroom teacher if you have any questions.\n\nThank you."),
+	},
+	TemplateUnpaidFee: {
+		LanguageIndonesian: newMessageTemplate("Tagihan {{.Data.bulan}} untuk {{.Nama}} belum dibayar",
+			"Yth. Bapak/Ibu wali {{.Nama}},\n\nTagihan sekolah bulan {{.Data.bulan}} sebesar {{.Data.jumlah}} belum kami terima. Mohon lakukan pembayaran sebelum {{.Data.jatuh_tempo}}.\n\nAbaikan pesan ini bila sudah membayar. Terima kasih."),
+		LanguageEnglish: newMessageTemplate("Unpaid {{.Data.bulan}} fee for {{.Nama}}",
+			"Dear parent or guardian of {{.Nama}},\n\nWe have not yet received the school fee of {{.Data.jumlah}} for {{.Data.bulan}}. Please pay it before {{.Data.jatuh_tempo}}.\n\nPlease ignore this message if you have already paid. Thank you."),
+	},
+	TemplateAnnouncement: {
+		LanguageIndonesian: newMessageTemplate("{{.Data.judul}}",
+			"Yth. Bapak/Ibu wali {{.Nama}},\n\n{{.Data.isi}}\n\nTerima kasih."),
+		LanguageEnglish: newMessageTemplate("{{.Data.judul}}",
+			"Dear parent or guardian of {{.Nama}},\n\n{{.Data.isi}}\n\nThank you."),
+	},
+}
+
+func newMessageTemplate(subject string, body string) messageTemplate {
+	return messageTemplate{
+		Subject: template.Must(template.New("subject").Option("missingkey=error").Parse(subject)),
+		Body:    template.Must(template.New("body").Option("missingkey=error").Parse(body)),
+	}
+}
+
+// Render returns the subject and body of template name in language. It
+// fails for an unknown template or language, or a missing field.
+func Render(name string, language string, data TemplateData) (string, string, error) {
+	languages, ok := templates[name]
+	if !ok {
+		return "", "", errors.New("unknown template " + name)
+	}
+	messageTemplate, ok := languages[language]
+	if !ok {
+		return "", "", errors.New("template " + name + " has no language " + language)
+	}
+
+	subject, body := strings.Builder{}, strings.Builder{}
+	if err := messageTemplate.Subject.Execute(&subject, data); err != nil {
+		return "", "", err
+	}
+	if err := messageTemplate.Body.Execute(&body, data); err != nil {
+		return "", "", err
+	}
+	return subject.String(), body.String(), nil
+}
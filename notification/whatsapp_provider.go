@@ -0,0 +1,53 @@
+package notification
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+const defaultWhatsappURL = "https://graph.facebook.com/v17.0"
+
+// WhatsappProvider sends text messages through the WhatsApp Business Cloud
+// API from the number PhoneNumberId. WhatsApp only delivers free-form text
+// to parents who wrote to the school in the last 24 hours; others have to be
+// reached with an approved template, so keep SMS as the next channel.
+type WhatsappProvider struct {
+	URL           string
+	PhoneNumberId string
+	Token         string
+	Client        *http.Client
+}
+
+func NewWhatsappProvider(url string, phoneNumberId string, token string) *WhatsappProvider {
+	if url == "" {
+		url = defaultWhatsappURL
+	}
+	return &WhatsappProvider{URL: strings.TrimSuffix(url, "/"), PhoneNumberId: phoneNumberId, Token: token, Client: &http.Client{Timeout: providerTimeout}}
+}
+
+func (provider *WhatsappProvider) Channel() string {
+	return ChannelWhatsapp
+}
+
+type whatsappRequest struct {
+	MessagingProduct string       `json:"messaging_product"`
+	RecipientType    string       `json:"recipient_type"`
+	To               string       `json:"to"`
+	Type             string       `json:"type"`
+	Text             whatsappText `json:"text"`
+}
+
+type whatsappText struct {
+	Body string `json:"body"`
+}
+
+func (provider *WhatsappProvider) Send(ctx context.Context, message Message) error {
+	return postJSON(ctx, provider.Client, provider.URL+"/"+provider.PhoneNumberId+"/messages", provider.Token, whatsappRequest{
+		MessagingProduct: "whatsapp",
+		RecipientType:    "individual",
+		To:               NormalizePhone(message.To),
+		Type:             "text",
+		Text:             whatsappText{Body: message.Body},
+	})
+}
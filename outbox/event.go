@@ -0,0 +1,36 @@
+// Package outbox delivers the events SiswaService writes to the outbox_event
+// table to the systems that follow student changes.
+package outbox
+
+import (
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+// Event is what every sink receives. Delivery is at least once, so consumers
+// should skip an Id they have already seen; events of one siswa arrive in
+// the order they happened.
+type Event struct {
+	Id            int64           `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateId   int             `json:"aggregate_id"`
+	Actor         string          `json:"actor"`
+	RequestId     string          `json:"request_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+func NewEvent(outboxEvent domain.OutboxEvent) Event {
+	return Event{
+		Id:            outboxEvent.Id,
+		Type:          outboxEvent.EventType,
+		AggregateType: outboxEvent.AggregateType,
+		AggregateId:   outboxEvent.AggregateId,
+		Actor:         outboxEvent.Actor,
+		RequestId:     outboxEvent.RequestId,
+		OccurredAt:    outboxEvent.CreatedAt,
+		Data:          outboxEvent.Payload,
+	}
+}
@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// KafkaSink produces events through a Kafka REST Proxy (v2 API) to Topic.
+// The record key is the aggregate, so Kafka keeps the events of one siswa in
+// one partition and in order. Locally any HTTP server answering like the
+// proxy will do.
+type KafkaSink struct {
+	URL    string
+	Topic  string
+	Client *http.Client
+}
+
+func NewKafkaSink(url string, topic string) *KafkaSink {
+	return &KafkaSink{URL: strings.TrimSuffix(url, "/"), Topic: topic, Client: &http.Client{Timeout: sinkTimeout}}
+}
+
+func (sink *KafkaSink) Name() string {
+	return "kafka"
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value Event  `json:"value"`
+}
+
+type kafkaOffsets struct {
+	Offsets []struct {
+		Error *string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (sink *KafkaSink) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(kafkaRecords{Records: []kafkaRecord{{
+		Key:   event.AggregateType + "-" + strconv.Itoa(event.AggregateId),
+		Value: event,
+	}}})
+	if err != nil {
+		return err
+	}
+
+	responseBody, err := post(ctx, sink.Client, sink.URL+"/topics/"+sink.Topic, "application/vnd.kafka.json.v2+json", body, nil)
+	if err != nil {
+		return err
+	}
+
+	// the proxy answers 200 even when producing a record failed
+	offsets := kafkaOffsets{}
+	if err := json.Unmarshal(responseBody, &offsets); err != nil {
+		return err
+	}
+	for _, offset := range offsets.Offsets {
+		if offset.Error != nil {
+			return errors.New("kafka: " + *offset.Error)
+		}
+	}
+	return nil
+}
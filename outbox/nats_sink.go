@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NatsSink publishes events to a NATS server on Subject.<aggregate>.<type>,
+// e.g. sisko.siswa.SiswaCreated. It speaks the text protocol in verbose
+// mode, so an event counts as accepted once the server acknowledged it with
+// +OK. Locally nats-server with default options is enough.
+type NatsSink struct {
+	Addr    string
+	Subject string
+	mutex   sync.Mutex
+	conn    net.Conn
+	reader  *bufio.Reader
+}
+
+func NewNatsSink(url string, subject string) *NatsSink {
+	return &NatsSink{Addr: strings.TrimPrefix(url, "nats://"), Subject: subject}
+}
+
+func (sink *NatsSink) Name() string {
+	return "nats"
+}
+
+func (sink *NatsSink) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	err = sink.publish(ctx, sink.Subject+"."+event.AggregateType+"."+event.Type, payload)
+	if err != nil && sink.conn != nil {
+		// start over on a fresh connection next time
+		sink.conn.Close()
+		sink.conn = nil
+	}
+	return err
+}
+
+func (sink *NatsSink) publish(ctx context.Context, subject string, payload []byte) error {
+	if sink.conn == nil {
+		if err := sink.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	deadline := time.Now().Add(sinkTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := sink.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(sink.conn, "PUB %s %d\r\n%s\r\n", subject, len(payload), payload); err != nil {
+		return err
+	}
+	return sink.readOk()
+}
+
+func (sink *NatsSink) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: sinkTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", sink.Addr)
+	if err != nil {
+		return err
+	}
+	sink.conn = conn
+	sink.reader = bufio.NewReader(conn)
+
+	if err := conn.SetDeadline(time.Now().Add(sinkTimeout)); err != nil {
+		return err
+	}
+	line, err := sink.reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return errors.New("nats: expected INFO, got " + strings.TrimSpace(line))
+	}
+	if _, err := fmt.Fprint(conn, "CONNECT {\"verbose\":true,\"pedantic\":false,\"name\":\"sisko\"}\r\n"); err != nil {
+		return err
+	}
+	return sink.readOk()
+}
+
+// readOk waits for the acknowledgement of the last command, answering the
+// server's pings meanwhile.
+func (sink *NatsSink) readOk() error {
+	for {
+		line, err := sink.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "+OK":
+			return nil
+		case line == "PING":
+			if _, err := fmt.Fprint(sink.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("nats: " + strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+// Close drops the connection, if there is one.
+func (sink *NatsSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	if sink.conn == nil {
+		return nil
+	}
+	err := sink.conn.Close()
+	sink.conn = nil
+	return err
+}
@@ -0,0 +1,168 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	relayLock       = "sisko_outbox_relay"
+	relayBatchSize  = 100
+	relayMinBackoff = time.Second
+	relayMaxBackoff = 5 * time.Minute
+)
+
+// Relay publishes committed outbox events to every sink. An event is marked
+// published only after all sinks accepted it, and retried with exponential
+// backoff otherwise, so delivery is at least once. While an event of a siswa
+// is failing, the later events of that siswa wait behind it; other siswa are
+// not held up. After MaxAttempts the event is dead: it is kept but no longer
+// retried, and the events behind it go ahead. A MySQL named lock keeps a
+// second instance from relaying at the same time and reordering events.
+type Relay struct {
+	DB                    *sql.DB
+	OutboxEventRepository repository.OutboxEventRepository
+	Sinks                 []Sink
+	Retention             time.Duration
+	MaxAttempts           int
+}
+
+func NewRelay(DB *sql.DB, outboxEventRepository repository.OutboxEventRepository, sinks []Sink, retention time.Duration, maxAttempts int) *Relay {
+	return &Relay{
+		DB:                    DB,
+		OutboxEventRepository: outboxEventRepository,
+		Sinks:                 sinks,
+		Retention:             retention,
+		MaxAttempts:           maxAttempts,
+	}
+}
+
+// Start relays every interval until ctx is cancelled.
+func (relay *Relay) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				relay.run(ctx)
+			}
+		}
+	}()
+}
+
+func (relay *Relay) run(ctx context.Context) {
+	// a failed run is simply retried on the next tick
+	defer func() {
+		if err := recover(); err != nil {
+			logrus.WithField("error", err).Error("outbox relay failed")
+		}
+	}()
+
+	// keep going while full batches get through
+	for relay.RelayOnce(ctx) == relayBatchSize {
+		logrus.Debug("outbox relay continuing with the next batch")
+	}
+}
+
+// RelayOnce publishes up to one batch of pending events and returns how many
+// of them were published, 0 when another instance holds the lock.
+func (relay *Relay) RelayOnce(ctx context.Context) int {
+	conn, err := relay.DB.Conn(ctx)
+	helper.PanicIfError(err)
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	err = conn.QueryRowContext(ctx, "select get_lock(?, 0)", relayLock).Scan(&locked)
+	helper.PanicIfError(err)
+	if locked.Int64 != 1 {
+		return 0
+	}
+	defer conn.ExecContext(context.Background(), "select release_lock(?)", relayLock)
+
+	var events []domain.OutboxEvent
+	relay.inTx(func(tx *sql.Tx) {
+		events = relay.OutboxEventRepository.FindPending(ctx, tx, time.Now(), relayBatchSize)
+	})
+
+	published := 0
+	waiting := map[int]bool{}
+	for _, outboxEvent := range events {
+		if waiting[outboxEvent.AggregateId] {
+			continue
+		}
+
+		event := NewEvent(outboxEvent)
+		logger := logrus.WithFields(logrus.Fields{"event_id": event.Id, "event_type": event.Type, "aggregate_id": event.AggregateId})
+		if sink, err := relay.publish(ctx, event); err != nil {
+			logger = logger.WithFields(logrus.Fields{"sink": sink, "error": err, "attempts": outboxEvent.Attempts + 1})
+			if outboxEvent.Attempts+1 >= relay.MaxAttempts {
+				logger.Error("publishing event failed, giving up")
+				relay.inTx(func(tx *sql.Tx) {
+					relay.OutboxEventRepository.MarkDead(ctx, tx, event.Id, sink+": "+err.Error(), time.Now())
+				})
+				continue
+			}
+
+			waiting[outboxEvent.AggregateId] = true
+			backoff := relayBackoff(outboxEvent.Attempts)
+			logger.WithField("retry_in", backoff.String()).Warn("publishing event failed")
+			relay.inTx(func(tx *sql.Tx) {
+				relay.OutboxEventRepository.MarkFailed(ctx, tx, event.Id, sink+": "+err.Error(), time.Now().Add(backoff))
+			})
+			continue
+		}
+
+		logger.Debug("event published")
+		relay.inTx(func(tx *sql.Tx) {
+			relay.OutboxEventRepository.MarkPublished(ctx, tx, event.Id, time.Now())
+		})
+		published++
+	}
+	return published
+}
+
+// publish returns the name of the sink that failed along with its error.
+func (relay *Relay) publish(ctx context.Context, event Event) (string, error) {
+	for _, sink := range relay.Sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			return sink.Name(), err
+		}
+	}
+	return "", nil
+}
+
+func (relay *Relay) inTx(fn func(tx *sql.Tx)) {
+	tx, err := relay.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+	fn(tx)
+}
+
+// PurgePublished deletes events published longer than Retention ago.
+func (relay *Relay) PurgePublished(ctx context.Context) int {
+	tx, err := relay.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return relay.OutboxEventRepository.DeletePublished(ctx, tx, time.Now().Add(-relay.Retention))
+}
+
+func relayBackoff(attempts int) time.Duration {
+	backoff := relayMinBackoff
+	for i := 0; i < attempts && backoff < relayMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > relayMaxBackoff {
+		return relayMaxBackoff
+	}
+	return backoff
+}
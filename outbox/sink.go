@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+)
+
+// Sink is somewhere events are published to. Publish returns once the event
+// is accepted; an error has it published again later, to every sink.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, event Event) error
+}
+
+// Handler consumes events published on a Bus.
+type Handler func(ctx context.Context, event Event) error
+
+// Bus is the in-process sink: it hands each event to every subscribed
+// handler, in the order they subscribed, and fails if any of them does.
+type Bus struct {
+	mutex    sync.RWMutex
+	handlers []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+func (bus *Bus) Subscribe(handler Handler) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.handlers = append(bus.handlers, handler)
+}
+
+func (bus *Bus) Name() string {
+	return "bus"
+}
+
+func (bus *Bus) Publish(ctx context.Context, event Event) error {
+	bus.mutex.RLock()
+	defer bus.mutex.RUnlock()
+
+	for _, handler := range bus.handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const sinkTimeout = 10 * time.Second
+
+// WebhookSink POSTs every event as JSON to one URL and counts any 2xx as
+// accepted. Subscriptions per integrator are the webhook feature; this is for
+// a single fixed consumer such as the SMS gateway.
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{URL: url, Client: &http.Client{Timeout: sinkTimeout}}
+}
+
+func (sink *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (sink *WebhookSink) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = post(ctx, sink.Client, sink.URL, "application/json", body, map[string]string{
+		"X-Sisko-Event":    event.Type,
+		"X-Sisko-Event-Id": strconv.FormatInt(event.Id, 10),
+	})
+	return err
+}
+
+// post sends body and returns the response body of a 2xx answer.
+func post(ctx context.Context, client *http.Client, url string, contentType string, body []byte, headers map[string]string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", contentType)
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%s answered %s", url, response.Status)
+	}
+	return responseBody, nil
+}
@@ -0,0 +1,5 @@
+// Package pb holds the protobuf messages and gRPC service generated from
+// siswa.proto. Regenerate with buf, protoc-gen-go and protoc-gen-go-grpc.
+package pb
+
+//go:generate buf generate
@@ -0,0 +1,1306 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: siswa.proto
+
+// The gRPC counterpart of the siswa routes under /api/v2. Errors use the
+// status codes of exception.ToStatus, authentication the x-api-key metadata.
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Siswa struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Nama          string                 `protobuf:"bytes,2,opt,name=nama,proto3" json:"nama,omitempty"`
+	Alamat        string                 `protobuf:"bytes,3,opt,name=alamat,proto3" json:"alamat,omitempty"`
+	TanggalLahir  string                 `protobuf:"bytes,4,opt,name=tanggal_lahir,json=tanggalLahir,proto3" json:"tanggal_lahir,omitempty"`
+	TempatLahir   string                 `protobuf:"bytes,5,opt,name=tempat_lahir,json=tempatLahir,proto3" json:"tempat_lahir,omitempty"`
+	JenisKelamin  string                 `protobuf:"bytes,6,opt,name=jenis_kelamin,json=jenisKelamin,proto3" json:"jenis_kelamin,omitempty"`
+	Agama         string                 `protobuf:"bytes,7,opt,name=agama,proto3" json:"agama,omitempty"`
+	GolonganDarah string                 `protobuf:"bytes,8,opt,name=golongan_darah,json=golonganDarah,proto3" json:"golongan_darah,omitempty"`
+	NoTelepon     string                 `protobuf:"bytes,9,opt,name=no_telepon,json=noTelepon,proto3" json:"no_telepon,omitempty"`
+	Version       int64                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	DeletedBy     string                 `protobuf:"bytes,12,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`
+}
+
+func (x *Siswa) Reset() {
+	*x = Siswa{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Siswa) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Siswa) ProtoMessage() {}
+
+func (x *Siswa) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Siswa.ProtoReflect.Descriptor instead.
+func (*Siswa) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Siswa) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Siswa) GetNama() string {
+	if x != nil {
+		return x.Nama
+	}
+	return ""
+}
+
+func (x *Siswa) GetAlamat() string {
+	if x != nil {
+		return x.Alamat
+	}
+	return ""
+}
+
+func (x *Siswa) GetTanggalLahir() string {
+	if x != nil {
+		return x.TanggalLahir
+	}
+	return ""
+}
+
+func (x *Siswa) GetTempatLahir() string {
+	if x != nil {
+		return x.TempatLahir
+	}
+	return ""
+}
+
+func (x *Siswa) GetJenisKelamin() string {
+	if x != nil {
+		return x.JenisKelamin
+	}
+	return ""
+}
+
+func (x *Siswa) GetAgama() string {
+	if x != nil {
+		return x.Agama
+	}
+	return ""
+}
+
+func (x *Siswa) GetGolonganDarah() string {
+	if x != nil {
+		return x.GolonganDarah
+	}
+	return ""
+}
+
+func (x *Siswa) GetNoTelepon() string {
+	if x != nil {
+		return x.NoTelepon
+	}
+	return ""
+}
+
+func (x *Siswa) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Siswa) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Siswa) GetDeletedBy() string {
+	if x != nil {
+		return x.DeletedBy
+	}
+	return ""
+}
+
+type SiswaData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nama          string `protobuf:"bytes,1,opt,name=nama,proto3" json:"nama,omitempty"`
+	Alamat        string `protobuf:"bytes,2,opt,name=alamat,proto3" json:"alamat,omitempty"`
+	TanggalLahir  string `protobuf:"bytes,3,opt,name=tanggal_lahir,json=tanggalLahir,proto3" json:"tanggal_lahir,omitempty"`
+	TempatLahir   string `protobuf:"bytes,4,opt,name=tempat_lahir,json=tempatLahir,proto3" json:"tempat_lahir,omitempty"`
+	JenisKelamin  string `protobuf:"bytes,5,opt,name=jenis_kelamin,json=jenisKelamin,proto3" json:"jenis_kelamin,omitempty"`
+	Agama         string `protobuf:"bytes,6,opt,name=agama,proto3" json:"agama,omitempty"`
+	GolonganDarah string `protobuf:"bytes,7,opt,name=golongan_darah,json=golonganDarah,proto3" json:"golongan_darah,omitempty"`
+	NoTelepon     string `protobuf:"bytes,8,opt,name=no_telepon,json=noTelepon,proto3" json:"no_telepon,omitempty"`
+}
+
+func (x *SiswaData) Reset() {
+	*x = SiswaData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SiswaData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SiswaData) ProtoMessage() {}
+
+func (x *SiswaData) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SiswaData.ProtoReflect.Descriptor instead.
+func (*SiswaData) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SiswaData) GetNama() string {
+	if x != nil {
+		return x.Nama
+	}
+	return ""
+}
+
+func (x *SiswaData) GetAlamat() string {
+	if x != nil {
+		return x.Alamat
+	}
+	return ""
+}
+
+func (x *SiswaData) GetTanggalLahir() string {
+	if x != nil {
+		return x.TanggalLahir
+	}
+	return ""
+}
+
+func (x *SiswaData) GetTempatLahir() string {
+	if x != nil {
+		return x.TempatLahir
+	}
+	return ""
+}
+
+func (x *SiswaData) GetJenisKelamin() string {
+	if x != nil {
+		return x.JenisKelamin
+	}
+	return ""
+}
+
+func (x *SiswaData) GetAgama() string {
+	if x != nil {
+		return x.Agama
+	}
+	return ""
+}
+
+func (x *SiswaData) GetGolonganDarah() string {
+	if x != nil {
+		return x.GolonganDarah
+	}
+	return ""
+}
+
+func (x *SiswaData) GetNoTelepon() string {
+	if x != nil {
+		return x.NoTelepon
+	}
+	return ""
+}
+
+type CreateSiswaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data *SiswaData `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *CreateSiswaRequest) Reset() {
+	*x = CreateSiswaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSiswaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSiswaRequest) ProtoMessage() {}
+
+func (x *CreateSiswaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSiswaRequest.ProtoReflect.Descriptor instead.
+func (*CreateSiswaRequest) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateSiswaRequest) GetData() *SiswaData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UpdateSiswaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      int64      `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version int64      `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Data    *SiswaData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *UpdateSiswaRequest) Reset() {
+	*x = UpdateSiswaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSiswaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSiswaRequest) ProtoMessage() {}
+
+func (x *UpdateSiswaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSiswaRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSiswaRequest) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateSiswaRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateSiswaRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateSiswaRequest) GetData() *SiswaData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DeleteSiswaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *DeleteSiswaRequest) Reset() {
+	*x = DeleteSiswaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSiswaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSiswaRequest) ProtoMessage() {}
+
+func (x *DeleteSiswaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSiswaRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSiswaRequest) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteSiswaRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteSiswaRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type GetSiswaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetSiswaRequest) Reset() {
+	*x = GetSiswaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSiswaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSiswaRequest) ProtoMessage() {}
+
+func (x *GetSiswaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSiswaRequest.ProtoReflect.Descriptor instead.
+func (*GetSiswaRequest) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetSiswaRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListSiswaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Trash bool `protobuf:"varint,1,opt,name=trash,proto3" json:"trash,omitempty"`
+}
+
+func (x *ListSiswaRequest) Reset() {
+	*x = ListSiswaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSiswaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSiswaRequest) ProtoMessage() {}
+
+func (x *ListSiswaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSiswaRequest.ProtoReflect.Descriptor instead.
+func (*ListSiswaRequest) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSiswaRequest) GetTrash() bool {
+	if x != nil {
+		return x.Trash
+	}
+	return false
+}
+
+type RestoreSiswaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RestoreSiswaRequest) Reset() {
+	*x = RestoreSiswaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreSiswaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSiswaRequest) ProtoMessage() {}
+
+func (x *RestoreSiswaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSiswaRequest.ProtoReflect.Descriptor instead.
+func (*RestoreSiswaRequest) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RestoreSiswaRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type BatchSiswaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// atomic, the default, or best_effort
+	Mode       string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	Operations []*BatchSiswaOperation `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"`
+}
+
+func (x *BatchSiswaRequest) Reset() {
+	*x = BatchSiswaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSiswaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSiswaRequest) ProtoMessage() {}
+
+func (x *BatchSiswaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSiswaRequest.ProtoReflect.Descriptor instead.
+func (*BatchSiswaRequest) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchSiswaRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *BatchSiswaRequest) GetOperations() []*BatchSiswaOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type BatchSiswaOperation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// create, update or delete
+	Action  string     `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Id      int64      `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Version int64      `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Data    *SiswaData `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *BatchSiswaOperation) Reset() {
+	*x = BatchSiswaOperation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSiswaOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSiswaOperation) ProtoMessage() {}
+
+func (x *BatchSiswaOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSiswaOperation.ProtoReflect.Descriptor instead.
+func (*BatchSiswaOperation) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchSiswaOperation) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *BatchSiswaOperation) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BatchSiswaOperation) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *BatchSiswaOperation) GetData() *SiswaData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type BatchSiswaResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode      string              `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	Committed bool                `protobuf:"varint,2,opt,name=committed,proto3" json:"committed,omitempty"`
+	Results   []*BatchSiswaResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *BatchSiswaResponse) Reset() {
+	*x = BatchSiswaResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSiswaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSiswaResponse) ProtoMessage() {}
+
+func (x *BatchSiswaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSiswaResponse.ProtoReflect.Descriptor instead.
+func (*BatchSiswaResponse) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchSiswaResponse) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *BatchSiswaResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+func (x *BatchSiswaResponse) GetResults() []*BatchSiswaResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BatchSiswaResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index  int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	// the HTTP status the operation would have had on its own
+	Code   int32  `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Data   *Siswa `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	Error  string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *BatchSiswaResult) Reset() {
+	*x = BatchSiswaResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSiswaResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSiswaResult) ProtoMessage() {}
+
+func (x *BatchSiswaResult) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSiswaResult.ProtoReflect.Descriptor instead.
+func (*BatchSiswaResult) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchSiswaResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchSiswaResult) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *BatchSiswaResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchSiswaResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BatchSiswaResult) GetData() *Siswa {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *BatchSiswaResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SiswaStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActiveByJenisKelamin map[string]int64 `protobuf:"bytes,1,rep,name=active_by_jenis_kelamin,json=activeByJenisKelamin,proto3" json:"active_by_jenis_kelamin,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Trash                int64            `protobuf:"varint,2,opt,name=trash,proto3" json:"trash,omitempty"`
+}
+
+func (x *SiswaStatistics) Reset() {
+	*x = SiswaStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_siswa_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SiswaStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SiswaStatistics) ProtoMessage() {}
+
+func (x *SiswaStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_siswa_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SiswaStatistics.ProtoReflect.Descriptor instead.
+func (*SiswaStatistics) Descriptor() ([]byte, []int) {
+	return file_siswa_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SiswaStatistics) GetActiveByJenisKelamin() map[string]int64 {
+	if x != nil {
+		return x.ActiveByJenisKelamin
+	}
+	return nil
+}
+
+func (x *SiswaStatistics) GetTrash() int64 {
+	if x != nil {
+		return x.Trash
+	}
+	return 0
+}
+
+var File_siswa_proto protoreflect.FileDescriptor
+
+var file_siswa_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x73, 0x69, 0x73, 0x77, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x73,
+	0x69, 0x73, 0x6b, 0x6f, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x80, 0x03, 0x0a, 0x05, 0x53, 0x69, 0x73, 0x77, 0x61, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6c, 0x61, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6c, 0x61, 0x6d, 0x61, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x74,
+	0x61, 0x6e, 0x67, 0x67, 0x61, 0x6c, 0x5f, 0x6c, 0x61, 0x68, 0x69, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x74, 0x61, 0x6e, 0x67, 0x67, 0x61, 0x6c, 0x4c, 0x61, 0x68, 0x69, 0x72,
+	0x12, 0x21, 0x0a, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x61, 0x74, 0x5f, 0x6c, 0x61, 0x68, 0x69, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x61, 0x74, 0x4c, 0x61,
+	0x68, 0x69, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x6a, 0x65, 0x6e, 0x69, 0x73, 0x5f, 0x6b, 0x65, 0x6c,
+	0x61, 0x6d, 0x69, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6a, 0x65, 0x6e, 0x69,
+	0x73, 0x4b, 0x65, 0x6c, 0x61, 0x6d, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x67, 0x61, 0x6d,
+	0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x67, 0x61, 0x6d, 0x61, 0x12, 0x25,
+	0x0a, 0x0e, 0x67, 0x6f, 0x6c, 0x6f, 0x6e, 0x67, 0x61, 0x6e, 0x5f, 0x64, 0x61, 0x72, 0x61, 0x68,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x67, 0x6f, 0x6c, 0x6f, 0x6e, 0x67, 0x61, 0x6e,
+	0x44, 0x61, 0x72, 0x61, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f, 0x5f, 0x74, 0x65, 0x6c, 0x65,
+	0x70, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x6f, 0x54, 0x65, 0x6c,
+	0x65, 0x70, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39,
+	0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x42, 0x79, 0x22, 0x80, 0x02, 0x0a, 0x09, 0x53, 0x69, 0x73,
+	0x77, 0x61, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x61, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6c,
+	0x61, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6c, 0x61, 0x6d,
+	0x61, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x61, 0x6e, 0x67, 0x67, 0x61, 0x6c, 0x5f, 0x6c, 0x61,
+	0x68, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x61, 0x6e, 0x67, 0x67,
+	0x61, 0x6c, 0x4c, 0x61, 0x68, 0x69, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x61,
+	0x74, 0x5f, 0x6c, 0x61, 0x68, 0x69, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74,
+	0x65, 0x6d, 0x70, 0x61, 0x74, 0x4c, 0x61, 0x68, 0x69, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x6a, 0x65,
+	0x6e, 0x69, 0x73, 0x5f, 0x6b, 0x65, 0x6c, 0x61, 0x6d, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x6a, 0x65, 0x6e, 0x69, 0x73, 0x4b, 0x65, 0x6c, 0x61, 0x6d, 0x69, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x61, 0x67, 0x61, 0x6d, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x61, 0x67, 0x61, 0x6d, 0x61, 0x12, 0x25, 0x0a, 0x0e, 0x67, 0x6f, 0x6c, 0x6f, 0x6e, 0x67, 0x61,
+	0x6e, 0x5f, 0x64, 0x61, 0x72, 0x61, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x67,
+	0x6f, 0x6c, 0x6f, 0x6e, 0x67, 0x61, 0x6e, 0x44, 0x61, 0x72, 0x61, 0x68, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6e, 0x6f, 0x5f, 0x74, 0x65, 0x6c, 0x65, 0x70, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6e, 0x6f, 0x54, 0x65, 0x6c, 0x65, 0x70, 0x6f, 0x6e, 0x22, 0x3d, 0x0a, 0x12, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x69, 0x73, 0x77, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x27, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x73, 0x77, 0x61,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x67, 0x0a, 0x12, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x53, 0x69, 0x73, 0x77, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x73, 0x77, 0x61, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0x3e, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x69, 0x73,
+	0x77, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x21, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x69, 0x73, 0x77, 0x61, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x28, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x69,
+	0x73, 0x77, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x72,
+	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x74, 0x72, 0x61, 0x73, 0x68,
+	0x22, 0x25, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x69, 0x73, 0x77, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x66, 0x0a, 0x11, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x69, 0x73, 0x77, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x12, 0x3d, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x73, 0x77, 0x61, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x80, 0x01, 0x0a, 0x13, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x73, 0x77, 0x61, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x69, 0x73, 0x77, 0x61, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x7c, 0x0a, 0x12, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x73, 0x77, 0x61,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x69,
+	0x73, 0x6b, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x73, 0x77,
+	0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x22, 0xa7, 0x01, 0x0a, 0x10, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x73, 0x77, 0x61, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x23, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x73, 0x77, 0x61, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xdc, 0x01, 0x0a, 0x0f, 0x53,
+	0x69, 0x73, 0x77, 0x61, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x6a,
+	0x0a, 0x17, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x62, 0x79, 0x5f, 0x6a, 0x65, 0x6e, 0x69,
+	0x73, 0x5f, 0x6b, 0x65, 0x6c, 0x61, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x33, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x73, 0x77, 0x61,
+	0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x42, 0x79, 0x4a, 0x65, 0x6e, 0x69, 0x73, 0x4b, 0x65, 0x6c, 0x61, 0x6d, 0x69, 0x6e, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x14, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x42, 0x79, 0x4a, 0x65,
+	0x6e, 0x69, 0x73, 0x4b, 0x65, 0x6c, 0x61, 0x6d, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x72,
+	0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x72, 0x61, 0x73, 0x68,
+	0x1a, 0x47, 0x0a, 0x19, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x42, 0x79, 0x4a, 0x65, 0x6e, 0x69,
+	0x73, 0x4b, 0x65, 0x6c, 0x61, 0x6d, 0x69, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0x95, 0x04, 0x0a, 0x0c, 0x53, 0x69,
+	0x73, 0x77, 0x61, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x53, 0x69, 0x73, 0x77, 0x61, 0x12, 0x1c, 0x2e, 0x73, 0x69, 0x73, 0x6b,
+	0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x69, 0x73, 0x77, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x69, 0x73, 0x77, 0x61, 0x12, 0x3c, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x53, 0x69, 0x73, 0x77, 0x61, 0x12, 0x1c, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x69, 0x73, 0x77, 0x61, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x69, 0x73, 0x77, 0x61, 0x12, 0x43, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x53, 0x69, 0x73, 0x77, 0x61, 0x12, 0x1c, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x69, 0x73, 0x77, 0x61, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x47, 0x0a, 0x0a, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x73, 0x77, 0x61, 0x12, 0x1b, 0x2e, 0x73, 0x69, 0x73, 0x6b,
+	0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x73, 0x77, 0x61, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x73, 0x77, 0x61, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x69, 0x73, 0x77, 0x61,
+	0x12, 0x19, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x69, 0x73, 0x77, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x73, 0x69,
+	0x73, 0x6b, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x73, 0x77, 0x61, 0x12, 0x3a, 0x0a, 0x09,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x69, 0x73, 0x77, 0x61, 0x12, 0x1a, 0x2e, 0x73, 0x69, 0x73, 0x6b,
+	0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x69, 0x73, 0x77, 0x61, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x69, 0x73, 0x77, 0x61, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x53, 0x69, 0x73, 0x77, 0x61, 0x12, 0x1d, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x69, 0x73, 0x77, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x69, 0x73, 0x77, 0x61, 0x12, 0x47, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53,
+	0x69, 0x73, 0x77, 0x61, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x73, 0x69, 0x73, 0x6b, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x69, 0x73, 0x77, 0x61, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x42, 0x20, 0x5a, 0x1e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x41, 0x72, 0x72, 0x61, 0x66, 0x31, 0x38, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x69, 0x73, 0x6b, 0x6f,
+	0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_siswa_proto_rawDescOnce sync.Once
+	file_siswa_proto_rawDescData = file_siswa_proto_rawDesc
+)
+
+func file_siswa_proto_rawDescGZIP() []byte {
+	file_siswa_proto_rawDescOnce.Do(func() {
+		file_siswa_proto_rawDescData = protoimpl.X.CompressGZIP(file_siswa_proto_rawDescData)
+	})
+	return file_siswa_proto_rawDescData
+}
+
+var file_siswa_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_siswa_proto_goTypes = []interface{}{
+	(*Siswa)(nil),                 // 0: sisko.v1.Siswa
+	(*SiswaData)(nil),             // 1: sisko.v1.SiswaData
+	(*CreateSiswaRequest)(nil),    // 2: sisko.v1.CreateSiswaRequest
+	(*UpdateSiswaRequest)(nil),    // 3: sisko.v1.UpdateSiswaRequest
+	(*DeleteSiswaRequest)(nil),    // 4: sisko.v1.DeleteSiswaRequest
+	(*GetSiswaRequest)(nil),       // 5: sisko.v1.GetSiswaRequest
+	(*ListSiswaRequest)(nil),      // 6: sisko.v1.ListSiswaRequest
+	(*RestoreSiswaRequest)(nil),   // 7: sisko.v1.RestoreSiswaRequest
+	(*BatchSiswaRequest)(nil),     // 8: sisko.v1.BatchSiswaRequest
+	(*BatchSiswaOperation)(nil),   // 9: sisko.v1.BatchSiswaOperation
+	(*BatchSiswaResponse)(nil),    // 10: sisko.v1.BatchSiswaResponse
+	(*BatchSiswaResult)(nil),      // 11: sisko.v1.BatchSiswaResult
+	(*SiswaStatistics)(nil),       // 12: sisko.v1.SiswaStatistics
+	nil,                           // 13: sisko.v1.SiswaStatistics.ActiveByJenisKelaminEntry
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 15: google.protobuf.Empty
+}
+var file_siswa_proto_depIdxs = []int32{
+	14, // 0: sisko.v1.Siswa.deleted_at:type_name -> google.protobuf.Timestamp
+	1,  // 1: sisko.v1.CreateSiswaRequest.data:type_name -> sisko.v1.SiswaData
+	1,  // 2: sisko.v1.UpdateSiswaRequest.data:type_name -> sisko.v1.SiswaData
+	9,  // 3: sisko.v1.BatchSiswaRequest.operations:type_name -> sisko.v1.BatchSiswaOperation
+	1,  // 4: sisko.v1.BatchSiswaOperation.data:type_name -> sisko.v1.SiswaData
+	11, // 5: sisko.v1.BatchSiswaResponse.results:type_name -> sisko.v1.BatchSiswaResult
+	0,  // 6: sisko.v1.BatchSiswaResult.data:type_name -> sisko.v1.Siswa
+	13, // 7: sisko.v1.SiswaStatistics.active_by_jenis_kelamin:type_name -> sisko.v1.SiswaStatistics.ActiveByJenisKelaminEntry
+	2,  // 8: sisko.v1.SiswaService.CreateSiswa:input_type -> sisko.v1.CreateSiswaRequest
+	3,  // 9: sisko.v1.SiswaService.UpdateSiswa:input_type -> sisko.v1.UpdateSiswaRequest
+	4,  // 10: sisko.v1.SiswaService.DeleteSiswa:input_type -> sisko.v1.DeleteSiswaRequest
+	8,  // 11: sisko.v1.SiswaService.BatchSiswa:input_type -> sisko.v1.BatchSiswaRequest
+	5,  // 12: sisko.v1.SiswaService.GetSiswa:input_type -> sisko.v1.GetSiswaRequest
+	6,  // 13: sisko.v1.SiswaService.ListSiswa:input_type -> sisko.v1.ListSiswaRequest
+	7,  // 14: sisko.v1.SiswaService.RestoreSiswa:input_type -> sisko.v1.RestoreSiswaRequest
+	15, // 15: sisko.v1.SiswaService.GetSiswaStatistics:input_type -> google.protobuf.Empty
+	0,  // 16: sisko.v1.SiswaService.CreateSiswa:output_type -> sisko.v1.Siswa
+	0,  // 17: sisko.v1.SiswaService.UpdateSiswa:output_type -> sisko.v1.Siswa
+	15, // 18: sisko.v1.SiswaService.DeleteSiswa:output_type -> google.protobuf.Empty
+	10, // 19: sisko.v1.SiswaService.BatchSiswa:output_type -> sisko.v1.BatchSiswaResponse
+	0,  // 20: sisko.v1.SiswaService.GetSiswa:output_type -> sisko.v1.Siswa
+	0,  // 21: sisko.v1.SiswaService.ListSiswa:output_type -> sisko.v1.Siswa
+	0,  // 22: sisko.v1.SiswaService.RestoreSiswa:output_type -> sisko.v1.Siswa
+	12, // 23: sisko.v1.SiswaService.GetSiswaStatistics:output_type -> sisko.v1.SiswaStatistics
+	16, // [16:24] is the sub-list for method output_type
+	8,  // [8:16] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_siswa_proto_init() }
+func file_siswa_proto_init() {
+	if File_siswa_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_siswa_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Siswa); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_siswa_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SiswaData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_siswa_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSiswaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_siswa_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateSiswaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_siswa_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSiswaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_siswa_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSiswaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_siswa_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSiswaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_siswa_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreSiswaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_siswa_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSiswaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_siswa_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSiswaOperation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_siswa_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSiswaResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_siswa_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSiswaResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_siswa_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SiswaStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_siswa_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_siswa_proto_goTypes,
+		DependencyIndexes: file_siswa_proto_depIdxs,
+		MessageInfos:      file_siswa_proto_msgTypes,
+	}.Build()
+	File_siswa_proto = out.File
+	file_siswa_proto_rawDesc = nil
+	file_siswa_proto_goTypes = nil
+	file_siswa_proto_depIdxs = nil
+}
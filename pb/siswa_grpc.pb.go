@@ -0,0 +1,406 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: siswa.proto
+
+// The gRPC counterpart of the siswa routes under /api/v2. Errors use the
+// status codes of exception.ToStatus, authentication the x-api-key metadata.
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SiswaService_CreateSiswa_FullMethodName        = "/sisko.v1.SiswaService/CreateSiswa"
+	SiswaService_UpdateSiswa_FullMethodName        = "/sisko.v1.SiswaService/UpdateSiswa"
+	SiswaService_DeleteSiswa_FullMethodName        = "/sisko.v1.SiswaService/DeleteSiswa"
+	SiswaService_BatchSiswa_FullMethodName         = "/sisko.v1.SiswaService/BatchSiswa"
+	SiswaService_GetSiswa_FullMethodName           = "/sisko.v1.SiswaService/GetSiswa"
+	SiswaService_ListSiswa_FullMethodName          = "/sisko.v1.SiswaService/ListSiswa"
+	SiswaService_RestoreSiswa_FullMethodName       = "/sisko.v1.SiswaService/RestoreSiswa"
+	SiswaService_GetSiswaStatistics_FullMethodName = "/sisko.v1.SiswaService/GetSiswaStatistics"
+)
+
+// SiswaServiceClient is the client API for SiswaService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SiswaServiceClient interface {
+	CreateSiswa(ctx context.Context, in *CreateSiswaRequest, opts ...grpc.CallOption) (*Siswa, error)
+	// UpdateSiswa needs the version last read, like If-Match does.
+	UpdateSiswa(ctx context.Context, in *UpdateSiswaRequest, opts ...grpc.CallOption) (*Siswa, error)
+	DeleteSiswa(ctx context.Context, in *DeleteSiswaRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// BatchSiswa answers OK with committed false when an atomic batch rolled back.
+	BatchSiswa(ctx context.Context, in *BatchSiswaRequest, opts ...grpc.CallOption) (*BatchSiswaResponse, error)
+	GetSiswa(ctx context.Context, in *GetSiswaRequest, opts ...grpc.CallOption) (*Siswa, error)
+	// ListSiswa streams every siswa, or the soft deleted ones with trash.
+	ListSiswa(ctx context.Context, in *ListSiswaRequest, opts ...grpc.CallOption) (SiswaService_ListSiswaClient, error)
+	RestoreSiswa(ctx context.Context, in *RestoreSiswaRequest, opts ...grpc.CallOption) (*Siswa, error)
+	GetSiswaStatistics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SiswaStatistics, error)
+}
+
+type siswaServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSiswaServiceClient(cc grpc.ClientConnInterface) SiswaServiceClient {
+	return &siswaServiceClient{cc}
+}
+
+func (c *siswaServiceClient) CreateSiswa(ctx context.Context, in *CreateSiswaRequest, opts ...grpc.CallOption) (*Siswa, error) {
+	out := new(Siswa)
+	err := c.cc.Invoke(ctx, SiswaService_CreateSiswa_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siswaServiceClient) UpdateSiswa(ctx context.Context, in *UpdateSiswaRequest, opts ...grpc.CallOption) (*Siswa, error) {
+	out := new(Siswa)
+	err := c.cc.Invoke(ctx, SiswaService_UpdateSiswa_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siswaServiceClient) DeleteSiswa(ctx context.Context, in *DeleteSiswaRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SiswaService_DeleteSiswa_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siswaServiceClient) BatchSiswa(ctx context.Context, in *BatchSiswaRequest, opts ...grpc.CallOption) (*BatchSiswaResponse, error) {
+	out := new(BatchSiswaResponse)
+	err := c.cc.Invoke(ctx, SiswaService_BatchSiswa_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siswaServiceClient) GetSiswa(ctx context.Context, in *GetSiswaRequest, opts ...grpc.CallOption) (*Siswa, error) {
+	out := new(Siswa)
+	err := c.cc.Invoke(ctx, SiswaService_GetSiswa_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siswaServiceClient) ListSiswa(ctx context.Context, in *ListSiswaRequest, opts ...grpc.CallOption) (SiswaService_ListSiswaClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SiswaService_ServiceDesc.Streams[0], SiswaService_ListSiswa_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &siswaServiceListSiswaClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SiswaService_ListSiswaClient interface {
+	Recv() (*Siswa, error)
+	grpc.ClientStream
+}
+
+type siswaServiceListSiswaClient struct {
+	grpc.ClientStream
+}
+
+func (x *siswaServiceListSiswaClient) Recv() (*Siswa, error) {
+	m := new(Siswa)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *siswaServiceClient) RestoreSiswa(ctx context.Context, in *RestoreSiswaRequest, opts ...grpc.CallOption) (*Siswa, error) {
+	out := new(Siswa)
+	err := c.cc.Invoke(ctx, SiswaService_RestoreSiswa_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siswaServiceClient) GetSiswaStatistics(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SiswaStatistics, error) {
+	out := new(SiswaStatistics)
+	err := c.cc.Invoke(ctx, SiswaService_GetSiswaStatistics_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SiswaServiceServer is the server API for SiswaService service.
+// All implementations must embed UnimplementedSiswaServiceServer
+// for forward compatibility
+type SiswaServiceServer interface {
+	CreateSiswa(context.Context, *CreateSiswaRequest) (*Siswa, error)
+	// UpdateSiswa needs the version last read, like If-Match does.
+	UpdateSiswa(context.Context, *UpdateSiswaRequest) (*Siswa, error)
+	DeleteSiswa(context.Context, *DeleteSiswaRequest) (*emptypb.Empty, error)
+	// BatchSiswa answers OK with committed false when an atomic batch rolled back.
+	BatchSiswa(context.Context, *BatchSiswaRequest) (*BatchSiswaResponse, error)
+	GetSiswa(context.Context, *GetSiswaRequest) (*Siswa, error)
+	// ListSiswa streams every siswa, or the soft deleted ones with trash.
+	ListSiswa(*ListSiswaRequest, SiswaService_ListSiswaServer) error
+	RestoreSiswa(context.Context, *RestoreSiswaRequest) (*Siswa, error)
+	GetSiswaStatistics(context.Context, *emptypb.Empty) (*SiswaStatistics, error)
+	mustEmbedUnimplementedSiswaServiceServer()
+}
+
+// UnimplementedSiswaServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSiswaServiceServer struct {
+}
+
+func (UnimplementedSiswaServiceServer) CreateSiswa(context.Context, *CreateSiswaRequest) (*Siswa, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSiswa not implemented")
+}
+func (UnimplementedSiswaServiceServer) UpdateSiswa(context.Context, *UpdateSiswaRequest) (*Siswa, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSiswa not implemented")
+}
+func (UnimplementedSiswaServiceServer) DeleteSiswa(context.Context, *DeleteSiswaRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSiswa not implemented")
+}
+func (UnimplementedSiswaServiceServer) BatchSiswa(context.Context, *BatchSiswaRequest) (*BatchSiswaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchSiswa not implemented")
+}
+func (UnimplementedSiswaServiceServer) GetSiswa(context.Context, *GetSiswaRequest) (*Siswa, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSiswa not implemented")
+}
+func (UnimplementedSiswaServiceServer) ListSiswa(*ListSiswaRequest, SiswaService_ListSiswaServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListSiswa not implemented")
+}
+func (UnimplementedSiswaServiceServer) RestoreSiswa(context.Context, *RestoreSiswaRequest) (*Siswa, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreSiswa not implemented")
+}
+func (UnimplementedSiswaServiceServer) GetSiswaStatistics(context.Context, *emptypb.Empty) (*SiswaStatistics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSiswaStatistics not implemented")
+}
+func (UnimplementedSiswaServiceServer) mustEmbedUnimplementedSiswaServiceServer() {}
+
+// UnsafeSiswaServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SiswaServiceServer will
+// result in compilation errors.
+type UnsafeSiswaServiceServer interface {
+	mustEmbedUnimplementedSiswaServiceServer()
+}
+
+func RegisterSiswaServiceServer(s grpc.ServiceRegistrar, srv SiswaServiceServer) {
+	s.RegisterService(&SiswaService_ServiceDesc, srv)
+}
+
+func _SiswaService_CreateSiswa_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSiswaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiswaServiceServer).CreateSiswa(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiswaService_CreateSiswa_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiswaServiceServer).CreateSiswa(ctx, req.(*CreateSiswaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiswaService_UpdateSiswa_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSiswaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiswaServiceServer).UpdateSiswa(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiswaService_UpdateSiswa_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiswaServiceServer).UpdateSiswa(ctx, req.(*UpdateSiswaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiswaService_DeleteSiswa_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSiswaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiswaServiceServer).DeleteSiswa(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiswaService_DeleteSiswa_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiswaServiceServer).DeleteSiswa(ctx, req.(*DeleteSiswaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiswaService_BatchSiswa_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchSiswaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiswaServiceServer).BatchSiswa(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiswaService_BatchSiswa_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiswaServiceServer).BatchSiswa(ctx, req.(*BatchSiswaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiswaService_GetSiswa_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSiswaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiswaServiceServer).GetSiswa(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiswaService_GetSiswa_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiswaServiceServer).GetSiswa(ctx, req.(*GetSiswaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiswaService_ListSiswa_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSiswaRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SiswaServiceServer).ListSiswa(m, &siswaServiceListSiswaServer{stream})
+}
+
+type SiswaService_ListSiswaServer interface {
+	Send(*Siswa) error
+	grpc.ServerStream
+}
+
+type siswaServiceListSiswaServer struct {
+	grpc.ServerStream
+}
+
+func (x *siswaServiceListSiswaServer) Send(m *Siswa) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SiswaService_RestoreSiswa_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSiswaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiswaServiceServer).RestoreSiswa(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiswaService_RestoreSiswa_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiswaServiceServer).RestoreSiswa(ctx, req.(*RestoreSiswaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiswaService_GetSiswaStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiswaServiceServer).GetSiswaStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiswaService_GetSiswaStatistics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiswaServiceServer).GetSiswaStatistics(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SiswaService_ServiceDesc is the grpc.ServiceDesc for SiswaService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SiswaService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sisko.v1.SiswaService",
+	HandlerType: (*SiswaServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSiswa",
+			Handler:    _SiswaService_CreateSiswa_Handler,
+		},
+		{
+			MethodName: "UpdateSiswa",
+			Handler:    _SiswaService_UpdateSiswa_Handler,
+		},
+		{
+			MethodName: "DeleteSiswa",
+			Handler:    _SiswaService_DeleteSiswa_Handler,
+		},
+		{
+			MethodName: "BatchSiswa",
+			Handler:    _SiswaService_BatchSiswa_Handler,
+		},
+		{
+			MethodName: "GetSiswa",
+			Handler:    _SiswaService_GetSiswa_Handler,
+		},
+		{
+			MethodName: "RestoreSiswa",
+			Handler:    _SiswaService_RestoreSiswa_Handler,
+		},
+		{
+			MethodName: "GetSiswaStatistics",
+			Handler:    _SiswaService_GetSiswaStatistics_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListSiswa",
+			Handler:       _SiswaService_ListSiswa_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "siswa.proto",
+}
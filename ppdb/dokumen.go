@@ -0,0 +1,67 @@
+package ppdb
+
+import (
+	"github.com/Arraf18/go-sisko/model/domain"
+	"mime"
+)
+
+const (
+	DokumenAktaKelahiran = "akta_kelahiran"
+	DokumenKartuKeluarga = "kartu_keluarga"
+	DokumenRapor         = "rapor"
+	DokumenSertifikat    = "sertifikat_prestasi"
+	DokumenKip           = "kip"
+)
+
+// requiredDokumen are the documents a pendaftar must upload before being
+// verified: everyone proves their birth date and address, prestasi needs
+// the achievements and afirmasi the Kartu Indonesia Pintar.
+var requiredDokumen = map[string][]string{
+	domain.JalurZonasi:   {DokumenAktaKelahiran, DokumenKartuKeluarga},
+	domain.JalurPrestasi: {DokumenAktaKelahiran, DokumenKartuKeluarga, DokumenRapor, DokumenSertifikat},
+	domain.JalurAfirmasi: {DokumenAktaKelahiran, DokumenKartuKeluarga, DokumenKip},
+}
+
+// DokumenContentTypes are the files accepted: scans and photos.
+var DokumenContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+// IsDokumen reports whether jenis is a document any jalur asks for.
+func IsDokumen(jenis string) bool {
+	switch jenis {
+	case DokumenAktaKelahiran, DokumenKartuKeluarga, DokumenRapor, DokumenSertifikat, DokumenKip:
+		return true
+	}
+	return false
+}
+
+// MissingDokumen returns the documents of the jalur of pendaftar it has
+// not uploaded yet.
+func MissingDokumen(pendaftar domain.Pendaftar) []string {
+	uploaded := map[string]bool{}
+	for _, dokumen := range pendaftar.Dokumen {
+		uploaded[dokumen.Jenis] = true
+	}
+
+	var missing []string
+	for _, jenis := range requiredDokumen[pendaftar.Jalur] {
+		if !uploaded[jenis] {
+			missing = append(missing, jenis)
+		}
+	}
+	return missing
+}
+
+// DokumenContentType returns the media type of a Content-Type header if
+// documents may have it.
+func DokumenContentType(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+	for _, accepted := range DokumenContentTypes {
+		if mediaType == accepted {
+			return mediaType, true
+		}
+	}
+	return "", false
+}
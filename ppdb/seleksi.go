@@ -0,0 +1,89 @@
+package ppdb
+
+import (
+	"github.com/Arraf18/go-sisko/model/domain"
+	"sort"
+)
+
+// Kuota returns the seats sekolah offers per jalur.
+func Kuota(sekolah domain.Sekolah) map[string]int {
+	return map[string]int{
+		domain.JalurZonasi:   sekolah.KuotaZonasi,
+		domain.JalurPrestasi: sekolah.KuotaPrestasi,
+		domain.JalurAfirmasi: sekolah.KuotaAfirmasi,
+	}
+}
+
+// Rank orders pendaftar of one jalur best first. Zonasi favours who lives
+// closest and then the higher score; prestasi and afirmasi the higher score
+// and then who lives closest. Whoever registered first wins a tie.
+func Rank(pendaftars []domain.Pendaftar) {
+	sort.SliceStable(pendaftars, func(i, j int) bool {
+		a, b := pendaftars[i], pendaftars[j]
+		if a.Jalur == domain.JalurZonasi && a.Jarak != b.Jarak {
+			return a.Jarak < b.Jarak
+		}
+		if nilai(a) != nilai(b) {
+			return nilai(a) > nilai(b)
+		}
+		if a.Jarak != b.Jarak {
+			return a.Jarak < b.Jarak
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+}
+
+// Select ranks tested pendaftar per jalur and accepts the best of them
+// while seats of kuota are left, rejecting the rest. Taken are the seats
+// earlier selections already filled, so ranks carry on after them. Seats
+// caps the acceptances of every jalur together, e.g. for a school almost at
+// its kuota of siswa; the jalur then take turns by rank, so the best of each
+// get in first. A negative seats caps nothing.
+func Select(pendaftars []domain.Pendaftar, kuota map[string]int, taken map[string]int, seats int) []domain.Pendaftar {
+	byJalur := map[string][]domain.Pendaftar{}
+	var jalurs []string
+	for _, pendaftar := range pendaftars {
+		if _, ok := byJalur[pendaftar.Jalur]; !ok {
+			jalurs = append(jalurs, pendaftar.Jalur)
+		}
+		byJalur[pendaftar.Jalur] = append(byJalur[pendaftar.Jalur], pendaftar)
+	}
+
+	for _, jalur := range jalurs {
+		ranked := byJalur[jalur]
+		Rank(ranked)
+		for i := range ranked {
+			ranked[i].Peringkat = taken[jalur] + i + 1
+			ranked[i].Status = domain.PendaftarRejected
+		}
+	}
+
+	for i := 0; seats != 0; i++ {
+		accepting := false
+		for _, jalur := range jalurs {
+			ranked := byJalur[jalur]
+			if i >= len(ranked) || ranked[i].Peringkat > kuota[jalur] || seats == 0 {
+				continue
+			}
+			ranked[i].Status = domain.PendaftarAccepted
+			accepting = true
+			seats--
+		}
+		if !accepting {
+			break
+		}
+	}
+
+	var selected []domain.Pendaftar
+	for _, jalur := range jalurs {
+		selected = append(selected, byJalur[jalur]...)
+	}
+	return selected
+}
+
+func nilai(pendaftar domain.Pendaftar) float64 {
+	if pendaftar.Nilai == nil {
+		return 0
+	}
+	return *pendaftar.Nilai
+}
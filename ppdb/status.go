@@ -0,0 +1,28 @@
+package ppdb
+
+import (
+	"errors"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+// transitions lists the statuses a pendaftar may move to from each status.
+// Staff verify the documents and record the test score; selection accepts
+// or rejects the tested ones, and accepted pendaftar re-register as siswa.
+// Staff may reject a pendaftar until selection, e.g. for a forged document.
+var transitions = map[string][]string{
+	domain.PendaftarRegistered: {domain.PendaftarVerified, domain.PendaftarRejected},
+	domain.PendaftarVerified:   {domain.PendaftarTested, domain.PendaftarRejected},
+	domain.PendaftarTested:     {domain.PendaftarAccepted, domain.PendaftarRejected},
+	domain.PendaftarAccepted:   {domain.PendaftarReRegistered},
+}
+
+// Transition returns an error when a pendaftar in status from may not move
+// to status to.
+func Transition(from string, to string) error {
+	for _, next := range transitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return errors.New("pendaftar that is " + from + " cannot become " + to)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type ApiKeyRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, apiKey domain.ApiKey) domain.ApiKey
+	FindActiveByHash(ctx context.Context, tx *sql.Tx, keyHash string) (domain.ApiKey, error)
+	FindAll(ctx context.Context, tx *sql.Tx) []domain.ApiKey
+	Revoke(ctx context.Context, tx *sql.Tx, apiKeyId int, revokedAt time.Time) error
+}
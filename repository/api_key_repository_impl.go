@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type ApiKeyRepositoryImpl struct {
+}
+
+func NewApiKeyRepository() ApiKeyRepository {
+	return &ApiKeyRepositoryImpl{}
+}
+
+const apiKeyColumns = "api_key.id, api_key.user_id, user_account.username, api_key.name, api_key.prefix, api_key.key_hash, api_key.created_at, api_key.revoked_at"
+
+func scanApiKey(rows *sql.Rows) domain.ApiKey {
+	apiKey := domain.ApiKey{}
+	err := rows.Scan(&apiKey.Id, &apiKey.UserId, &apiKey.Username, &apiKey.Name, &apiKey.Prefix, &apiKey.KeyHash, &apiKey.CreatedAt, &apiKey.RevokedAt)
+	helper.PanicIfError(err)
+	return apiKey
+}
+
+func (c ApiKeyRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, apiKey domain.ApiKey) domain.ApiKey {
+	SQL := "insert into api_key(user_id, name, prefix, key_hash, created_at) values (?,?,?,?,?)"
+	result, err := tx.ExecContext(ctx, SQL, apiKey.UserId, apiKey.Name, apiKey.Prefix, apiKey.KeyHash, apiKey.CreatedAt)
+	helper.PanicIfError(err)
+
+	id, err := result.LastInsertId()
+	helper.PanicIfError(err)
+
+	apiKey.Id = int(id)
+	return apiKey
+}
+
+func (c ApiKeyRepositoryImpl) FindActiveByHash(ctx context.Context, tx *sql.Tx, keyHash string) (domain.ApiKey, error) {
+	SQL := "select " + apiKeyColumns + " from api_key join user_account on user_account.id = api_key.user_id where api_key.key_hash = ? and api_key.revoked_at is null"
+	rows, err := tx.QueryContext(ctx, SQL, keyHash)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	if rows.Next() {
+		return scanApiKey(rows), nil
+	} else {
+		return domain.ApiKey{}, errors.New("api key is not found")
+	}
+}
+
+func (c ApiKeyRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.ApiKey {
+	SQL := "select " + apiKeyColumns + " from api_key join user_account on user_account.id = api_key.user_id order by api_key.id"
+	rows, err := tx.QueryContext(ctx, SQL)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var apiKeys []domain.ApiKey
+	for rows.Next() {
+		apiKeys = append(apiKeys, scanApiKey(rows))
+	}
+	return apiKeys
+}
+
+func (c ApiKeyRepositoryImpl) Revoke(ctx context.Context, tx *sql.Tx, apiKeyId int, revokedAt time.Time) error {
+	SQL := "update api_key set revoked_at = ? where id = ? and revoked_at is null"
+	result, err := tx.ExecContext(ctx, SQL, revokedAt, apiKeyId)
+	helper.PanicIfError(err)
+
+	affected, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	if affected == 0 {
+		return errors.New("api key is not found")
+	}
+	return nil
+}
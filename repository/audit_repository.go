@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+type AuditRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, audit domain.Audit) domain.Audit
+	FindAll(ctx context.Context, tx *sql.Tx, filter domain.AuditFilter) []domain.Audit
+}
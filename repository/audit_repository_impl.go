@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"strings"
+)
+
+type AuditRepositoryImpl struct {
+}
+
+func NewAuditRepository() AuditRepository {
+	return &AuditRepositoryImpl{}
+}
+
+const auditColumns = "id, sekolah_id, entity, entity_id, action, actor, request_id, changes, created_at"
+
+func (c AuditRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, audit domain.Audit) domain.Audit {
+	changes, err := json.Marshal(audit.Changes)
+	helper.PanicIfError(err)
+
+	SQL := "insert into audit(sekolah_id, entity, entity_id, action, actor, request_id, changes, created_at) values (?,?,?,?,?,?,?,?)"
+	result, err := tx.ExecContext(ctx, SQL, nullInt(audit.SekolahId), audit.Entity, audit.EntityId, audit.Action, audit.Actor, audit.RequestId, string(changes), audit.CreatedAt)
+	helper.PanicIfError(err)
+
+	id, err := result.LastInsertId()
+	helper.PanicIfError(err)
+
+	audit.Id = int(id)
+	return audit
+}
+
+func (c AuditRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, filter domain.AuditFilter) []domain.Audit {
+	where := "where 1 = 1"
+	var args []interface{}
+	if filter.SekolahId != 0 {
+		where += " and sekolah_id = ?"
+		args = append(args, filter.SekolahId)
+	}
+	if filter.Actor != "" {
+		where += " and actor = ?"
+		args = append(args, filter.Actor)
+	}
+	if filter.Entity != "" {
+		where += " and entity = ?"
+		args = append(args, filter.Entity)
+	}
+	if filter.EntityId != 0 {
+		where += " and entity_id = ?"
+		args = append(args, filter.EntityId)
+	}
+	if len(filter.EntityIds) > 0 {
+		where += " and entity_id in (?" + strings.Repeat(", ?", len(filter.EntityIds)-1) + ")"
+		for _, entityId := range filter.EntityIds {
+			args = append(args, entityId)
+		}
+	}
+	if filter.From != nil {
+		where += " and created_at >= ?"
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		where += " and created_at < ?"
+		args = append(args, *filter.To)
+	}
+	SQL := "select " + auditColumns + " from audit " + where
+	if filter.Latest != 0 {
+		SQL = "select " + auditColumns + " from (select " + auditColumns + ", row_number() over (partition by entity, entity_id order by id desc) as latest from audit " + where + ") audit where latest <= ?"
+		args = append(args, filter.Latest)
+	}
+	SQL += " order by created_at, id"
+
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var audits []domain.Audit
+	for rows.Next() {
+		audit := domain.Audit{}
+		var sekolahId sql.NullInt64
+		var changes []byte
+		err := rows.Scan(&audit.Id, &sekolahId, &audit.Entity, &audit.EntityId, &audit.Action, &audit.Actor, &audit.RequestId, &changes, &audit.CreatedAt)
+		helper.PanicIfError(err)
+
+		err = json.Unmarshal(changes, &audit.Changes)
+		helper.PanicIfError(err)
+		audit.SekolahId = int(sekolahId.Int64)
+		audits = append(audits, audit)
+	}
+	return audits
+}
+
+// nullInt stores 0 as NULL.
+func nullInt(value int) interface{} {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type IdempotencyKeyRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, idempotencyKey domain.IdempotencyKey) error
+	Update(ctx context.Context, tx *sql.Tx, idempotencyKey domain.IdempotencyKey)
+	Delete(ctx context.Context, tx *sql.Tx, idempotencyKey domain.IdempotencyKey)
+	FindByKey(ctx context.Context, tx *sql.Tx, actor string, key string) (domain.IdempotencyKey, error)
+	DeleteExpired(ctx context.Context, tx *sql.Tx, createdBefore time.Time) int
+}
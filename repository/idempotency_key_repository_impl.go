@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type IdempotencyKeyRepositoryImpl struct {
+}
+
+func NewIdempotencyKeyRepository() IdempotencyKeyRepository {
+	return &IdempotencyKeyRepositoryImpl{}
+}
+
+// Save returns an error instead of panicking when the key already exists, as
+// that only means a concurrent retry got there first.
+func (c IdempotencyKeyRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, idempotencyKey domain.IdempotencyKey) error {
+	SQL := "insert into idempotency_key(actor, idempotency, fingerprint, created_at) values (?,?,?,?)"
+	_, err := tx.ExecContext(ctx, SQL, idempotencyKey.Actor, idempotencyKey.Key, idempotencyKey.Fingerprint, idempotencyKey.CreatedAt)
+	return err
+}
+
+func (c IdempotencyKeyRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, idempotencyKey domain.IdempotencyKey) {
+	headers, err := json.Marshal(idempotencyKey.Headers)
+	helper.PanicIfError(err)
+
+	SQL := "update idempotency_key set status_code = ?, content_type = ?, response_headers = ?, response_body = ? where actor = ? and idempotency = ?"
+	_, err = tx.ExecContext(ctx, SQL, idempotencyKey.StatusCode, idempotencyKey.ContentType, headers, idempotencyKey.ResponseBody, idempotencyKey.Actor, idempotencyKey.Key)
+	helper.PanicIfError(err)
+}
+
+func (c IdempotencyKeyRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, idempotencyKey domain.IdempotencyKey) {
+	SQL := "delete from idempotency_key where actor = ? and idempotency = ?"
+	_, err := tx.ExecContext(ctx, SQL, idempotencyKey.Actor, idempotencyKey.Key)
+	helper.PanicIfError(err)
+}
+
+func (c IdempotencyKeyRepositoryImpl) FindByKey(ctx context.Context, tx *sql.Tx, actor string, key string) (domain.IdempotencyKey, error) {
+	SQL := "select actor, idempotency, fingerprint, status_code, content_type, response_headers, response_body, created_at from idempotency_key where actor = ? and idempotency = ? for update"
+	rows, err := tx.QueryContext(ctx, SQL, actor, key)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	idempotencyKey := domain.IdempotencyKey{}
+	if rows.Next() {
+		var headers []byte
+		err := rows.Scan(&idempotencyKey.Actor, &idempotencyKey.Key, &idempotencyKey.Fingerprint, &idempotencyKey.StatusCode, &idempotencyKey.ContentType, &headers, &idempotencyKey.ResponseBody, &idempotencyKey.CreatedAt)
+		helper.PanicIfError(err)
+		// keys stored before response_headers existed have none
+		if headers != nil {
+			err = json.Unmarshal(headers, &idempotencyKey.Headers)
+			helper.PanicIfError(err)
+		}
+		return idempotencyKey, nil
+	} else {
+		return idempotencyKey, errors.New("idempotency key is not found")
+	}
+}
+
+func (c IdempotencyKeyRepositoryImpl) DeleteExpired(ctx context.Context, tx *sql.Tx, createdBefore time.Time) int {
+	SQL := "delete from idempotency_key where created_at < ?"
+	result, err := tx.ExecContext(ctx, SQL, createdBefore)
+	helper.PanicIfError(err)
+
+	affected, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	return int(affected)
+}
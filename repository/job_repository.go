@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type JobRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, job domain.Job) (domain.Job, bool)
+	Update(ctx context.Context, tx *sql.Tx, job domain.Job) domain.Job
+	Claim(ctx context.Context, tx *sql.Tx, worker string, now time.Time, staleBefore time.Time) (domain.Job, error)
+	FindById(ctx context.Context, tx *sql.Tx, jobId int64) (domain.Job, error)
+	FindAll(ctx context.Context, tx *sql.Tx, filter domain.JobFilter) []domain.Job
+	DeleteFinished(ctx context.Context, tx *sql.Tx, before time.Time) int
+}
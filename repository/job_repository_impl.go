@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type JobRepositoryImpl struct {
+}
+
+func NewJobRepository() JobRepository {
+	return &JobRepositoryImpl{}
+}
+
+const jobColumns = "id, name, payload, unique_key, status, attempts, max_attempts, run_at, locked_by, locked_at, last_error, created_at, started_at, finished_at"
+
+// Save reports false, saving nothing, when a job with the same UniqueKey
+// exists already.
+func (c JobRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, job domain.Job) (domain.Job, bool) {
+	var payload, uniqueKey interface{}
+	if job.Payload != nil {
+		payload = string(job.Payload)
+	}
+	if job.UniqueKey != "" {
+		uniqueKey = job.UniqueKey
+	}
+
+	SQL := "insert into job(name, payload, unique_key, status, max_attempts, run_at, created_at) values (?,?,?,?,?,?,?) on duplicate key update id = id"
+	result, err := tx.ExecContext(ctx, SQL, job.Name, payload, uniqueKey, job.Status, job.MaxAttempts, job.RunAt, job.CreatedAt)
+	helper.PanicIfError(err)
+
+	affected, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	if affected == 0 {
+		return job, false
+	}
+
+	id, err := result.LastInsertId()
+	helper.PanicIfError(err)
+
+	job.Id = id
+	return job, true
+}
+
+func (c JobRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, job domain.Job) domain.Job {
+	var lockedBy, lastError interface{}
+	if job.LockedBy != "" {
+		lockedBy = job.LockedBy
+	}
+	if job.LastError != "" {
+		lastError = job.LastError
+	}
+
+	SQL := "update job set status = ?, attempts = ?, run_at = ?, locked_by = ?, locked_at = ?, last_error = ?, started_at = ?, finished_at = ? where id = ?"
+	_, err := tx.ExecContext(ctx, SQL, job.Status, job.Attempts, job.RunAt, lockedBy, job.LockedAt, lastError, job.StartedAt, job.FinishedAt, job.Id)
+	helper.PanicIfError(err)
+
+	return job
+}
+
+// Claim takes the job due the longest, or one whose worker went away while
+// running it, and marks it running for worker. Rows locked by another
+// worker's claim are skipped rather than waited for.
+func (c JobRepositoryImpl) Claim(ctx context.Context, tx *sql.Tx, worker string, now time.Time, staleBefore time.Time) (domain.Job, error) {
+	SQL := "select " + jobColumns + " from job where (status = ? and run_at <= ?) or (status = ? and locked_at < ?) order by run_at, id limit 1 for update skip locked"
+	jobs := c.query(ctx, tx, SQL, domain.JobQueued, now, domain.JobRunning, staleBefore)
+	if len(jobs) == 0 {
+		return domain.Job{}, errors.New("no job is due")
+	}
+
+	job := jobs[0]
+	job.Status = domain.JobRunning
+	job.Attempts++
+	job.LockedBy = worker
+	job.LockedAt = &now
+	job.StartedAt = &now
+	job.FinishedAt = nil
+	return c.Update(ctx, tx, job), nil
+}
+
+func (c JobRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, jobId int64) (domain.Job, error) {
+	SQL := "select " + jobColumns + " from job where id = ?"
+	jobs := c.query(ctx, tx, SQL, jobId)
+	if len(jobs) == 0 {
+		return domain.Job{}, errors.New("job is not found")
+	}
+	return jobs[0], nil
+}
+
+// FindAll returns the latest jobs first.
+func (c JobRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, filter domain.JobFilter) []domain.Job {
+	SQL := "select " + jobColumns + " from job where 1 = 1"
+	var args []interface{}
+	if filter.Name != "" {
+		SQL += " and name = ?"
+		args = append(args, filter.Name)
+	}
+	if filter.Status != "" {
+		SQL += " and status = ?"
+		args = append(args, filter.Status)
+	}
+	SQL += " order by id desc limit ?"
+	args = append(args, filter.Limit)
+
+	return c.query(ctx, tx, SQL, args...)
+}
+
+func (c JobRepositoryImpl) DeleteFinished(ctx context.Context, tx *sql.Tx, before time.Time) int {
+	SQL := "delete from job where status in (?, ?) and finished_at < ?"
+	result, err := tx.ExecContext(ctx, SQL, domain.JobSucceeded, domain.JobFailed, before)
+	helper.PanicIfError(err)
+
+	deleted, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	return int(deleted)
+}
+
+func (c JobRepositoryImpl) query(ctx context.Context, tx *sql.Tx, SQL string, args ...interface{}) []domain.Job {
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var jobs []domain.Job
+	for rows.Next() {
+		job := domain.Job{}
+		var uniqueKey, lockedBy, lastError sql.NullString
+		var lockedAt, startedAt, finishedAt sql.NullTime
+		err := rows.Scan(&job.Id, &job.Name, &job.Payload, &uniqueKey, &job.Status, &job.Attempts, &job.MaxAttempts, &job.RunAt, &lockedBy, &lockedAt, &lastError, &job.CreatedAt, &startedAt, &finishedAt)
+		helper.PanicIfError(err)
+
+		job.UniqueKey = uniqueKey.String
+		job.LockedBy = lockedBy.String
+		job.LastError = lastError.String
+		if lockedAt.Valid {
+			job.LockedAt = &lockedAt.Time
+		}
+		if startedAt.Valid {
+			job.StartedAt = &startedAt.Time
+		}
+		if finishedAt.Valid {
+			job.FinishedAt = &finishedAt.Time
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+type MigrationRepository interface {
+	FindCurrent(ctx context.Context, tx *sql.Tx) (domain.Migration, error)
+}
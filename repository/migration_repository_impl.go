@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+type MigrationRepositoryImpl struct {
+}
+
+func NewMigrationRepository() MigrationRepository {
+	return &MigrationRepositoryImpl{}
+}
+
+// FindCurrent reads the schema_migrations table maintained by golang-migrate.
+// Errors are returned rather than panicking as a missing table simply means
+// no migration has been applied yet.
+func (c MigrationRepositoryImpl) FindCurrent(ctx context.Context, tx *sql.Tx) (domain.Migration, error) {
+	SQL := "select version, dirty from schema_migrations limit 1"
+	rows, err := tx.QueryContext(ctx, SQL)
+	if err != nil {
+		return domain.Migration{}, err
+	}
+	defer rows.Close()
+
+	migration := domain.Migration{}
+	if rows.Next() {
+		err := rows.Scan(&migration.Version, &migration.Dirty)
+		return migration, err
+	} else {
+		return migration, errors.New("no migration has been applied")
+	}
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+type NotificationPreferenceRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, preference domain.NotificationPreference) domain.NotificationPreference
+	FindBySiswaId(ctx context.Context, tx *sql.Tx, siswaId int) (domain.NotificationPreference, error)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+type NotificationPreferenceRepositoryImpl struct {
+}
+
+func NewNotificationPreferenceRepository() NotificationPreferenceRepository {
+	return &NotificationPreferenceRepositoryImpl{}
+}
+
+// Save inserts the preference of the siswa or replaces it.
+func (c NotificationPreferenceRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, preference domain.NotificationPreference) domain.NotificationPreference {
+	channels, err := json.Marshal(preference.Channels)
+	helper.PanicIfError(err)
+
+	SQL := "insert into notification_preference(siswa_id, nama_wali, email, no_telepon, no_whatsapp, channels, language, updated_at) values (?,?,?,?,?,?,?,?)" +
+		" on duplicate key update nama_wali = values(nama_wali), email = values(email), no_telepon = values(no_telepon), no_whatsapp = values(no_whatsapp), channels = values(channels), language = values(language), updated_at = values(updated_at)"
+	_, err = tx.ExecContext(ctx, SQL, preference.SiswaId, preference.NamaWali, nullString(preference.Email), nullString(preference.NoTelepon), nullString(preference.NoWhatsapp), string(channels), preference.Language, preference.UpdatedAt)
+	helper.PanicIfError(err)
+
+	return preference
+}
+
+func (c NotificationPreferenceRepositoryImpl) FindBySiswaId(ctx context.Context, tx *sql.Tx, siswaId int) (domain.NotificationPreference, error) {
+	SQL := "select siswa_id, nama_wali, email, no_telepon, no_whatsapp, channels, language, updated_at from notification_preference where siswa_id = ?"
+	rows, err := tx.QueryContext(ctx, SQL, siswaId)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	preference := domain.NotificationPreference{}
+	if !rows.Next() {
+		return preference, errors.New("notification preference is not found")
+	}
+
+	var email, noTelepon, noWhatsapp sql.NullString
+	var channels []byte
+	err = rows.Scan(&preference.SiswaId, &preference.NamaWali, &email, &noTelepon, &noWhatsapp, &channels, &preference.Language, &preference.UpdatedAt)
+	helper.PanicIfError(err)
+
+	err = json.Unmarshal(channels, &preference.Channels)
+	helper.PanicIfError(err)
+	preference.Email = email.String
+	preference.NoTelepon = noTelepon.String
+	preference.NoWhatsapp = noWhatsapp.String
+	return preference, nil
+}
+
+// nullString stores an empty string as NULL.
+func nullString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+type NotificationRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, notification domain.Notification) domain.Notification
+	Update(ctx context.Context, tx *sql.Tx, notification domain.Notification) domain.Notification
+	FindById(ctx context.Context, tx *sql.Tx, notificationId int64) (domain.Notification, error)
+	FindAll(ctx context.Context, tx *sql.Tx, filter domain.NotificationFilter) []domain.Notification
+}
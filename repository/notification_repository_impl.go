@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+type NotificationRepositoryImpl struct {
+}
+
+func NewNotificationRepository() NotificationRepository {
+	return &NotificationRepositoryImpl{}
+}
+
+const notificationColumns = "id, siswa_id, template, language, subject, body, routes, status, channel, recipient, attempts, last_error, created_by, created_at, sent_at"
+
+func (c NotificationRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, notification domain.Notification) domain.Notification {
+	routes, err := json.Marshal(notification.Routes)
+	helper.PanicIfError(err)
+
+	SQL := "insert into notification(siswa_id, template, language, subject, body, routes, status, created_by, created_at) values (?,?,?,?,?,?,?,?,?)"
+	result, err := tx.ExecContext(ctx, SQL, notification.SiswaId, notification.Template, notification.Language, notification.Subject, notification.Body, string(routes), notification.Status, notification.CreatedBy, notification.CreatedAt)
+	helper.PanicIfError(err)
+
+	id, err := result.LastInsertId()
+	helper.PanicIfError(err)
+
+	notification.Id = id
+	return notification
+}
+
+func (c NotificationRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, notification domain.Notification) domain.Notification {
+	SQL := "update notification set status = ?, channel = ?, recipient = ?, attempts = ?, last_error = ?, sent_at = ? where id = ?"
+	_, err := tx.ExecContext(ctx, SQL, notification.Status, nullString(notification.Channel), nullString(notification.Recipient), notification.Attempts, nullString(notification.LastError), notification.SentAt, notification.Id)
+	helper.PanicIfError(err)
+
+	return notification
+}
+
+func (c NotificationRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, notificationId int64) (domain.Notification, error) {
+	SQL := "select " + notificationColumns + " from notification where id = ?"
+	notifications := c.query(ctx, tx, SQL, notificationId)
+	if len(notifications) == 0 {
+		return domain.Notification{}, errors.New("notification is not found")
+	}
+	return notifications[0], nil
+}
+
+// FindAll returns the latest notifications first.
+func (c NotificationRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, filter domain.NotificationFilter) []domain.Notification {
+	SQL := "select " + notificationColumns + " from notification where 1 = 1"
+	var args []interface{}
+	if filter.SekolahId != 0 {
+		SQL += " and siswa_id in (select id from siswa where sekolah_id = ?)"
+		args = append(args, filter.SekolahId)
+	}
+	if filter.SiswaId != 0 {
+		SQL += " and siswa_id = ?"
+		args = append(args, filter.SiswaId)
+	}
+	if filter.Status != "" {
+		SQL += " and status = ?"
+		args = append(args, filter.Status)
+	}
+	SQL += " order by id desc limit ?"
+	args = append(args, filter.Limit)
+
+	return c.query(ctx, tx, SQL, args...)
+}
+
+func (c NotificationRepositoryImpl) query(ctx context.Context, tx *sql.Tx, SQL string, args ...interface{}) []domain.Notification {
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var notifications []domain.Notification
+	for rows.Next() {
+		notification := domain.Notification{}
+		var routes []byte
+		var channel, recipient, lastError sql.NullString
+		var sentAt sql.NullTime
+		err := rows.Scan(&notification.Id, &notification.SiswaId, &notification.Template, &notification.Language, &notification.Subject, &notification.Body, &routes, &notification.Status, &channel, &recipient, &notification.Attempts, &lastError, &notification.CreatedBy, &notification.CreatedAt, &sentAt)
+		helper.PanicIfError(err)
+
+		err = json.Unmarshal(routes, &notification.Routes)
+		helper.PanicIfError(err)
+		notification.Channel = channel.String
+		notification.Recipient = recipient.String
+		notification.LastError = lastError.String
+		if sentAt.Valid {
+			notification.SentAt = &sentAt.Time
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type OutboxEventRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, event domain.OutboxEvent) domain.OutboxEvent
+	FindPending(ctx context.Context, tx *sql.Tx, now time.Time, limit int) []domain.OutboxEvent
+	MarkPublished(ctx context.Context, tx *sql.Tx, eventId int64, publishedAt time.Time)
+	MarkFailed(ctx context.Context, tx *sql.Tx, eventId int64, lastError string, nextAttemptAt time.Time)
+	MarkDead(ctx context.Context, tx *sql.Tx, eventId int64, lastError string, deadAt time.Time)
+	DeletePublished(ctx context.Context, tx *sql.Tx, publishedBefore time.Time) int
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type OutboxEventRepositoryImpl struct {
+}
+
+func NewOutboxEventRepository() OutboxEventRepository {
+	return &OutboxEventRepositoryImpl{}
+}
+
+func (c OutboxEventRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, event domain.OutboxEvent) domain.OutboxEvent {
+	SQL := "insert into outbox_event(aggregate_type, aggregate_id, event_type, payload, actor, request_id, created_at) values (?,?,?,?,?,?,?)"
+	result, err := tx.ExecContext(ctx, SQL, event.AggregateType, event.AggregateId, event.EventType, string(event.Payload), event.Actor, event.RequestId, event.CreatedAt)
+	helper.PanicIfError(err)
+
+	id, err := result.LastInsertId()
+	helper.PanicIfError(err)
+
+	event.Id = id
+	return event
+}
+
+// FindPending returns the oldest events due at now first. An event waiting
+// for its next attempt holds back the later events of its aggregate, so they
+// are left out too; dead events hold back nothing.
+func (c OutboxEventRepositoryImpl) FindPending(ctx context.Context, tx *sql.Tx, now time.Time, limit int) []domain.OutboxEvent {
+	SQL := "select id, aggregate_type, aggregate_id, event_type, payload, actor, request_id, created_at, attempts, last_error, next_attempt_at from outbox_event e " +
+		"where published_at is null and dead_at is null and (next_attempt_at is null or next_attempt_at <= ?) " +
+		"and not exists (select 1 from outbox_event w where w.aggregate_type = e.aggregate_type and w.aggregate_id = e.aggregate_id and w.id < e.id " +
+		"and w.published_at is null and w.dead_at is null and w.next_attempt_at > ?) " +
+		"order by id limit ?"
+	rows, err := tx.QueryContext(ctx, SQL, now, now, limit)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var events []domain.OutboxEvent
+	for rows.Next() {
+		event := domain.OutboxEvent{}
+		var lastError sql.NullString
+		var nextAttemptAt sql.NullTime
+		err := rows.Scan(&event.Id, &event.AggregateType, &event.AggregateId, &event.EventType, &event.Payload, &event.Actor, &event.RequestId, &event.CreatedAt, &event.Attempts, &lastError, &nextAttemptAt)
+		helper.PanicIfError(err)
+		event.LastError = lastError.String
+		if nextAttemptAt.Valid {
+			event.NextAttemptAt = &nextAttemptAt.Time
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func (c OutboxEventRepositoryImpl) MarkPublished(ctx context.Context, tx *sql.Tx, eventId int64, publishedAt time.Time) {
+	SQL := "update outbox_event set published_at = ?, attempts = attempts + 1, next_attempt_at = null where id = ?"
+	_, err := tx.ExecContext(ctx, SQL, publishedAt, eventId)
+	helper.PanicIfError(err)
+}
+
+func (c OutboxEventRepositoryImpl) MarkFailed(ctx context.Context, tx *sql.Tx, eventId int64, lastError string, nextAttemptAt time.Time) {
+	SQL := "update outbox_event set attempts = attempts + 1, last_error = ?, next_attempt_at = ? where id = ?"
+	_, err := tx.ExecContext(ctx, SQL, lastError, nextAttemptAt, eventId)
+	helper.PanicIfError(err)
+}
+
+// MarkDead gives up on the event, which stays in the table for inspection
+// until dead_at is cleared to retry it.
+func (c OutboxEventRepositoryImpl) MarkDead(ctx context.Context, tx *sql.Tx, eventId int64, lastError string, deadAt time.Time) {
+	SQL := "update outbox_event set attempts = attempts + 1, last_error = ?, next_attempt_at = null, dead_at = ? where id = ?"
+	_, err := tx.ExecContext(ctx, SQL, lastError, deadAt, eventId)
+	helper.PanicIfError(err)
+}
+
+func (c OutboxEventRepositoryImpl) DeletePublished(ctx context.Context, tx *sql.Tx, publishedBefore time.Time) int {
+	SQL := "delete from outbox_event where published_at < ?"
+	result, err := tx.ExecContext(ctx, SQL, publishedBefore)
+	helper.PanicIfError(err)
+
+	affected, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	return int(affected)
+}
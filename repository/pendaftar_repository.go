@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type PendaftarRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, pendaftar domain.Pendaftar) domain.Pendaftar
+	Update(ctx context.Context, tx *sql.Tx, pendaftar domain.Pendaftar, status string) (domain.Pendaftar, error)
+	FindById(ctx context.Context, tx *sql.Tx, pendaftarId int) (domain.Pendaftar, error)
+	FindAll(ctx context.Context, tx *sql.Tx, filter domain.PendaftarFilter) []domain.Pendaftar
+	FindAnnounced(ctx context.Context, tx *sql.Tx) []domain.Pendaftar
+	CountAccepted(ctx context.Context, tx *sql.Tx) map[string]int
+	Announce(ctx context.Context, tx *sql.Tx, now time.Time) []domain.Pendaftar
+	SaveDokumen(ctx context.Context, tx *sql.Tx, dokumen domain.Dokumen) domain.Dokumen
+	FindDokumen(ctx context.Context, tx *sql.Tx, pendaftarId int, jenis string) (domain.Dokumen, error)
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"strings"
+	"time"
+)
+
+type PendaftarRepositoryImpl struct {
+}
+
+func NewPendaftarRepository() PendaftarRepository {
+	return &PendaftarRepositoryImpl{}
+}
+
+const pendaftarColumns = "id, sekolah_id, token_hash, jalur, status, nama, alamat, tanggal_lahir, tempat_lahir, jenis_kelamin, agama, golongan_darah, no_telepon, asal_sekolah, jarak, nilai, peringkat, catatan, siswa_id, created_at, updated_at, announced_at"
+
+func (c PendaftarRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, pendaftar domain.Pendaftar) domain.Pendaftar {
+	sekolah := sekolahId(ctx, "pendaftar")
+	SQL := "insert into pendaftar(sekolah_id, token_hash, jalur, status, nama, alamat, tanggal_lahir, tempat_lahir, jenis_kelamin, agama, golongan_darah, no_telepon, asal_sekolah, jarak, created_at, updated_at) values (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+	result, err := tx.ExecContext(ctx, SQL, sekolah, pendaftar.TokenHash, pendaftar.Jalur, pendaftar.Status, pendaftar.Nama, pendaftar.Alamat, pendaftar.TanggalLahir, pendaftar.TempatLahir, pendaftar.JenisKelamin, pendaftar.Agama, pendaftar.GolonganDarah, pendaftar.NoTelepon, pendaftar.AsalSekolah, pendaftar.Jarak, pendaftar.CreatedAt, pendaftar.UpdatedAt)
+	helper.PanicIfError(err)
+
+	id, err := result.LastInsertId()
+	helper.PanicIfError(err)
+
+	pendaftar.Id = int(id)
+	pendaftar.SekolahId = sekolah
+	return pendaftar
+}
+
+// Update saves the progress of a pendaftar that is still in status, and
+// returns an error when another request moved it on in the meantime.
+func (c PendaftarRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, pendaftar domain.Pendaftar, status string) (domain.Pendaftar, error) {
+	SQL := "update pendaftar set status = ?, jarak = ?, nilai = ?, peringkat = ?, catatan = ?, siswa_id = ?, updated_at = ? where id = ? and sekolah_id = ? and status = ?"
+	result, err := tx.ExecContext(ctx, SQL, pendaftar.Status, pendaftar.Jarak, pendaftar.Nilai, nullInt(pendaftar.Peringkat), nullString(pendaftar.Catatan), nullInt(pendaftar.SiswaId), pendaftar.UpdatedAt, pendaftar.Id, sekolahId(ctx, "pendaftar"), status)
+	helper.PanicIfError(err)
+
+	affected, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	if affected == 0 {
+		return pendaftar, errors.New("pendaftar has been modified")
+	}
+	return pendaftar, nil
+}
+
+// FindById returns the pendaftar with its documents, but not their files.
+func (c PendaftarRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, pendaftarId int) (domain.Pendaftar, error) {
+	SQL := "select " + pendaftarColumns + " from pendaftar where id = ? and sekolah_id = ?"
+	pendaftars := c.query(ctx, tx, SQL, pendaftarId, sekolahId(ctx, "pendaftar"))
+	if len(pendaftars) == 0 {
+		return domain.Pendaftar{}, errors.New("pendaftar is not found")
+	}
+
+	pendaftar := pendaftars[0]
+	SQL = "select pendaftar_id, jenis, content_type, ukuran, uploaded_at from pendaftar_dokumen where pendaftar_id = ? order by jenis"
+	rows, err := tx.QueryContext(ctx, SQL, pendaftar.Id)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	for rows.Next() {
+		dokumen := domain.Dokumen{}
+		err := rows.Scan(&dokumen.PendaftarId, &dokumen.Jenis, &dokumen.ContentType, &dokumen.Ukuran, &dokumen.UploadedAt)
+		helper.PanicIfError(err)
+		pendaftar.Dokumen = append(pendaftar.Dokumen, dokumen)
+	}
+	return pendaftar, nil
+}
+
+func (c PendaftarRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, filter domain.PendaftarFilter) []domain.Pendaftar {
+	SQL := "select " + pendaftarColumns + " from pendaftar where sekolah_id = ?"
+	args := []interface{}{sekolahId(ctx, "pendaftar")}
+	if filter.Jalur != "" {
+		SQL += " and jalur = ?"
+		args = append(args, filter.Jalur)
+	}
+	if filter.Status != "" {
+		SQL += " and status = ?"
+		args = append(args, filter.Status)
+	}
+	SQL += " order by id"
+
+	return c.query(ctx, tx, SQL, args...)
+}
+
+// FindAnnounced returns who was admitted, by jalur and rank.
+func (c PendaftarRepositoryImpl) FindAnnounced(ctx context.Context, tx *sql.Tx) []domain.Pendaftar {
+	SQL := "select " + pendaftarColumns + " from pendaftar where sekolah_id = ? and announced_at is not null and status in (?, ?) order by jalur, peringkat"
+	return c.query(ctx, tx, SQL, sekolahId(ctx, "pendaftar"), domain.PendaftarAccepted, domain.PendaftarReRegistered)
+}
+
+// CountAccepted returns the seats taken per jalur by earlier selections.
+func (c PendaftarRepositoryImpl) CountAccepted(ctx context.Context, tx *sql.Tx) map[string]int {
+	SQL := "select jalur, count(*) from pendaftar where sekolah_id = ? and status in (?, ?) group by jalur"
+	rows, err := tx.QueryContext(ctx, SQL, sekolahId(ctx, "pendaftar"), domain.PendaftarAccepted, domain.PendaftarReRegistered)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var jalur string
+		var count int
+		err := rows.Scan(&jalur, &count)
+		helper.PanicIfError(err)
+		counts[jalur] = count
+	}
+	return counts
+}
+
+// Announce publishes every decision that is not public yet and returns the
+// pendaftar it did so for.
+func (c PendaftarRepositoryImpl) Announce(ctx context.Context, tx *sql.Tx, now time.Time) []domain.Pendaftar {
+	SQL := "select " + pendaftarColumns + " from pendaftar where sekolah_id = ? and announced_at is null and status in (?, ?) order by jalur, peringkat for update"
+	pendaftars := c.query(ctx, tx, SQL, sekolahId(ctx, "pendaftar"), domain.PendaftarAccepted, domain.PendaftarRejected)
+	if len(pendaftars) == 0 {
+		return nil
+	}
+
+	args := []interface{}{now}
+	for i := range pendaftars {
+		pendaftars[i].AnnouncedAt = &now
+		args = append(args, pendaftars[i].Id)
+	}
+	SQL = "update pendaftar set announced_at = ? where id in (?" + strings.Repeat(", ?", len(pendaftars)-1) + ")"
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	helper.PanicIfError(err)
+
+	return pendaftars
+}
+
+// SaveDokumen replaces the file of the same jenis, if any.
+func (c PendaftarRepositoryImpl) SaveDokumen(ctx context.Context, tx *sql.Tx, dokumen domain.Dokumen) domain.Dokumen {
+	SQL := "insert into pendaftar_dokumen(pendaftar_id, jenis, content_type, ukuran, isi, uploaded_at) values (?,?,?,?,?,?) on duplicate key update content_type = values(content_type), ukuran = values(ukuran), isi = values(isi), uploaded_at = values(uploaded_at)"
+	_, err := tx.ExecContext(ctx, SQL, dokumen.PendaftarId, dokumen.Jenis, dokumen.ContentType, dokumen.Ukuran, dokumen.Isi, dokumen.UploadedAt)
+	helper.PanicIfError(err)
+
+	return dokumen
+}
+
+func (c PendaftarRepositoryImpl) FindDokumen(ctx context.Context, tx *sql.Tx, pendaftarId int, jenis string) (domain.Dokumen, error) {
+	SQL := "select d.pendaftar_id, d.jenis, d.content_type, d.ukuran, d.isi, d.uploaded_at from pendaftar_dokumen d join pendaftar p on p.id = d.pendaftar_id where d.pendaftar_id = ? and d.jenis = ? and p.sekolah_id = ?"
+	rows, err := tx.QueryContext(ctx, SQL, pendaftarId, jenis, sekolahId(ctx, "pendaftar"))
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	if !rows.Next() {
+		return domain.Dokumen{}, errors.New("dokumen " + jenis + " is not found")
+	}
+	dokumen := domain.Dokumen{}
+	err = rows.Scan(&dokumen.PendaftarId, &dokumen.Jenis, &dokumen.ContentType, &dokumen.Ukuran, &dokumen.Isi, &dokumen.UploadedAt)
+	helper.PanicIfError(err)
+	return dokumen, nil
+}
+
+func (c PendaftarRepositoryImpl) query(ctx context.Context, tx *sql.Tx, SQL string, args ...interface{}) []domain.Pendaftar {
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var pendaftars []domain.Pendaftar
+	for rows.Next() {
+		pendaftar := domain.Pendaftar{}
+		var nilai sql.NullFloat64
+		var peringkat, siswaId sql.NullInt64
+		var catatan sql.NullString
+		var announcedAt sql.NullTime
+		err := rows.Scan(&pendaftar.Id, &pendaftar.SekolahId, &pendaftar.TokenHash, &pendaftar.Jalur, &pendaftar.Status, &pendaftar.Nama, &pendaftar.Alamat, &pendaftar.TanggalLahir, &pendaftar.TempatLahir, &pendaftar.JenisKelamin, &pendaftar.Agama, &pendaftar.GolonganDarah, &pendaftar.NoTelepon, &pendaftar.AsalSekolah, &pendaftar.Jarak, &nilai, &peringkat, &catatan, &siswaId, &pendaftar.CreatedAt, &pendaftar.UpdatedAt, &announcedAt)
+		helper.PanicIfError(err)
+
+		if nilai.Valid {
+			pendaftar.Nilai = &nilai.Float64
+		}
+		pendaftar.Peringkat = int(peringkat.Int64)
+		pendaftar.Catatan = catatan.String
+		pendaftar.SiswaId = int(siswaId.Int64)
+		if announcedAt.Valid {
+			pendaftar.AnnouncedAt = &announcedAt.Time
+		}
+		pendaftars = append(pendaftars, pendaftar)
+	}
+	return pendaftars
+}
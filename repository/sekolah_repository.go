@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+type SekolahRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, sekolah domain.Sekolah) (domain.Sekolah, error)
+	Update(ctx context.Context, tx *sql.Tx, sekolah domain.Sekolah) domain.Sekolah
+	FindById(ctx context.Context, tx *sql.Tx, sekolahId int) (domain.Sekolah, error)
+	FindByKode(ctx context.Context, tx *sql.Tx, kode string) (domain.Sekolah, error)
+	FindAll(ctx context.Context, tx *sql.Tx) []domain.Sekolah
+}
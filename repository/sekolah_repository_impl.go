@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+type SekolahRepositoryImpl struct {
+}
+
+func NewSekolahRepository() SekolahRepository {
+	return &SekolahRepositoryImpl{}
+}
+
+const sekolahColumns = "id, kode, nama, bahasa, kuota_siswa, kuota_zonasi, kuota_prestasi, kuota_afirmasi, created_at, updated_at"
+
+// Save returns an error instead of panicking when the kode is taken.
+func (c SekolahRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, sekolah domain.Sekolah) (domain.Sekolah, error) {
+	SQL := "insert into sekolah(kode, nama, bahasa, kuota_siswa, kuota_zonasi, kuota_prestasi, kuota_afirmasi, created_at) values (?,?,?,?,?,?,?,?)"
+	result, err := tx.ExecContext(ctx, SQL, sekolah.Kode, sekolah.Nama, sekolah.Bahasa, sekolah.KuotaSiswa, sekolah.KuotaZonasi, sekolah.KuotaPrestasi, sekolah.KuotaAfirmasi, sekolah.CreatedAt)
+	if err != nil {
+		return sekolah, err
+	}
+
+	id, err := result.LastInsertId()
+	helper.PanicIfError(err)
+
+	sekolah.Id = int(id)
+	return sekolah, nil
+}
+
+func (c SekolahRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, sekolah domain.Sekolah) domain.Sekolah {
+	SQL := "update sekolah set nama = ?, bahasa = ?, kuota_siswa = ?, kuota_zonasi = ?, kuota_prestasi = ?, kuota_afirmasi = ?, updated_at = ? where id = ?"
+	_, err := tx.ExecContext(ctx, SQL, sekolah.Nama, sekolah.Bahasa, sekolah.KuotaSiswa, sekolah.KuotaZonasi, sekolah.KuotaPrestasi, sekolah.KuotaAfirmasi, sekolah.UpdatedAt, sekolah.Id)
+	helper.PanicIfError(err)
+
+	return sekolah
+}
+
+func (c SekolahRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, sekolahId int) (domain.Sekolah, error) {
+	SQL := "select " + sekolahColumns + " from sekolah where id = ?"
+	sekolahs := c.query(ctx, tx, SQL, sekolahId)
+	if len(sekolahs) == 0 {
+		return domain.Sekolah{}, errors.New("sekolah is not found")
+	}
+	return sekolahs[0], nil
+}
+
+func (c SekolahRepositoryImpl) FindByKode(ctx context.Context, tx *sql.Tx, kode string) (domain.Sekolah, error) {
+	SQL := "select " + sekolahColumns + " from sekolah where kode = ?"
+	sekolahs := c.query(ctx, tx, SQL, kode)
+	if len(sekolahs) == 0 {
+		return domain.Sekolah{}, errors.New("sekolah " + kode + " is not found")
+	}
+	return sekolahs[0], nil
+}
+
+func (c SekolahRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.Sekolah {
+	SQL := "select " + sekolahColumns + " from sekolah order by kode"
+	return c.query(ctx, tx, SQL)
+}
+
+func (c SekolahRepositoryImpl) query(ctx context.Context, tx *sql.Tx, SQL string, args ...interface{}) []domain.Sekolah {
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var sekolahs []domain.Sekolah
+	for rows.Next() {
+		sekolah := domain.Sekolah{}
+		var updatedAt sql.NullTime
+		err := rows.Scan(&sekolah.Id, &sekolah.Kode, &sekolah.Nama, &sekolah.Bahasa, &sekolah.KuotaSiswa, &sekolah.KuotaZonasi, &sekolah.KuotaPrestasi, &sekolah.KuotaAfirmasi, &sekolah.CreatedAt, &updatedAt)
+		helper.PanicIfError(err)
+
+		if updatedAt.Valid {
+			sekolah.UpdatedAt = &updatedAt.Time
+		}
+		sekolahs = append(sekolahs, sekolah)
+	}
+	return sekolahs
+}
@@ -4,12 +4,23 @@ import (
 	"context"
 	"database/sql"
 	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
 )
 
 type SiswaRepository interface {
 	Save(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) domain.Siswa
-	Update(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) domain.Siswa
-	Delete(ctx context.Context, tx *sql.Tx, siswa domain.Siswa)
+	Update(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) (domain.Siswa, error)
+	Delete(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) error
 	FindById(ctx context.Context, tx *sql.Tx, siswaId int) (domain.Siswa, error)
+	FindByIds(ctx context.Context, tx *sql.Tx, siswaIds []int) []domain.Siswa
 	FindAll(ctx context.Context, tx *sql.Tx) []domain.Siswa
+	Search(ctx context.Context, tx *sql.Tx, filter domain.SiswaFilter) []domain.Siswa
+	Count(ctx context.Context, tx *sql.Tx, filter domain.SiswaFilter) int
+	FindTrashById(ctx context.Context, tx *sql.Tx, siswaId int) (domain.Siswa, error)
+	FindTrash(ctx context.Context, tx *sql.Tx) []domain.Siswa
+	Restore(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) domain.Siswa
+	FindTrashBefore(ctx context.Context, tx *sql.Tx, deletedBefore time.Time) []domain.Siswa
+	Purge(ctx context.Context, tx *sql.Tx, siswa domain.Siswa)
+	CountByJenisKelamin(ctx context.Context, tx *sql.Tx) map[string]int
+	CountTrash(ctx context.Context, tx *sql.Tx) int
 }
@@ -6,8 +6,13 @@ import (
 	"errors"
 	"github.com/Arraf18/go-sisko/helper"
 	"github.com/Arraf18/go-sisko/model/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"strings"
+	"time"
 )
 
+const siswaColumns = "id, sekolah_id, nama, alamat, tanggal_lahir, tempat_lahir, jenis_kelamin, agama, golongan_darah, no_telepon, version, deleted_at, deleted_by"
+
 type SiswaRepositoryImpl struct {
 }
 
@@ -16,59 +21,299 @@ func NewSiswaRepository() SiswaRepository {
 }
 
 func (c SiswaRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) domain.Siswa {
-	SQL := "insert into siswa(nama, alamat, tanggal_lahir, tempat_lahir, jenis_kelamin, agama, golongan_darah, no_telepon) values (?,?,?,?,?,?,?,?)"
-	result, err := tx.ExecContext(ctx, SQL, siswa.Nama, siswa.Alamat, siswa.TanggalLahir, siswa.TempatLahir, siswa.JenisKelamin, siswa.Agama, siswa.GolonganDarah, siswa.NoTelepon)
+	sekolah := sekolahId(ctx, "siswa")
+	SQL := "insert into siswa(sekolah_id, nama, alamat, tanggal_lahir, tempat_lahir, jenis_kelamin, agama, golongan_darah, no_telepon, version) values (?,?,?,?,?,?,?,?,?,1)"
+	ctx, end := traceStatement(ctx, "SiswaRepository.Save", SQL)
+	defer end()
+	result, err := tx.ExecContext(ctx, SQL, sekolah, siswa.Nama, siswa.Alamat, siswa.TanggalLahir, siswa.TempatLahir, siswa.JenisKelamin, siswa.Agama, siswa.GolonganDarah, siswa.NoTelepon)
 	helper.PanicIfError(err)
 
 	id, err := result.LastInsertId()
 	helper.PanicIfError(err)
 
 	siswa.Id = int(id)
+	siswa.SekolahId = sekolah
+	siswa.Version = 1
 	return siswa
 }
 
-func (c SiswaRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) domain.Siswa {
-	SQL := "update siswa set nama = ?, alamat = ?, tanggal_lahir = ?, tempat_lahir = ?, jenis_kelamin = ?, agama = ?, golongan_darah = ?, no_telepon = ? where id = ?"
-	_, err := tx.ExecContext(ctx, SQL, siswa.Nama, siswa.Alamat, siswa.TanggalLahir, siswa.TempatLahir, siswa.JenisKelamin, siswa.Agama, siswa.GolonganDarah, siswa.NoTelepon, siswa.Id)
+func (c SiswaRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) (domain.Siswa, error) {
+	SQL := "update siswa set nama = ?, alamat = ?, tanggal_lahir = ?, tempat_lahir = ?, jenis_kelamin = ?, agama = ?, golongan_darah = ?, no_telepon = ?, version = version + 1 where id = ? and sekolah_id = ? and version = ? and deleted_at is null"
+	ctx, end := traceStatement(ctx, "SiswaRepository.Update", SQL)
+	defer end()
+	result, err := tx.ExecContext(ctx, SQL, siswa.Nama, siswa.Alamat, siswa.TanggalLahir, siswa.TempatLahir, siswa.JenisKelamin, siswa.Agama, siswa.GolonganDarah, siswa.NoTelepon, siswa.Id, sekolahId(ctx, "siswa"), siswa.Version)
 	helper.PanicIfError(err)
 
-	return siswa
+	affected, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	if affected == 0 {
+		return siswa, errors.New("siswa has been modified")
+	}
+
+	siswa.Version++
+	return siswa, nil
 }
 
-func (c SiswaRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) {
-	SQL := "delete from siswa where id = ?"
-	_, err := tx.ExecContext(ctx, SQL, siswa.Id)
+func (c SiswaRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) error {
+	SQL := "update siswa set deleted_at = ?, deleted_by = ?, version = version + 1 where id = ? and sekolah_id = ? and version = ? and deleted_at is null"
+	ctx, end := traceStatement(ctx, "SiswaRepository.Delete", SQL)
+	defer end()
+	result, err := tx.ExecContext(ctx, SQL, siswa.DeletedAt, siswa.DeletedBy, siswa.Id, sekolahId(ctx, "siswa"), siswa.Version)
+	helper.PanicIfError(err)
+
+	affected, err := result.RowsAffected()
 	helper.PanicIfError(err)
+	if affected == 0 {
+		return errors.New("siswa has been modified")
+	}
+	return nil
 }
 
 func (c SiswaRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, siswaId int) (domain.Siswa, error) {
-	SQL := "select id, nama, alamat, tanggal_lahir, tempat_lahir, jenis_kelamin, agama, golongan_darah, no_telepon from siswa where id = ?"
-	rows, err := tx.QueryContext(ctx, SQL, siswaId)
+	SQL := "select " + siswaColumns + " from siswa where id = ? and sekolah_id = ? and deleted_at is null"
+	ctx, end := traceStatement(ctx, "SiswaRepository.FindById", SQL)
+	defer end()
+	rows, err := tx.QueryContext(ctx, SQL, siswaId, sekolahId(ctx, "siswa"))
 	helper.PanicIfError(err)
 	defer rows.Close()
 
-	siswa := domain.Siswa{}
 	if rows.Next() {
-		err := rows.Scan(&siswa.Id, &siswa.Nama, &siswa.Alamat, &siswa.TanggalLahir, &siswa.TempatLahir, &siswa.JenisKelamin, &siswa.Agama, &siswa.GolonganDarah, &siswa.NoTelepon)
-		helper.PanicIfError(err)
-		return siswa, nil
+		return scanSiswa(rows), nil
 	} else {
-		return siswa, errors.New("siswa is not found")
+		return domain.Siswa{}, errors.New("siswa is not found")
+	}
+}
+
+// FindByIds returns the active siswa among siswaIds with one query, in no
+// particular order.
+func (c SiswaRepositoryImpl) FindByIds(ctx context.Context, tx *sql.Tx, siswaIds []int) []domain.Siswa {
+	if len(siswaIds) == 0 {
+		return nil
 	}
+
+	args := []interface{}{sekolahId(ctx, "siswa")}
+	for _, siswaId := range siswaIds {
+		args = append(args, siswaId)
+	}
+	SQL := "select " + siswaColumns + " from siswa where sekolah_id = ? and id in (?" + strings.Repeat(", ?", len(siswaIds)-1) + ") and deleted_at is null"
+	ctx, end := traceStatement(ctx, "SiswaRepository.FindByIds", SQL)
+	defer end()
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var siswas []domain.Siswa
+	for rows.Next() {
+		siswas = append(siswas, scanSiswa(rows))
+	}
+	return siswas
 }
 
 func (c SiswaRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.Siswa {
-	SQL := "select id, nama, alamat, tanggal_lahir, tempat_lahir, jenis_kelamin, agama, golongan_darah, no_telepon from siswa"
-	rows, err := tx.QueryContext(ctx, SQL)
+	SQL := "select " + siswaColumns + " from siswa where sekolah_id = ? and deleted_at is null"
+	ctx, end := traceStatement(ctx, "SiswaRepository.FindAll", SQL)
+	defer end()
+	rows, err := tx.QueryContext(ctx, SQL, sekolahId(ctx, "siswa"))
 	helper.PanicIfError(err)
 	defer rows.Close()
 
 	var siswas []domain.Siswa
 	for rows.Next() {
-		siswa := domain.Siswa{}
-		err := rows.Scan(&siswa.Id, &siswa.Nama, &siswa.Alamat, &siswa.TanggalLahir, &siswa.TempatLahir, &siswa.JenisKelamin, &siswa.Agama, &siswa.GolonganDarah, &siswa.NoTelepon)
-		helper.PanicIfError(err)
-		siswas = append(siswas, siswa)
+		siswas = append(siswas, scanSiswa(rows))
 	}
 	return siswas
 }
+
+// Search returns a page of the siswa matching filter, by id.
+func (c SiswaRepositoryImpl) Search(ctx context.Context, tx *sql.Tx, filter domain.SiswaFilter) []domain.Siswa {
+	where, args := siswaFilterWhere(ctx, filter)
+	SQL := "select " + siswaColumns + " from siswa where " + where + " and id > ? order by id limit ?"
+	args = append(args, filter.AfterId, filter.Limit)
+	ctx, end := traceStatement(ctx, "SiswaRepository.Search", SQL)
+	defer end()
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var siswas []domain.Siswa
+	for rows.Next() {
+		siswas = append(siswas, scanSiswa(rows))
+	}
+	return siswas
+}
+
+// Count returns how many siswa match filter on every page together.
+func (c SiswaRepositoryImpl) Count(ctx context.Context, tx *sql.Tx, filter domain.SiswaFilter) int {
+	where, args := siswaFilterWhere(ctx, filter)
+	SQL := "select count(*) from siswa where " + where
+	ctx, end := traceStatement(ctx, "SiswaRepository.Count", SQL)
+	defer end()
+	var count int
+	err := tx.QueryRowContext(ctx, SQL, args...).Scan(&count)
+	helper.PanicIfError(err)
+	return count
+}
+
+func (c SiswaRepositoryImpl) FindTrashById(ctx context.Context, tx *sql.Tx, siswaId int) (domain.Siswa, error) {
+	SQL := "select " + siswaColumns + " from siswa where id = ? and sekolah_id = ? and deleted_at is not null"
+	ctx, end := traceStatement(ctx, "SiswaRepository.FindTrashById", SQL)
+	defer end()
+	rows, err := tx.QueryContext(ctx, SQL, siswaId, sekolahId(ctx, "siswa"))
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	if rows.Next() {
+		return scanSiswa(rows), nil
+	} else {
+		return domain.Siswa{}, errors.New("siswa is not found in trash")
+	}
+}
+
+func (c SiswaRepositoryImpl) FindTrash(ctx context.Context, tx *sql.Tx) []domain.Siswa {
+	SQL := "select " + siswaColumns + " from siswa where sekolah_id = ? and deleted_at is not null order by deleted_at desc"
+	ctx, end := traceStatement(ctx, "SiswaRepository.FindTrash", SQL)
+	defer end()
+	rows, err := tx.QueryContext(ctx, SQL, sekolahId(ctx, "siswa"))
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var siswas []domain.Siswa
+	for rows.Next() {
+		siswas = append(siswas, scanSiswa(rows))
+	}
+	return siswas
+}
+
+func (c SiswaRepositoryImpl) Restore(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) domain.Siswa {
+	SQL := "update siswa set deleted_at = null, deleted_by = null, version = version + 1 where id = ? and sekolah_id = ?"
+	ctx, end := traceStatement(ctx, "SiswaRepository.Restore", SQL)
+	defer end()
+	_, err := tx.ExecContext(ctx, SQL, siswa.Id, sekolahId(ctx, "siswa"))
+	helper.PanicIfError(err)
+
+	siswa.DeletedAt = nil
+	siswa.DeletedBy = ""
+	siswa.Version++
+	return siswa
+}
+
+// FindTrashBefore locks the siswa put in the trash before deletedBefore, so
+// they cannot be restored while they are purged.
+func (c SiswaRepositoryImpl) FindTrashBefore(ctx context.Context, tx *sql.Tx, deletedBefore time.Time) []domain.Siswa {
+	SQL := "select " + siswaColumns + " from siswa where sekolah_id = ? and deleted_at is not null and deleted_at < ? order by id for update"
+	ctx, end := traceStatement(ctx, "SiswaRepository.FindTrashBefore", SQL)
+	defer end()
+	rows, err := tx.QueryContext(ctx, SQL, sekolahId(ctx, "siswa"), deletedBefore)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var siswas []domain.Siswa
+	for rows.Next() {
+		siswas = append(siswas, scanSiswa(rows))
+	}
+	return siswas
+}
+
+func (c SiswaRepositoryImpl) Purge(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) {
+	SQL := "delete from siswa where id = ? and sekolah_id = ? and deleted_at is not null"
+	ctx, end := traceStatement(ctx, "SiswaRepository.Purge", SQL)
+	defer end()
+	_, err := tx.ExecContext(ctx, SQL, siswa.Id, sekolahId(ctx, "siswa"))
+	helper.PanicIfError(err)
+}
+
+func (c SiswaRepositoryImpl) CountByJenisKelamin(ctx context.Context, tx *sql.Tx) map[string]int {
+	SQL := "select jenis_kelamin, count(*) from siswa where sekolah_id = ? and deleted_at is null group by jenis_kelamin"
+	ctx, end := traceStatement(ctx, "SiswaRepository.CountByJenisKelamin", SQL)
+	defer end()
+	rows, err := tx.QueryContext(ctx, SQL, sekolahId(ctx, "siswa"))
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var jenisKelamin string
+		var count int
+		err := rows.Scan(&jenisKelamin, &count)
+		helper.PanicIfError(err)
+		counts[jenisKelamin] = count
+	}
+	return counts
+}
+
+func (c SiswaRepositoryImpl) CountTrash(ctx context.Context, tx *sql.Tx) int {
+	SQL := "select count(*) from siswa where sekolah_id = ? and deleted_at is not null"
+	ctx, end := traceStatement(ctx, "SiswaRepository.CountTrash", SQL)
+	defer end()
+	var count int
+	err := tx.QueryRowContext(ctx, SQL, sekolahId(ctx, "siswa")).Scan(&count)
+	helper.PanicIfError(err)
+	return count
+}
+
+// sekolahId is the school every query of entity is scoped to. A query
+// without one is a bug, so it fails rather than reach the rows of every
+// school.
+func sekolahId(ctx context.Context, entity string) int {
+	sekolah, ok := helper.SekolahFromContext(ctx)
+	if !ok {
+		panic(errors.New(entity + " queried without a sekolah"))
+	}
+	return sekolah.Id
+}
+
+// siswaFilterWhere compares with the case insensitive collation of the
+// table, and tanggal lahir by the date it starts with.
+func siswaFilterWhere(ctx context.Context, filter domain.SiswaFilter) (string, []interface{}) {
+	where := "sekolah_id = ? and deleted_at is null"
+	if filter.Trash {
+		where = "sekolah_id = ? and deleted_at is not null"
+	}
+	args := []interface{}{sekolahId(ctx, "siswa")}
+
+	if filter.Nama != "" {
+		where += " and nama like ?"
+		args = append(args, "%"+likeEscaper.Replace(filter.Nama)+"%")
+	}
+	columns := []string{"jenis_kelamin", "agama", "golongan_darah", "tempat_lahir"}
+	for i, value := range []string{filter.JenisKelamin, filter.Agama, filter.GolonganDarah, filter.TempatLahir} {
+		if value != "" {
+			where += " and " + columns[i] + " = ?"
+			args = append(args, value)
+		}
+	}
+	if filter.TanggalLahirFrom != "" {
+		where += " and left(tanggal_lahir, 10) >= ?"
+		args = append(args, filter.TanggalLahirFrom)
+	}
+	if filter.TanggalLahirTo != "" {
+		where += " and left(tanggal_lahir, 10) <= ?"
+		args = append(args, filter.TanggalLahirTo)
+	}
+	return where, args
+}
+
+var likeEscaper = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+
+// traceStatement records the statement text but never its parameters, which
+// hold personal data of the siswa.
+func traceStatement(ctx context.Context, name string, SQL string) (context.Context, func()) {
+	return helper.StartSpan(ctx, name,
+		attribute.String("db.system", "mysql"),
+		attribute.String("db.statement", SQL),
+	)
+}
+
+func scanSiswa(rows *sql.Rows) domain.Siswa {
+	siswa := domain.Siswa{}
+	deletedAt := sql.NullTime{}
+	deletedBy := sql.NullString{}
+	err := rows.Scan(&siswa.Id, &siswa.SekolahId, &siswa.Nama, &siswa.Alamat, &siswa.TanggalLahir, &siswa.TempatLahir, &siswa.JenisKelamin, &siswa.Agama, &siswa.GolonganDarah, &siswa.NoTelepon, &siswa.Version, &deletedAt, &deletedBy)
+	helper.PanicIfError(err)
+
+	if deletedAt.Valid {
+		siswa.DeletedAt = &deletedAt.Time
+	}
+	siswa.DeletedBy = deletedBy.String
+	return siswa
+}
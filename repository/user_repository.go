@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+type UserRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, user domain.User) (domain.User, error)
+	FindByUsername(ctx context.Context, tx *sql.Tx, username string) (domain.User, error)
+	FindAll(ctx context.Context, tx *sql.Tx) []domain.User
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+)
+
+type UserRepositoryImpl struct {
+}
+
+const userColumns = "u.id, u.username, u.nama, u.sekolah_id, s.kode, u.created_at"
+
+func NewUserRepository() UserRepository {
+	return &UserRepositoryImpl{}
+}
+
+// Save returns an error instead of panicking when the username is taken.
+func (c UserRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, user domain.User) (domain.User, error) {
+	SQL := "insert into user_account(username, nama, sekolah_id, created_at) values (?,?,?,?)"
+	result, err := tx.ExecContext(ctx, SQL, user.Username, user.Nama, nullInt(user.SekolahId), user.CreatedAt)
+	if err != nil {
+		return user, err
+	}
+
+	id, err := result.LastInsertId()
+	helper.PanicIfError(err)
+
+	user.Id = int(id)
+	return user, nil
+}
+
+func (c UserRepositoryImpl) FindByUsername(ctx context.Context, tx *sql.Tx, username string) (domain.User, error) {
+	SQL := "select " + userColumns + " from user_account u left join sekolah s on s.id = u.sekolah_id where u.username = ?"
+	rows, err := tx.QueryContext(ctx, SQL, username)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	if rows.Next() {
+		return scanUser(rows), nil
+	} else {
+		return domain.User{}, errors.New("user is not found")
+	}
+}
+
+func (c UserRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.User {
+	SQL := "select " + userColumns + " from user_account u left join sekolah s on s.id = u.sekolah_id order by u.username"
+	rows, err := tx.QueryContext(ctx, SQL)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		users = append(users, scanUser(rows))
+	}
+	return users
+}
+
+func scanUser(rows *sql.Rows) domain.User {
+	user := domain.User{}
+	sekolahId := sql.NullInt64{}
+	sekolah := sql.NullString{}
+	err := rows.Scan(&user.Id, &user.Username, &user.Nama, &sekolahId, &sekolah, &user.CreatedAt)
+	helper.PanicIfError(err)
+
+	user.SekolahId = int(sekolahId.Int64)
+	user.Sekolah = sekolah.String
+	return user
+}
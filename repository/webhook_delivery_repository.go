@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type WebhookDeliveryRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, delivery domain.WebhookDelivery) (domain.WebhookDelivery, bool)
+	Update(ctx context.Context, tx *sql.Tx, delivery domain.WebhookDelivery) domain.WebhookDelivery
+	FindById(ctx context.Context, tx *sql.Tx, deliveryId int64) (domain.WebhookDelivery, error)
+	FindBySubscription(ctx context.Context, tx *sql.Tx, subscriptionId int, limit int) []domain.WebhookDelivery
+	FindPending(ctx context.Context, tx *sql.Tx, now time.Time, limit int) []domain.WebhookDelivery
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type WebhookDeliveryRepositoryImpl struct {
+}
+
+func NewWebhookDeliveryRepository() WebhookDeliveryRepository {
+	return &WebhookDeliveryRepositoryImpl{}
+}
+
+const webhookDeliveryColumns = "d.id, d.subscription_id, d.event_id, d.event_type, d.aggregate_id, d.payload, d.status, d.attempts, d.response_status, d.last_error, d.next_attempt_at, d.created_at, d.delivered_at"
+
+// Save reports false, saving nothing, when the subscription already has a
+// delivery of the event: the outbox may publish an event more than once.
+func (c WebhookDeliveryRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, delivery domain.WebhookDelivery) (domain.WebhookDelivery, bool) {
+	SQL := "insert into webhook_delivery(subscription_id, event_id, event_type, aggregate_id, payload, status, created_at) values (?,?,?,?,?,?,?) on duplicate key update id = id"
+	result, err := tx.ExecContext(ctx, SQL, delivery.SubscriptionId, delivery.EventId, delivery.EventType, delivery.AggregateId, string(delivery.Payload), delivery.Status, delivery.CreatedAt)
+	helper.PanicIfError(err)
+
+	affected, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	if affected == 0 {
+		return delivery, false
+	}
+
+	id, err := result.LastInsertId()
+	helper.PanicIfError(err)
+
+	delivery.Id = id
+	return delivery, true
+}
+
+func (c WebhookDeliveryRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, delivery domain.WebhookDelivery) domain.WebhookDelivery {
+	var responseStatus, lastError interface{}
+	if delivery.ResponseStatus != 0 {
+		responseStatus = delivery.ResponseStatus
+	}
+	if delivery.LastError != "" {
+		lastError = delivery.LastError
+	}
+
+	SQL := "update webhook_delivery set status = ?, attempts = ?, response_status = ?, last_error = ?, next_attempt_at = ?, delivered_at = ? where id = ?"
+	_, err := tx.ExecContext(ctx, SQL, delivery.Status, delivery.Attempts, responseStatus, lastError, delivery.NextAttemptAt, delivery.DeliveredAt, delivery.Id)
+	helper.PanicIfError(err)
+
+	return delivery
+}
+
+func (c WebhookDeliveryRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, deliveryId int64) (domain.WebhookDelivery, error) {
+	SQL := "select " + webhookDeliveryColumns + " from webhook_delivery d where d.id = ?"
+	deliveries := c.query(ctx, tx, SQL, deliveryId)
+	if len(deliveries) == 0 {
+		return domain.WebhookDelivery{}, errors.New("delivery is not found")
+	}
+	return deliveries[0], nil
+}
+
+// FindBySubscription returns the latest deliveries first.
+func (c WebhookDeliveryRepositoryImpl) FindBySubscription(ctx context.Context, tx *sql.Tx, subscriptionId int, limit int) []domain.WebhookDelivery {
+	SQL := "select " + webhookDeliveryColumns + " from webhook_delivery d where d.subscription_id = ? order by d.id desc limit ?"
+	return c.query(ctx, tx, SQL, subscriptionId, limit)
+}
+
+// FindPending returns the oldest pending deliveries of active subscriptions
+// due at now first. A delivery waiting for its next attempt holds back the
+// later deliveries of its siswa to its subscription, so they are left out too.
+func (c WebhookDeliveryRepositoryImpl) FindPending(ctx context.Context, tx *sql.Tx, now time.Time, limit int) []domain.WebhookDelivery {
+	SQL := "select " + webhookDeliveryColumns + " from webhook_delivery d join webhook_subscription s on s.id = d.subscription_id " +
+		"where d.status = ? and s.active = true and (d.next_attempt_at is null or d.next_attempt_at <= ?) " +
+		"and not exists (select 1 from webhook_delivery w where w.subscription_id = d.subscription_id and w.aggregate_id = d.aggregate_id and w.id < d.id " +
+		"and w.status = ? and w.next_attempt_at > ?) " +
+		"order by d.id limit ?"
+	return c.query(ctx, tx, SQL, domain.DeliveryPending, now, domain.DeliveryPending, now, limit)
+}
+
+func (c WebhookDeliveryRepositoryImpl) query(ctx context.Context, tx *sql.Tx, SQL string, args ...interface{}) []domain.WebhookDelivery {
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		delivery := domain.WebhookDelivery{}
+		var responseStatus sql.NullInt64
+		var lastError sql.NullString
+		var nextAttemptAt, deliveredAt sql.NullTime
+		err := rows.Scan(&delivery.Id, &delivery.SubscriptionId, &delivery.EventId, &delivery.EventType, &delivery.AggregateId, &delivery.Payload, &delivery.Status, &delivery.Attempts, &responseStatus, &lastError, &nextAttemptAt, &delivery.CreatedAt, &deliveredAt)
+		helper.PanicIfError(err)
+
+		delivery.ResponseStatus = int(responseStatus.Int64)
+		delivery.LastError = lastError.String
+		if nextAttemptAt.Valid {
+			delivery.NextAttemptAt = &nextAttemptAt.Time
+		}
+		if deliveredAt.Valid {
+			delivery.DeliveredAt = &deliveredAt.Time
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type WebhookSubscriptionRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, subscription domain.WebhookSubscription) domain.WebhookSubscription
+	Update(ctx context.Context, tx *sql.Tx, subscription domain.WebhookSubscription) domain.WebhookSubscription
+	Delete(ctx context.Context, tx *sql.Tx, subscription domain.WebhookSubscription)
+	FindById(ctx context.Context, tx *sql.Tx, subscriptionId int) (domain.WebhookSubscription, error)
+	FindAll(ctx context.Context, tx *sql.Tx) []domain.WebhookSubscription
+	FindActiveByEventType(ctx context.Context, tx *sql.Tx, eventType string) []domain.WebhookSubscription
+	RecordSuccess(ctx context.Context, tx *sql.Tx, subscriptionId int)
+	RecordFailure(ctx context.Context, tx *sql.Tx, subscriptionId int, disableAfter int, now time.Time) bool
+}
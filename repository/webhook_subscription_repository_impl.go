@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"time"
+)
+
+type WebhookSubscriptionRepositoryImpl struct {
+}
+
+func NewWebhookSubscriptionRepository() WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepositoryImpl{}
+}
+
+const webhookSubscriptionColumns = "id, url, event_types, secret, active, consecutive_failures, disabled_at, created_at, updated_at"
+
+func (c WebhookSubscriptionRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, subscription domain.WebhookSubscription) domain.WebhookSubscription {
+	eventTypes, err := json.Marshal(subscription.EventTypes)
+	helper.PanicIfError(err)
+
+	SQL := "insert into webhook_subscription(url, event_types, secret, active, created_at, updated_at) values (?,?,?,?,?,?)"
+	result, err := tx.ExecContext(ctx, SQL, subscription.Url, string(eventTypes), subscription.Secret, subscription.Active, subscription.CreatedAt, subscription.UpdatedAt)
+	helper.PanicIfError(err)
+
+	id, err := result.LastInsertId()
+	helper.PanicIfError(err)
+
+	subscription.Id = int(id)
+	return subscription
+}
+
+func (c WebhookSubscriptionRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, subscription domain.WebhookSubscription) domain.WebhookSubscription {
+	eventTypes, err := json.Marshal(subscription.EventTypes)
+	helper.PanicIfError(err)
+
+	SQL := "update webhook_subscription set url = ?, event_types = ?, secret = ?, active = ?, consecutive_failures = ?, disabled_at = ?, updated_at = ? where id = ?"
+	_, err = tx.ExecContext(ctx, SQL, subscription.Url, string(eventTypes), subscription.Secret, subscription.Active, subscription.ConsecutiveFailures, subscription.DisabledAt, subscription.UpdatedAt, subscription.Id)
+	helper.PanicIfError(err)
+
+	return subscription
+}
+
+func (c WebhookSubscriptionRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, subscription domain.WebhookSubscription) {
+	SQL := "delete from webhook_subscription where id = ?"
+	_, err := tx.ExecContext(ctx, SQL, subscription.Id)
+	helper.PanicIfError(err)
+}
+
+func (c WebhookSubscriptionRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, subscriptionId int) (domain.WebhookSubscription, error) {
+	SQL := "select " + webhookSubscriptionColumns + " from webhook_subscription where id = ?"
+	subscriptions := c.query(ctx, tx, SQL, subscriptionId)
+	if len(subscriptions) == 0 {
+		return domain.WebhookSubscription{}, errors.New("webhook is not found")
+	}
+	return subscriptions[0], nil
+}
+
+func (c WebhookSubscriptionRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []domain.WebhookSubscription {
+	SQL := "select " + webhookSubscriptionColumns + " from webhook_subscription order by id"
+	return c.query(ctx, tx, SQL)
+}
+
+func (c WebhookSubscriptionRepositoryImpl) FindActiveByEventType(ctx context.Context, tx *sql.Tx, eventType string) []domain.WebhookSubscription {
+	SQL := "select " + webhookSubscriptionColumns + " from webhook_subscription where active = true and json_contains(event_types, json_quote(?)) order by id"
+	return c.query(ctx, tx, SQL, eventType)
+}
+
+func (c WebhookSubscriptionRepositoryImpl) RecordSuccess(ctx context.Context, tx *sql.Tx, subscriptionId int) {
+	SQL := "update webhook_subscription set consecutive_failures = 0 where id = ?"
+	_, err := tx.ExecContext(ctx, SQL, subscriptionId)
+	helper.PanicIfError(err)
+}
+
+// RecordFailure counts a failed attempt and disables the subscription once
+// disableAfter attempts in a row failed. It reports whether it did so.
+func (c WebhookSubscriptionRepositoryImpl) RecordFailure(ctx context.Context, tx *sql.Tx, subscriptionId int, disableAfter int, now time.Time) bool {
+	SQL := "update webhook_subscription set consecutive_failures = consecutive_failures + 1 where id = ?"
+	_, err := tx.ExecContext(ctx, SQL, subscriptionId)
+	helper.PanicIfError(err)
+
+	SQL = "update webhook_subscription set active = false, disabled_at = ?, updated_at = ? where id = ? and active = true and consecutive_failures >= ?"
+	result, err := tx.ExecContext(ctx, SQL, now, now, subscriptionId, disableAfter)
+	helper.PanicIfError(err)
+
+	disabled, err := result.RowsAffected()
+	helper.PanicIfError(err)
+	return disabled > 0
+}
+
+func (c WebhookSubscriptionRepositoryImpl) query(ctx context.Context, tx *sql.Tx, SQL string, args ...interface{}) []domain.WebhookSubscription {
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	helper.PanicIfError(err)
+	defer rows.Close()
+
+	var subscriptions []domain.WebhookSubscription
+	for rows.Next() {
+		subscription := domain.WebhookSubscription{}
+		var eventTypes []byte
+		var disabledAt sql.NullTime
+		err := rows.Scan(&subscription.Id, &subscription.Url, &eventTypes, &subscription.Secret, &subscription.Active, &subscription.ConsecutiveFailures, &disabledAt, &subscription.CreatedAt, &subscription.UpdatedAt)
+		helper.PanicIfError(err)
+
+		err = json.Unmarshal(eventTypes, &subscription.EventTypes)
+		helper.PanicIfError(err)
+		if disabledAt.Valid {
+			subscription.DisabledAt = &disabledAt.Time
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions
+}
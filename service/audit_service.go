@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/model/web"
+)
+
+type AuditService interface {
+	FindSiswaHistory(ctx context.Context, siswaId int) []web.AuditResponse
+	FindSiswaHistories(ctx context.Context, siswaIds []int, latest int) map[int][]web.AuditResponse
+	Search(ctx context.Context, request web.AuditSearchRequest) []web.AuditResponse
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/repository"
+	"time"
+)
+
+type AuditServiceImpl struct {
+	AuditRepository repository.AuditRepository
+	DB              *sql.DB
+}
+
+func NewAuditService(auditRepository repository.AuditRepository, DB *sql.DB) AuditService {
+	return &AuditServiceImpl{
+		AuditRepository: auditRepository,
+		DB:              DB,
+	}
+}
+
+func (service *AuditServiceImpl) FindSiswaHistory(ctx context.Context, siswaId int) []web.AuditResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	audits := service.AuditRepository.FindAll(ctx, tx, domain.AuditFilter{
+		SekolahId: currentSekolah(ctx).Id,
+		Entity:    "siswa",
+		EntityId:  siswaId,
+	})
+
+	return helper.ToAuditResponses(audits)
+}
+
+// FindSiswaHistories is FindSiswaHistory for many siswa with one query,
+// keeping only the latest audits of each.
+func (service *AuditServiceImpl) FindSiswaHistories(ctx context.Context, siswaIds []int, latest int) map[int][]web.AuditResponse {
+	histories := map[int][]web.AuditResponse{}
+	if len(siswaIds) == 0 {
+		return histories
+	}
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	audits := service.AuditRepository.FindAll(ctx, tx, domain.AuditFilter{
+		SekolahId: currentSekolah(ctx).Id,
+		Entity:    "siswa",
+		EntityIds: siswaIds,
+		Latest:    latest,
+	})
+
+	for _, audit := range audits {
+		histories[audit.EntityId] = append(histories[audit.EntityId], helper.ToAuditResponse(audit))
+	}
+	return histories
+}
+
+// Search covers every school for staff of the yayasan and only their own
+// for users of one school.
+func (service *AuditServiceImpl) Search(ctx context.Context, request web.AuditSearchRequest) []web.AuditResponse {
+	filter := domain.AuditFilter{
+		Actor:    request.Actor,
+		Entity:   request.Entity,
+		EntityId: request.EntityId,
+		From:     parseAuditDate("from", request.From, false),
+		To:       parseAuditDate("to", request.To, true),
+	}
+	if helper.RestrictedToSekolah(ctx) {
+		filter.SekolahId = currentSekolah(ctx).Id
+	}
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	audits := service.AuditRepository.FindAll(ctx, tx, filter)
+
+	return helper.ToAuditResponses(audits)
+}
+
+// parseAuditDate accepts either a date (2006-01-02) or an RFC 3339 timestamp.
+// A plain date used as the upper bound covers the whole day.
+func parseAuditDate(name string, value string, endOfDay bool) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		if endOfDay {
+			date = date.AddDate(0, 0, 1)
+		}
+		return &date
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(exception.NewBadRequestError(name + " must be a date (2006-01-02) or RFC 3339 timestamp"))
+	}
+	return &timestamp
+}
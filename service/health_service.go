@@ -0,0 +1,10 @@
+package service
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/model/web"
+)
+
+type HealthService interface {
+	Readiness(ctx context.Context) web.HealthResponse
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/db"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/repository"
+	"strconv"
+	"time"
+)
+
+// HealthServiceImpl expects the schema_migrations table of golang-migrate,
+// kept by sisko migrate. CheckMigration turns that check off for databases
+// whose schema is managed some other way.
+type HealthServiceImpl struct {
+	MigrationRepository repository.MigrationRepository
+	DB                  *sql.DB
+	CheckMigration      bool
+}
+
+func NewHealthService(migrationRepository repository.MigrationRepository, DB *sql.DB, checkMigration bool) HealthService {
+	return &HealthServiceImpl{
+		MigrationRepository: migrationRepository,
+		DB:                  DB,
+		CheckMigration:      checkMigration,
+	}
+}
+
+// Readiness never panics: a failing check is reported in the response so the
+// probe can answer 503 instead of going through the error handler.
+func (service *HealthServiceImpl) Readiness(ctx context.Context) web.HealthResponse {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	healthResponse := web.HealthResponse{
+		Ready:    true,
+		Checks:   map[string]string{},
+		Expected: db.LatestVersion(),
+	}
+
+	err := service.DB.PingContext(ctx)
+	if err != nil {
+		healthResponse.Ready = false
+		healthResponse.Checks["database"] = err.Error()
+		healthResponse.Checks["migration"] = "skipped"
+		return healthResponse
+	}
+	healthResponse.Checks["database"] = "ok"
+	if !service.CheckMigration {
+		healthResponse.Checks["migration"] = "skipped"
+		return healthResponse
+	}
+
+	tx, err := service.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		healthResponse.Ready = false
+		healthResponse.Checks["migration"] = err.Error()
+		return healthResponse
+	}
+	defer tx.Rollback()
+
+	migration, err := service.MigrationRepository.FindCurrent(ctx, tx)
+	healthResponse.Migration = migration.Version
+	switch {
+	case err != nil:
+		healthResponse.Ready = false
+		healthResponse.Checks["migration"] = err.Error()
+	case migration.Dirty:
+		healthResponse.Ready = false
+		healthResponse.Checks["migration"] = "migration " + strconv.Itoa(migration.Version) + " is dirty, repair the schema and run sisko migrate force"
+	case migration.Version < healthResponse.Expected:
+		healthResponse.Ready = false
+		healthResponse.Checks["migration"] = "pending migrations up to " + strconv.Itoa(healthResponse.Expected)
+	default:
+		healthResponse.Checks["migration"] = "ok"
+	}
+	return healthResponse
+}
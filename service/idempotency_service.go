@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/model/web"
+)
+
+type IdempotencyService interface {
+	Start(ctx context.Context, key string, fingerprint string) *web.IdempotentResponse
+	Finish(ctx context.Context, key string, response web.IdempotentResponse)
+	Abort(ctx context.Context, key string)
+	PurgeExpired(ctx context.Context) int
+}
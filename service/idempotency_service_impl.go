@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/repository"
+	"time"
+)
+
+type IdempotencyServiceImpl struct {
+	IdempotencyKeyRepository repository.IdempotencyKeyRepository
+	DB                       *sql.DB
+	TTL                      time.Duration
+}
+
+func NewIdempotencyService(idempotencyKeyRepository repository.IdempotencyKeyRepository, DB *sql.DB, ttl time.Duration) IdempotencyService {
+	return &IdempotencyServiceImpl{
+		IdempotencyKeyRepository: idempotencyKeyRepository,
+		DB:                       DB,
+		TTL:                      ttl,
+	}
+}
+
+// Start claims key for the current actor. It returns the stored response when
+// the same request was already completed, or nil when the caller should go
+// ahead and handle the request, then call Finish or Abort.
+func (service *IdempotencyServiceImpl) Start(ctx context.Context, key string, fingerprint string) *web.IdempotentResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	actor := helper.ActorFromContext(ctx)
+	idempotencyKey, err := service.IdempotencyKeyRepository.FindByKey(ctx, tx, actor, key)
+	if err == nil && idempotencyKey.CreatedAt.Before(time.Now().Add(-service.TTL)) {
+		service.IdempotencyKeyRepository.Delete(ctx, tx, idempotencyKey)
+	} else if err == nil {
+		if idempotencyKey.Fingerprint != fingerprint {
+			panic(exception.NewUnprocessableEntityError("Idempotency-Key was already used with a different request"))
+		}
+		if idempotencyKey.StatusCode == 0 {
+			panic(exception.NewConflictError("a request with this Idempotency-Key is still being processed"))
+		}
+		return &web.IdempotentResponse{
+			StatusCode:  idempotencyKey.StatusCode,
+			ContentType: idempotencyKey.ContentType,
+			Headers:     idempotencyKey.Headers,
+			Body:        idempotencyKey.ResponseBody,
+		}
+	}
+
+	err = service.IdempotencyKeyRepository.Save(ctx, tx, domain.IdempotencyKey{
+		Actor:       actor,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   time.Now(),
+	})
+	if helper.IsDuplicateKey(err) {
+		panic(exception.NewConflictError("a request with this Idempotency-Key is still being processed"))
+	}
+	helper.PanicIfError(err)
+	return nil
+}
+
+func (service *IdempotencyServiceImpl) Finish(ctx context.Context, key string, response web.IdempotentResponse) {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	service.IdempotencyKeyRepository.Update(ctx, tx, domain.IdempotencyKey{
+		Actor:        helper.ActorFromContext(ctx),
+		Key:          key,
+		StatusCode:   response.StatusCode,
+		ContentType:  response.ContentType,
+		Headers:      response.Headers,
+		ResponseBody: response.Body,
+	})
+}
+
+func (service *IdempotencyServiceImpl) Abort(ctx context.Context, key string) {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	service.IdempotencyKeyRepository.Delete(ctx, tx, domain.IdempotencyKey{
+		Actor: helper.ActorFromContext(ctx),
+		Key:   key,
+	})
+}
+
+func (service *IdempotencyServiceImpl) PurgeExpired(ctx context.Context) int {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return service.IdempotencyKeyRepository.DeleteExpired(ctx, tx, time.Now().Add(-service.TTL))
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/model/web"
+	"time"
+)
+
+type JobService interface {
+	FindAll(ctx context.Context, request web.JobSearchRequest) []web.JobResponse
+	FindById(ctx context.Context, jobId int64) web.JobResponse
+	Retry(ctx context.Context, jobId int64) web.JobResponse
+	PurgeFinished(ctx context.Context, retention time.Duration) int
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/go-playground/validator"
+	"time"
+)
+
+// jobListSize is how many of the latest jobs FindAll shows.
+const jobListSize = 200
+
+type JobServiceImpl struct {
+	JobRepository repository.JobRepository
+	DB            *sql.DB
+	Validate      *validator.Validate
+}
+
+func NewJobService(jobRepository repository.JobRepository, DB *sql.DB, validate *validator.Validate) JobService {
+	return &JobServiceImpl{
+		JobRepository: jobRepository,
+		DB:            DB,
+		Validate:      validate,
+	}
+}
+
+func (service *JobServiceImpl) FindAll(ctx context.Context, request web.JobSearchRequest) []web.JobResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	jobs := service.JobRepository.FindAll(ctx, tx, domain.JobFilter{
+		Name:   request.Name,
+		Status: request.Status,
+		Limit:  jobListSize,
+	})
+	return helper.ToJobResponses(jobs)
+}
+
+func (service *JobServiceImpl) FindById(ctx context.Context, jobId int64) web.JobResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return helper.ToJobResponse(service.findById(ctx, tx, jobId))
+}
+
+// Retry queues a failed job to run now, with all its attempts again.
+func (service *JobServiceImpl) Retry(ctx context.Context, jobId int64) web.JobResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	job := service.findById(ctx, tx, jobId)
+	if job.Status != domain.JobFailed {
+		panic(exception.NewConflictError("only failed jobs can be retried, this one is " + job.Status))
+	}
+
+	job.Status = domain.JobQueued
+	job.Attempts = 0
+	job.RunAt = time.Now()
+	job.FinishedAt = nil
+	job = service.JobRepository.Update(ctx, tx, job)
+	return helper.ToJobResponse(job)
+}
+
+// PurgeFinished deletes the jobs that finished longer than retention ago.
+func (service *JobServiceImpl) PurgeFinished(ctx context.Context, retention time.Duration) int {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return service.JobRepository.DeleteFinished(ctx, tx, time.Now().Add(-retention))
+}
+
+func (service *JobServiceImpl) findById(ctx context.Context, tx *sql.Tx, jobId int64) domain.Job {
+	job, err := service.JobRepository.FindById(ctx, tx, jobId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	return job
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"time"
+)
+
+// SendNotificationJob is the job that delivers one notification; its
+// payload is the notification id.
+const SendNotificationJob = "send_notification"
+
+// JobQueue queues background jobs, as job.Runner does.
+type JobQueue interface {
+	EnqueueTx(ctx context.Context, tx *sql.Tx, name string, payload []byte, runAt time.Time) domain.Job
+}
+
+type NotificationService interface {
+	Send(ctx context.Context, request web.NotificationSendRequest) []web.NotificationResponse
+	FindAll(ctx context.Context, request web.NotificationSearchRequest) []web.NotificationResponse
+	FindPreference(ctx context.Context, siswaId int) web.NotificationPreferenceResponse
+	SavePreference(ctx context.Context, request web.NotificationPreferenceRequest) web.NotificationPreferenceResponse
+	Deliver(ctx context.Context, notificationId int64) error
+}
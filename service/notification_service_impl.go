@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/notification"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/go-playground/validator"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// notificationListSize is how many of the latest notifications FindAll shows.
+const notificationListSize = 200
+
+// defaultNotificationChannels reach the parents of a siswa without a
+// preference on Siswa.NoTelepon, in the language of the school.
+var defaultNotificationChannels = []string{notification.ChannelWhatsapp, notification.ChannelSms}
+
+// NotificationServiceImpl renders a notification when it is sent and queues
+// a job that delivers it. Delivery tries the channels of the parent in
+// order until one provider accepts the message; if none does, the job is
+// retried. The phone number of the siswa stands in for a missing SMS or
+// WhatsApp number.
+type NotificationServiceImpl struct {
+	NotificationRepository           repository.NotificationRepository
+	NotificationPreferenceRepository repository.NotificationPreferenceRepository
+	SiswaRepository                  repository.SiswaRepository
+	DB                               *sql.DB
+	Validate                         *validator.Validate
+	JobQueue                         JobQueue
+	Providers                        map[string]notification.Provider
+}
+
+func NewNotificationService(notificationRepository repository.NotificationRepository, notificationPreferenceRepository repository.NotificationPreferenceRepository, siswaRepository repository.SiswaRepository, DB *sql.DB, validate *validator.Validate, jobQueue JobQueue, providers []notification.Provider) NotificationService {
+	providersByChannel := map[string]notification.Provider{}
+	for _, provider := range providers {
+		providersByChannel[provider.Channel()] = provider
+	}
+
+	return &NotificationServiceImpl{
+		NotificationRepository:           notificationRepository,
+		NotificationPreferenceRepository: notificationPreferenceRepository,
+		SiswaRepository:                  siswaRepository,
+		DB:                               DB,
+		Validate:                         validate,
+		JobQueue:                         jobQueue,
+		Providers:                        providersByChannel,
+	}
+}
+
+// Send renders and queues a notification per siswa, all or none of them.
+// Parents who turned notifications off get one that is skipped.
+func (service *NotificationServiceImpl) Send(ctx context.Context, request web.NotificationSendRequest) []web.NotificationResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	var notifications []domain.Notification
+	now := time.Now()
+	for _, siswaId := range request.SiswaIds {
+		siswa := service.findSiswa(ctx, tx, siswaId)
+		preference := service.preference(ctx, tx, siswa)
+
+		subject, body, err := notification.Render(request.Template, preference.Language, notification.TemplateData{Nama: siswa.Nama, Data: request.Data})
+		if err != nil {
+			panic(exception.NewBadRequestError(err.Error()))
+		}
+
+		routes := notificationRoutes(siswa, preference)
+		status := domain.NotificationPending
+		if len(routes) == 0 {
+			status = domain.NotificationSkipped
+		}
+
+		saved := service.NotificationRepository.Save(ctx, tx, domain.Notification{
+			SiswaId:   siswa.Id,
+			Template:  request.Template,
+			Language:  preference.Language,
+			Subject:   subject,
+			Body:      body,
+			Routes:    routes,
+			Status:    status,
+			CreatedBy: helper.ActorFromContext(ctx),
+			CreatedAt: now,
+		})
+		if status == domain.NotificationPending {
+			service.JobQueue.EnqueueTx(ctx, tx, SendNotificationJob, []byte(strconv.FormatInt(saved.Id, 10)), now)
+		}
+		notifications = append(notifications, saved)
+	}
+	return helper.ToNotificationResponses(notifications)
+}
+
+// FindAll covers every school for staff of the yayasan and only their own
+// for users of one school.
+func (service *NotificationServiceImpl) FindAll(ctx context.Context, request web.NotificationSearchRequest) []web.NotificationResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	filter := domain.NotificationFilter{
+		SiswaId: request.SiswaId,
+		Status:  request.Status,
+		Limit:   notificationListSize,
+	}
+	if helper.RestrictedToSekolah(ctx) {
+		filter.SekolahId = currentSekolah(ctx).Id
+	}
+
+	notifications := service.NotificationRepository.FindAll(ctx, tx, filter)
+	return helper.ToNotificationResponses(notifications)
+}
+
+// FindPreference returns the defaults for a siswa whose parent has no
+// preference yet.
+func (service *NotificationServiceImpl) FindPreference(ctx context.Context, siswaId int) web.NotificationPreferenceResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	siswa := service.findSiswa(ctx, tx, siswaId)
+	return helper.ToNotificationPreferenceResponse(service.preference(ctx, tx, siswa))
+}
+
+func (service *NotificationServiceImpl) SavePreference(ctx context.Context, request web.NotificationPreferenceRequest) web.NotificationPreferenceResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	siswa := service.findSiswa(ctx, tx, request.SiswaId)
+	if containsString(request.Channels, notification.ChannelEmail) && request.Email == "" {
+		panic(exception.NewBadRequestError("email is required to be notified by email"))
+	}
+
+	preference := service.NotificationPreferenceRepository.Save(ctx, tx, domain.NotificationPreference{
+		SiswaId:    siswa.Id,
+		NamaWali:   request.NamaWali,
+		Email:      request.Email,
+		NoTelepon:  request.NoTelepon,
+		NoWhatsapp: request.NoWhatsapp,
+		Channels:   request.Channels,
+		Language:   request.Language,
+		UpdatedAt:  time.Now(),
+	})
+	return helper.ToNotificationPreferenceResponse(preference)
+}
+
+// Deliver sends a pending notification on the first route whose provider
+// accepts it. Delivering a notification that went out already does nothing,
+// as jobs may run more than once.
+func (service *NotificationServiceImpl) Deliver(ctx context.Context, notificationId int64) error {
+	saved, err := service.findNotification(ctx, notificationId)
+	if err != nil {
+		return err
+	}
+	if saved.Status == domain.NotificationSent || saved.Status == domain.NotificationSkipped {
+		return nil
+	}
+
+	saved.Attempts++
+	var failures []string
+	for _, route := range saved.Routes {
+		provider, ok := service.Providers[route.Channel]
+		if !ok {
+			failures = append(failures, route.Channel+": no provider configured")
+			continue
+		}
+
+		err := provider.Send(ctx, notification.Message{To: route.To, Subject: saved.Subject, Body: saved.Body})
+		if err != nil {
+			failures = append(failures, route.Channel+": "+err.Error())
+			continue
+		}
+
+		sentAt := time.Now()
+		saved.Status = domain.NotificationSent
+		saved.Channel = route.Channel
+		saved.Recipient = route.To
+		saved.LastError = strings.Join(failures, "; ")
+		saved.SentAt = &sentAt
+		service.update(ctx, saved)
+		return nil
+	}
+
+	saved.Status = domain.NotificationFailed
+	saved.LastError = strings.Join(failures, "; ")
+	service.update(ctx, saved)
+	return errors.New(saved.LastError)
+}
+
+func (service *NotificationServiceImpl) findNotification(ctx context.Context, notificationId int64) (domain.Notification, error) {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return service.NotificationRepository.FindById(ctx, tx, notificationId)
+}
+
+func (service *NotificationServiceImpl) update(ctx context.Context, saved domain.Notification) {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	service.NotificationRepository.Update(ctx, tx, saved)
+}
+
+func (service *NotificationServiceImpl) findSiswa(ctx context.Context, tx *sql.Tx, siswaId int) domain.Siswa {
+	siswa, err := service.SiswaRepository.FindById(ctx, tx, siswaId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	return siswa
+}
+
+func (service *NotificationServiceImpl) preference(ctx context.Context, tx *sql.Tx, siswa domain.Siswa) domain.NotificationPreference {
+	preference, err := service.NotificationPreferenceRepository.FindBySiswaId(ctx, tx, siswa.Id)
+	if err != nil {
+		return domain.NotificationPreference{
+			SiswaId:  siswa.Id,
+			Channels: defaultNotificationChannels,
+			Language: currentSekolah(ctx).Bahasa,
+		}
+	}
+	return preference
+}
+
+// notificationRoutes pairs every channel of the preference with the contact
+// to use on it, leaving out channels without one.
+func notificationRoutes(siswa domain.Siswa, preference domain.NotificationPreference) []domain.NotificationRoute {
+	var routes []domain.NotificationRoute
+	for _, channel := range preference.Channels {
+		to := ""
+		switch channel {
+		case notification.ChannelEmail:
+			to = preference.Email
+		case notification.ChannelSms:
+			to = firstNonEmpty(preference.NoTelepon, siswa.NoTelepon)
+		case notification.ChannelWhatsapp:
+			to = firstNonEmpty(preference.NoWhatsapp, preference.NoTelepon, siswa.NoTelepon)
+		}
+		if to != "" {
+			routes = append(routes, domain.NotificationRoute{Channel: channel, To: to})
+		}
+	}
+	return routes
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
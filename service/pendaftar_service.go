@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/model/web"
+)
+
+// PendaftarService runs the PPDB of the school in ctx. Register,
+// UploadDokumen, FindOwn and FindAnnounced serve the public; the rest serve
+// the staff.
+type PendaftarService interface {
+	Register(ctx context.Context, request web.PendaftarCreateRequest) web.PendaftarRegisterResponse
+	UploadDokumen(ctx context.Context, request web.DokumenUploadRequest) web.DokumenResponse
+	FindOwn(ctx context.Context, pendaftarId int, token string) web.PendaftarResponse
+	FindAnnounced(ctx context.Context) []web.PengumumanResponse
+	FindById(ctx context.Context, pendaftarId int) web.PendaftarResponse
+	FindAll(ctx context.Context, request web.PendaftarSearchRequest) []web.PendaftarResponse
+	FindDokumen(ctx context.Context, pendaftarId int, jenis string) web.DokumenResponse
+	Verify(ctx context.Context, pendaftarId int) web.PendaftarResponse
+	Score(ctx context.Context, request web.PendaftarScoreRequest) web.PendaftarResponse
+	Reject(ctx context.Context, request web.PendaftarRejectRequest) web.PendaftarResponse
+	Select(ctx context.Context) []web.PendaftarResponse
+	Announce(ctx context.Context) []web.PendaftarResponse
+	ReRegister(ctx context.Context, pendaftarId int) web.PendaftarResponse
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/ppdb"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/go-playground/validator"
+	"strings"
+	"time"
+)
+
+// PendaftarServiceImpl keeps a pendaftar on the path of ppdb.Transition.
+// A pendaftar proves who they are with the token handed out once when they
+// register, kept only as a hash like API keys.
+type PendaftarServiceImpl struct {
+	PendaftarRepository repository.PendaftarRepository
+	SiswaService        SiswaService
+	DB                  *sql.DB
+	Validate            *validator.Validate
+}
+
+func NewPendaftarService(pendaftarRepository repository.PendaftarRepository, siswaService SiswaService, DB *sql.DB, validate *validator.Validate) PendaftarService {
+	return &PendaftarServiceImpl{
+		PendaftarRepository: pendaftarRepository,
+		SiswaService:        siswaService,
+		DB:                  DB,
+		Validate:            validate,
+	}
+}
+
+func (service *PendaftarServiceImpl) Register(ctx context.Context, request web.PendaftarCreateRequest) web.PendaftarRegisterResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	random := make([]byte, apiKeyLength/2)
+	_, err = rand.Read(random)
+	helper.PanicIfError(err)
+	token := hex.EncodeToString(random)
+
+	now := time.Now()
+	pendaftar := service.PendaftarRepository.Save(ctx, tx, domain.Pendaftar{
+		TokenHash:     hashApiKey(token),
+		Jalur:         request.Jalur,
+		Status:        domain.PendaftarRegistered,
+		Nama:          request.Nama,
+		Alamat:        request.Alamat,
+		TanggalLahir:  request.TanggalLahir,
+		TempatLahir:   request.TempatLahir,
+		JenisKelamin:  request.JenisKelamin,
+		Agama:         request.Agama,
+		GolonganDarah: request.GolonganDarah,
+		NoTelepon:     request.NoTelepon,
+		AsalSekolah:   request.AsalSekolah,
+		Jarak:         request.Jarak,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+
+	return web.PendaftarRegisterResponse{PendaftarResponse: helper.ToPendaftarResponse(pendaftar), Token: token}
+}
+
+// UploadDokumen replaces a document of the same jenis, which is possible
+// until the documents are verified.
+func (service *PendaftarServiceImpl) UploadDokumen(ctx context.Context, request web.DokumenUploadRequest) web.DokumenResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	pendaftar := service.findOwn(ctx, tx, request.PendaftarId, request.Token)
+	if pendaftar.Status != domain.PendaftarRegistered {
+		panic(exception.NewConflictError("documents cannot be changed once the pendaftar is " + pendaftar.Status))
+	}
+
+	dokumen := service.PendaftarRepository.SaveDokumen(ctx, tx, domain.Dokumen{
+		PendaftarId: pendaftar.Id,
+		Jenis:       request.Jenis,
+		ContentType: request.ContentType,
+		Ukuran:      len(request.Isi),
+		Isi:         request.Isi,
+		UploadedAt:  time.Now(),
+	})
+	dokumen.Isi = nil
+	return helper.ToDokumenResponse(dokumen)
+}
+
+// FindOwn shows a pendaftar their registration. Selection results stay
+// hidden until they are announced.
+func (service *PendaftarServiceImpl) FindOwn(ctx context.Context, pendaftarId int, token string) web.PendaftarResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	pendaftar := service.findOwn(ctx, tx, pendaftarId, token)
+	if pendaftar.AnnouncedAt == nil && pendaftar.Peringkat > 0 {
+		pendaftar.Status = domain.PendaftarTested
+		pendaftar.Peringkat = 0
+	}
+	return helper.ToPendaftarResponse(pendaftar)
+}
+
+// FindAnnounced lists who was admitted, once announced.
+func (service *PendaftarServiceImpl) FindAnnounced(ctx context.Context) []web.PengumumanResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	pendaftars := service.PendaftarRepository.FindAnnounced(ctx, tx)
+	return helper.ToPengumumanResponses(pendaftars)
+}
+
+func (service *PendaftarServiceImpl) FindById(ctx context.Context, pendaftarId int) web.PendaftarResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return helper.ToPendaftarResponse(service.findById(ctx, tx, pendaftarId))
+}
+
+func (service *PendaftarServiceImpl) FindAll(ctx context.Context, request web.PendaftarSearchRequest) []web.PendaftarResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	pendaftars := service.PendaftarRepository.FindAll(ctx, tx, domain.PendaftarFilter{
+		Jalur:  request.Jalur,
+		Status: request.Status,
+	})
+	return helper.ToPendaftarResponses(pendaftars)
+}
+
+func (service *PendaftarServiceImpl) FindDokumen(ctx context.Context, pendaftarId int, jenis string) web.DokumenResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	dokumen, err := service.PendaftarRepository.FindDokumen(ctx, tx, pendaftarId, jenis)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	return helper.ToDokumenResponse(dokumen)
+}
+
+// Verify confirms the documents of the jalur are all there and genuine.
+func (service *PendaftarServiceImpl) Verify(ctx context.Context, pendaftarId int) web.PendaftarResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	pendaftar := service.moveTo(ctx, tx, service.findById(ctx, tx, pendaftarId), domain.PendaftarVerified)
+	if missing := ppdb.MissingDokumen(pendaftar); len(missing) > 0 {
+		panic(exception.NewUnprocessableEntityError("pendaftar has not uploaded " + strings.Join(missing, ", ")))
+	}
+	return helper.ToPendaftarResponse(pendaftar)
+}
+
+func (service *PendaftarServiceImpl) Score(ctx context.Context, request web.PendaftarScoreRequest) web.PendaftarResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	pendaftar := service.findById(ctx, tx, request.Id)
+	pendaftar.Nilai = request.Nilai
+	pendaftar = service.moveTo(ctx, tx, pendaftar, domain.PendaftarTested)
+	return helper.ToPendaftarResponse(pendaftar)
+}
+
+func (service *PendaftarServiceImpl) Reject(ctx context.Context, request web.PendaftarRejectRequest) web.PendaftarResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	pendaftar := service.findById(ctx, tx, request.Id)
+	pendaftar.Catatan = request.Catatan
+	pendaftar = service.moveTo(ctx, tx, pendaftar, domain.PendaftarRejected)
+	return helper.ToPendaftarResponse(pendaftar)
+}
+
+// Select decides on every tested pendaftar with the kuota of the school,
+// see ppdb.Select. It may run again for pendaftar tested later; their
+// ranks follow on the seats taken before.
+func (service *PendaftarServiceImpl) Select(ctx context.Context) []web.PendaftarResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	tested := service.PendaftarRepository.FindAll(ctx, tx, domain.PendaftarFilter{Status: domain.PendaftarTested})
+	taken := service.PendaftarRepository.CountAccepted(ctx, tx)
+	seats := service.freeSeats(ctx, tx)
+
+	var pendaftars []domain.Pendaftar
+	now := time.Now()
+	for _, pendaftar := range ppdb.Select(tested, ppdb.Kuota(currentSekolah(ctx)), taken, seats) {
+		pendaftar.UpdatedAt = now
+		pendaftar, err := service.PendaftarRepository.Update(ctx, tx, pendaftar, domain.PendaftarTested)
+		if err != nil {
+			panic(exception.NewConflictError(err.Error()))
+		}
+		pendaftars = append(pendaftars, pendaftar)
+	}
+	return helper.ToPendaftarResponses(pendaftars)
+}
+
+// freeSeats is what is left of the KuotaSiswa of the school once its siswa
+// and the pendaftar accepted but not re-registered yet have their place,
+// -1 for a school without one. Like checkKuota of SiswaService it does not
+// stop a siswa created meanwhile.
+func (service *PendaftarServiceImpl) freeSeats(ctx context.Context, tx *sql.Tx) int {
+	kuota := currentSekolah(ctx).KuotaSiswa
+	if kuota == 0 {
+		return -1
+	}
+
+	seats := kuota - len(service.PendaftarRepository.FindAll(ctx, tx, domain.PendaftarFilter{Status: domain.PendaftarAccepted}))
+	for _, count := range service.SiswaService.Statistics(ctx).ActiveByJenisKelamin {
+		seats -= count
+	}
+	if seats < 0 {
+		return 0
+	}
+	return seats
+}
+
+// Announce makes every decision not announced yet public, to the pendaftar
+// concerned and, for those accepted, on the announcement.
+func (service *PendaftarServiceImpl) Announce(ctx context.Context) []web.PendaftarResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	pendaftars := service.PendaftarRepository.Announce(ctx, tx, time.Now())
+	return helper.ToPendaftarResponses(pendaftars)
+}
+
+// ReRegister turns an accepted pendaftar into a siswa in one transaction
+// with SiswaService.CreateTx, so the siswa and its event exist only once
+// the pendaftar is re-registered. Repeating it returns the pendaftar
+// re-registered before.
+func (service *PendaftarServiceImpl) ReRegister(ctx context.Context, pendaftarId int) web.PendaftarResponse {
+	var onCommit helper.OnCommit
+	pendaftarResponse := service.reRegister(ctx, pendaftarId, &onCommit)
+	onCommit.Run()
+	return pendaftarResponse
+}
+
+func (service *PendaftarServiceImpl) reRegister(ctx context.Context, pendaftarId int, onCommit *helper.OnCommit) web.PendaftarResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	pendaftar := service.findById(ctx, tx, pendaftarId)
+	if pendaftar.Status == domain.PendaftarReRegistered {
+		return helper.ToPendaftarResponse(pendaftar)
+	}
+	err = ppdb.Transition(pendaftar.Status, domain.PendaftarReRegistered)
+	if err != nil {
+		panic(exception.NewConflictError(err.Error()))
+	}
+	if pendaftar.AnnouncedAt == nil {
+		panic(exception.NewConflictError("pendaftar cannot re-register before the results are announced"))
+	}
+
+	siswa := service.SiswaService.CreateTx(ctx, tx, onCommit, web.SiswaCreateRequest{
+		Nama:          pendaftar.Nama,
+		Alamat:        pendaftar.Alamat,
+		TanggalLahir:  pendaftar.TanggalLahir,
+		TempatLahir:   pendaftar.TempatLahir,
+		JenisKelamin:  pendaftar.JenisKelamin,
+		Agama:         pendaftar.Agama,
+		GolonganDarah: pendaftar.GolonganDarah,
+		NoTelepon:     pendaftar.NoTelepon,
+	})
+
+	pendaftar.Status = domain.PendaftarReRegistered
+	pendaftar.SiswaId = siswa.Id
+	pendaftar.UpdatedAt = time.Now()
+	pendaftar, err = service.PendaftarRepository.Update(ctx, tx, pendaftar, domain.PendaftarAccepted)
+	if err != nil {
+		panic(exception.NewConflictError(err.Error()))
+	}
+	return helper.ToPendaftarResponse(pendaftar)
+}
+
+// moveTo saves pendaftar in status, refusing what ppdb.Transition refuses.
+func (service *PendaftarServiceImpl) moveTo(ctx context.Context, tx *sql.Tx, pendaftar domain.Pendaftar, status string) domain.Pendaftar {
+	err := ppdb.Transition(pendaftar.Status, status)
+	if err != nil {
+		panic(exception.NewConflictError(err.Error()))
+	}
+
+	from := pendaftar.Status
+	pendaftar.Status = status
+	pendaftar.UpdatedAt = time.Now()
+	pendaftar, err = service.PendaftarRepository.Update(ctx, tx, pendaftar, from)
+	if err != nil {
+		panic(exception.NewConflictError(err.Error()))
+	}
+	return pendaftar
+}
+
+func (service *PendaftarServiceImpl) findById(ctx context.Context, tx *sql.Tx, pendaftarId int) domain.Pendaftar {
+	pendaftar, err := service.PendaftarRepository.FindById(ctx, tx, pendaftarId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	return pendaftar
+}
+
+// findOwn answers a wrong token like an unknown pendaftar, so ids cannot be
+// probed.
+func (service *PendaftarServiceImpl) findOwn(ctx context.Context, tx *sql.Tx, pendaftarId int, token string) domain.Pendaftar {
+	pendaftar, err := service.PendaftarRepository.FindById(ctx, tx, pendaftarId)
+	if err != nil || subtle.ConstantTimeCompare([]byte(hashApiKey(token)), []byte(pendaftar.TokenHash)) != 1 {
+		panic(exception.NewNotFoundError("pendaftar is not found"))
+	}
+	return pendaftar
+}
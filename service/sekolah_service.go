@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+)
+
+type SekolahService interface {
+	Create(ctx context.Context, request web.SekolahCreateRequest) web.SekolahResponse
+	Update(ctx context.Context, request web.SekolahUpdateRequest) web.SekolahResponse
+	FindById(ctx context.Context, sekolahId int) web.SekolahResponse
+	FindAll(ctx context.Context) []web.SekolahResponse
+	FindCurrent(ctx context.Context) web.SekolahResponse
+	Resolve(ctx context.Context, username string, kode string) (domain.Sekolah, bool)
+	ForEach(ctx context.Context, fn func(ctx context.Context, sekolah domain.Sekolah))
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/go-playground/validator"
+	"time"
+)
+
+type SekolahServiceImpl struct {
+	SekolahRepository repository.SekolahRepository
+	UserRepository    repository.UserRepository
+	DB                *sql.DB
+	Validate          *validator.Validate
+	DefaultKode       string
+}
+
+// NewSekolahService resolves requests that name no school to the one with
+// defaultKode, so a deployment with a single school needs no setup.
+func NewSekolahService(sekolahRepository repository.SekolahRepository, userRepository repository.UserRepository, DB *sql.DB, validate *validator.Validate, defaultKode string) SekolahService {
+	return &SekolahServiceImpl{
+		SekolahRepository: sekolahRepository,
+		UserRepository:    userRepository,
+		DB:                DB,
+		Validate:          validate,
+		DefaultKode:       defaultKode,
+	}
+}
+
+func (service *SekolahServiceImpl) Create(ctx context.Context, request web.SekolahCreateRequest) web.SekolahResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	sekolah, err := service.SekolahRepository.Save(ctx, tx, domain.Sekolah{
+		Kode:          request.Kode,
+		Nama:          request.Nama,
+		Bahasa:        request.Bahasa,
+		KuotaSiswa:    request.KuotaSiswa,
+		KuotaZonasi:   request.KuotaZonasi,
+		KuotaPrestasi: request.KuotaPrestasi,
+		KuotaAfirmasi: request.KuotaAfirmasi,
+		CreatedAt:     time.Now(),
+	})
+	if helper.IsDuplicateKey(err) {
+		panic(exception.NewConflictError("kode " + request.Kode + " is already taken"))
+	}
+	helper.PanicIfError(err)
+
+	return helper.ToSekolahResponse(sekolah)
+}
+
+func (service *SekolahServiceImpl) Update(ctx context.Context, request web.SekolahUpdateRequest) web.SekolahResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	sekolah, err := service.SekolahRepository.FindById(ctx, tx, request.Id)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+
+	updatedAt := time.Now()
+	sekolah.Nama = request.Nama
+	sekolah.Bahasa = request.Bahasa
+	sekolah.KuotaSiswa = request.KuotaSiswa
+	sekolah.KuotaZonasi = request.KuotaZonasi
+	sekolah.KuotaPrestasi = request.KuotaPrestasi
+	sekolah.KuotaAfirmasi = request.KuotaAfirmasi
+	sekolah.UpdatedAt = &updatedAt
+	sekolah = service.SekolahRepository.Update(ctx, tx, sekolah)
+
+	return helper.ToSekolahResponse(sekolah)
+}
+
+func (service *SekolahServiceImpl) FindById(ctx context.Context, sekolahId int) web.SekolahResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	sekolah, err := service.SekolahRepository.FindById(ctx, tx, sekolahId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+
+	return helper.ToSekolahResponse(sekolah)
+}
+
+func (service *SekolahServiceImpl) FindAll(ctx context.Context) []web.SekolahResponse {
+	return helper.ToSekolahResponses(service.findAll(ctx))
+}
+
+// FindCurrent returns the school the request was resolved to.
+func (service *SekolahServiceImpl) FindCurrent(ctx context.Context) web.SekolahResponse {
+	return helper.ToSekolahResponse(currentSekolah(ctx))
+}
+
+// Resolve returns the school a request of username works on given the kode
+// it asked for, if any, and whether username may only work on that school.
+// Users of one school always get theirs and are refused any other; staff of
+// the yayasan, and actors that are no user such as the bootstrap admin, get
+// the school they asked for or the default one.
+func (service *SekolahServiceImpl) Resolve(ctx context.Context, username string, kode string) (domain.Sekolah, bool) {
+	tx, err := service.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	helper.PanicIfError(err)
+	defer tx.Rollback()
+
+	user, err := service.UserRepository.FindByUsername(ctx, tx, username)
+	if err == nil && user.SekolahId != 0 {
+		if kode != "" && kode != user.Sekolah {
+			panic(exception.NewForbiddenError(username + " may not work on sekolah " + kode))
+		}
+		sekolah, err := service.SekolahRepository.FindById(ctx, tx, user.SekolahId)
+		helper.PanicIfError(err)
+		return sekolah, true
+	}
+
+	if kode == "" {
+		kode = service.DefaultKode
+	}
+	sekolah, err := service.SekolahRepository.FindByKode(ctx, tx, kode)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	return sekolah, false
+}
+
+// ForEach calls fn once per school with ctx scoped to it, for work such as
+// purging that spans the whole yayasan.
+func (service *SekolahServiceImpl) ForEach(ctx context.Context, fn func(ctx context.Context, sekolah domain.Sekolah)) {
+	for _, sekolah := range service.findAll(ctx) {
+		fn(helper.WithSekolah(ctx, sekolah, false), sekolah)
+	}
+}
+
+func (service *SekolahServiceImpl) findAll(ctx context.Context) []domain.Sekolah {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return service.SekolahRepository.FindAll(ctx, tx)
+}
+
+// currentSekolah is the school ctx is scoped to. Reaching a service without
+// one is a bug, as the tenant middleware scopes every request.
+func currentSekolah(ctx context.Context) domain.Sekolah {
+	sekolah, ok := helper.SekolahFromContext(ctx)
+	if !ok {
+		panic(errors.New("no sekolah in context"))
+	}
+	return sekolah
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+const (
+	BatchModeAtomic     = "atomic"
+	BatchModeBestEffort = "best_effort"
+)
+
+// Batch runs every operation in a single transaction. Each operation gets its
+// own savepoint, so in best effort mode a failing item is undone on its own,
+// while in atomic mode the first failure undoes the whole batch.
+func (service *SiswaServiceImpl) Batch(ctx context.Context, request web.SiswaBatchRequest) web.SiswaBatchResponse {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.Batch")
+	defer end()
+
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	if request.Mode == "" {
+		request.Mode = BatchModeAtomic
+	}
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	_, err = tx.ExecContext(ctx, "SAVEPOINT batch")
+	helper.PanicIfError(err)
+
+	batchResponse := web.SiswaBatchResponse{
+		Mode:      request.Mode,
+		Committed: true,
+		Results:   make([]web.SiswaBatchResult, len(request.Operations)),
+	}
+
+	for i, operation := range request.Operations {
+		if !batchResponse.Committed {
+			batchResponse.Results[i] = web.SiswaBatchResult{
+				Index:  i,
+				Action: operation.Action,
+				Code:   http.StatusFailedDependency,
+				Status: "SKIPPED",
+			}
+			continue
+		}
+
+		batchResponse.Results[i] = service.batchOperation(ctx, tx, i, operation)
+		if batchResponse.Results[i].Error != nil && request.Mode == BatchModeAtomic {
+			batchResponse.Committed = false
+		}
+	}
+
+	helper.Logger(ctx).WithFields(logrus.Fields{
+		"mode":       request.Mode,
+		"operations": len(request.Operations),
+		"committed":  batchResponse.Committed,
+	}).Info("siswa batch processed")
+
+	if !batchResponse.Committed {
+		helper.RollbackTo(ctx, tx, "batch")
+		for i, result := range batchResponse.Results {
+			if result.Error == nil && result.Status != "SKIPPED" {
+				batchResponse.Results[i].Code = http.StatusFailedDependency
+				batchResponse.Results[i].Status = "ROLLED BACK"
+				batchResponse.Results[i].Data = nil
+			}
+		}
+	}
+
+	return batchResponse
+}
+
+func (service *SiswaServiceImpl) batchOperation(ctx context.Context, tx *sql.Tx, index int, operation web.SiswaBatchOperation) web.SiswaBatchResult {
+	result := web.SiswaBatchResult{
+		Index:  index,
+		Action: operation.Action,
+		Code:   http.StatusOK,
+		Status: "OK",
+	}
+
+	err := helper.Savepoint(ctx, tx, "batch_item_"+strconv.Itoa(index), func() {
+		switch operation.Action {
+		case "create":
+			request := batchData(operation)
+			err := service.Validate.Struct(request)
+			helper.PanicIfError(err)
+
+			siswaResponse := service.create(ctx, tx, request)
+			result.Data = &siswaResponse
+		case "update":
+			data := batchData(operation)
+			request := web.SiswaUpdateRequest{
+				Id:            operation.Id,
+				Nama:          data.Nama,
+				Alamat:        data.Alamat,
+				TanggalLahir:  data.TanggalLahir,
+				TempatLahir:   data.TempatLahir,
+				JenisKelamin:  data.JenisKelamin,
+				Agama:         data.Agama,
+				GolonganDarah: data.GolonganDarah,
+				NoTelepon:     data.NoTelepon,
+				Version:       operation.Version,
+			}
+			err := service.Validate.Struct(request)
+			helper.PanicIfError(err)
+
+			siswaResponse := service.update(ctx, tx, request)
+			result.Data = &siswaResponse
+		case "delete":
+			service.delete(ctx, tx, operation.Id, operation.Version)
+		}
+	})
+
+	if err != nil {
+		webResponse := exception.ToWebResponse(err)
+		result.Code = webResponse.Code
+		result.Status = webResponse.Status
+		result.Error = webResponse.Data
+	}
+	return result
+}
+
+func batchData(operation web.SiswaBatchOperation) web.SiswaCreateRequest {
+	if operation.Data == nil {
+		panic(exception.NewBadRequestError("data is required for " + operation.Action))
+	}
+	return *operation.Data
+}
@@ -2,13 +2,24 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/helper"
 	"github.com/Arraf18/go-sisko/model/web"
+	"time"
 )
 
 type SiswaService interface {
 	Create(ctx context.Context, request web.SiswaCreateRequest) web.SiswaResponse
+	CreateTx(ctx context.Context, tx *sql.Tx, onCommit *helper.OnCommit, request web.SiswaCreateRequest) web.SiswaResponse
 	Update(ctx context.Context, request web.SiswaUpdateRequest) web.SiswaResponse
-	Delete(ctx context.Context, siswaId int)
+	Delete(ctx context.Context, siswaId int, version int)
+	Batch(ctx context.Context, request web.SiswaBatchRequest) web.SiswaBatchResponse
 	FindById(ctx context.Context, siswaId int) web.SiswaResponse
+	FindByIds(ctx context.Context, siswaIds []int) []web.SiswaResponse
 	FindAll(ctx context.Context) []web.SiswaResponse
+	FindTrash(ctx context.Context) []web.SiswaResponse
+	Search(ctx context.Context, request web.SiswaSearchRequest) web.SiswaPageResponse
+	Restore(ctx context.Context, siswaId int) web.SiswaResponse
+	Purge(ctx context.Context, retention time.Duration) int
+	Statistics(ctx context.Context) web.SiswaStatisticsResponse
 }
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/gob"
+	"github.com/Arraf18/go-sisko/cache"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// SiswaServiceCache serves FindById, FindByIds, FindAll and FindTrash from Cache and
+// drops the affected entries after every change made through it. A read
+// racing a write may put an old value back, which TTL bounds. Keys start
+// with the school, so schools never share an entry.
+type SiswaServiceCache struct {
+	SiswaService
+	Cache cache.Cache
+	TTL   time.Duration
+}
+
+func NewSiswaServiceCache(siswaService SiswaService, cache cache.Cache, ttl time.Duration) SiswaService {
+	return &SiswaServiceCache{SiswaService: siswaService, Cache: cache, TTL: ttl}
+}
+
+func (service *SiswaServiceCache) Create(ctx context.Context, request web.SiswaCreateRequest) web.SiswaResponse {
+	defer service.invalidate(ctx, siswaCacheKey(ctx, "all"))
+	return service.SiswaService.Create(ctx, request)
+}
+
+// CreateTx drops the list once tx has committed: dropping it before would
+// let a read in between cache the list without the new siswa.
+func (service *SiswaServiceCache) CreateTx(ctx context.Context, tx *sql.Tx, onCommit *helper.OnCommit, request web.SiswaCreateRequest) web.SiswaResponse {
+	onCommit.Add(func() {
+		service.invalidate(ctx, siswaCacheKey(ctx, "all"))
+	})
+	return service.SiswaService.CreateTx(ctx, tx, onCommit, request)
+}
+
+func (service *SiswaServiceCache) Update(ctx context.Context, request web.SiswaUpdateRequest) web.SiswaResponse {
+	defer service.invalidate(ctx, siswaIdCacheKey(ctx, request.Id), siswaCacheKey(ctx, "all"))
+	return service.SiswaService.Update(ctx, request)
+}
+
+func (service *SiswaServiceCache) Delete(ctx context.Context, siswaId int, version int) {
+	defer service.invalidate(ctx, siswaIdCacheKey(ctx, siswaId), siswaCacheKey(ctx, "all"), siswaCacheKey(ctx, "trash"))
+	service.SiswaService.Delete(ctx, siswaId, version)
+}
+
+func (service *SiswaServiceCache) Batch(ctx context.Context, request web.SiswaBatchRequest) web.SiswaBatchResponse {
+	keys := []string{siswaCacheKey(ctx, "all"), siswaCacheKey(ctx, "trash")}
+	for _, operation := range request.Operations {
+		if operation.Id != 0 {
+			keys = append(keys, siswaIdCacheKey(ctx, operation.Id))
+		}
+	}
+	defer service.invalidate(ctx, keys...)
+	return service.SiswaService.Batch(ctx, request)
+}
+
+func (service *SiswaServiceCache) Restore(ctx context.Context, siswaId int) web.SiswaResponse {
+	defer service.invalidate(ctx, siswaIdCacheKey(ctx, siswaId), siswaCacheKey(ctx, "all"), siswaCacheKey(ctx, "trash"))
+	return service.SiswaService.Restore(ctx, siswaId)
+}
+
+func (service *SiswaServiceCache) Purge(ctx context.Context, retention time.Duration) int {
+	defer service.invalidate(ctx, siswaCacheKey(ctx, "trash"))
+	return service.SiswaService.Purge(ctx, retention)
+}
+
+func (service *SiswaServiceCache) FindById(ctx context.Context, siswaId int) web.SiswaResponse {
+	var siswaResponse web.SiswaResponse
+	service.cached(ctx, siswaIdCacheKey(ctx, siswaId), &siswaResponse, func() interface{} {
+		return service.SiswaService.FindById(ctx, siswaId)
+	})
+	return siswaResponse
+}
+
+// FindByIds answers from the entries FindById keeps and loads the rest with
+// one call, caching each of them.
+func (service *SiswaServiceCache) FindByIds(ctx context.Context, siswaIds []int) []web.SiswaResponse {
+	var siswaResponses []web.SiswaResponse
+	var missing []int
+	for _, siswaId := range siswaIds {
+		value, ok, err := service.Cache.Get(ctx, siswaIdCacheKey(ctx, siswaId))
+		if err != nil {
+			helper.Logger(ctx).WithField("error", err).Warn("reading cache failed")
+		}
+		siswaResponse := web.SiswaResponse{}
+		if ok && gob.NewDecoder(bytes.NewReader(value)).Decode(&siswaResponse) == nil {
+			siswaResponses = append(siswaResponses, siswaResponse)
+		} else {
+			missing = append(missing, siswaId)
+		}
+	}
+	if len(missing) == 0 {
+		return siswaResponses
+	}
+
+	for _, siswaResponse := range service.SiswaService.FindByIds(ctx, missing) {
+		buffer := &bytes.Buffer{}
+		err := gob.NewEncoder(buffer).Encode(siswaResponse)
+		helper.PanicIfError(err)
+
+		err = service.Cache.Set(ctx, siswaIdCacheKey(ctx, siswaResponse.Id), buffer.Bytes(), service.TTL)
+		if err != nil {
+			helper.Logger(ctx).WithField("error", err).Warn("writing cache failed")
+		}
+		siswaResponses = append(siswaResponses, siswaResponse)
+	}
+	return siswaResponses
+}
+
+func (service *SiswaServiceCache) FindAll(ctx context.Context) []web.SiswaResponse {
+	var siswaResponses []web.SiswaResponse
+	service.cached(ctx, siswaCacheKey(ctx, "all"), &siswaResponses, func() interface{} {
+		return service.SiswaService.FindAll(ctx)
+	})
+	return siswaResponses
+}
+
+func (service *SiswaServiceCache) FindTrash(ctx context.Context) []web.SiswaResponse {
+	var siswaResponses []web.SiswaResponse
+	service.cached(ctx, siswaCacheKey(ctx, "trash"), &siswaResponses, func() interface{} {
+		return service.SiswaService.FindTrash(ctx)
+	})
+	return siswaResponses
+}
+
+// cached decodes key into result, or stores what load returns. Values are
+// gob encoded because the json form of SiswaResponse leaves out Version,
+// which the ETag is made of. A failing cache only costs the lookup.
+func (service *SiswaServiceCache) cached(ctx context.Context, key string, result interface{}, load func() interface{}) {
+	ctx, end := helper.StartSpan(ctx, "SiswaServiceCache.Get", attribute.String("cache.key", key))
+	defer end()
+	span := trace.SpanFromContext(ctx)
+	logger := helper.Logger(ctx).WithField("cache_key", key)
+
+	value, ok, err := service.Cache.Get(ctx, key)
+	if err != nil {
+		logger.WithField("error", err).Warn("reading cache failed")
+	}
+	if ok && gob.NewDecoder(bytes.NewReader(value)).Decode(result) == nil {
+		span.SetAttributes(attribute.Bool("cache.hit", true))
+		return
+	}
+	span.SetAttributes(attribute.Bool("cache.hit", false))
+
+	loaded := load()
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(loaded))
+
+	buffer := &bytes.Buffer{}
+	err = gob.NewEncoder(buffer).Encode(loaded)
+	helper.PanicIfError(err)
+
+	err = service.Cache.Set(ctx, key, buffer.Bytes(), service.TTL)
+	if err != nil {
+		logger.WithField("error", err).Warn("writing cache failed")
+	}
+}
+
+// invalidate runs deferred, so entries are dropped even when the wrapped
+// call panics after part of a batch was committed.
+func (service *SiswaServiceCache) invalidate(ctx context.Context, keys ...string) {
+	err := service.Cache.Delete(ctx, keys...)
+	if err != nil {
+		helper.Logger(ctx).WithField("error", err).WithField("cache_keys", keys).Error("invalidating cache failed, stale entries expire after the ttl")
+	}
+}
+
+// siswaCacheKey is the key of name in the school of ctx, such as
+// "sekolah:1:siswa:all".
+func siswaCacheKey(ctx context.Context, name string) string {
+	return "sekolah:" + strconv.Itoa(currentSekolah(ctx).Id) + ":siswa:" + name
+}
+
+func siswaIdCacheKey(ctx context.Context, siswaId int) string {
+	return siswaCacheKey(ctx, strconv.Itoa(siswaId))
+}
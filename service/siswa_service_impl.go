@@ -3,29 +3,39 @@ package service
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"github.com/Arraf18/go-sisko/exception"
 	"github.com/Arraf18/go-sisko/helper"
 	"github.com/Arraf18/go-sisko/model/domain"
 	"github.com/Arraf18/go-sisko/model/web"
 	"github.com/Arraf18/go-sisko/repository"
 	"github.com/go-playground/validator"
+	"strconv"
+	"time"
 )
 
 type SiswaServiceImpl struct {
-	SiswaRepository repository.SiswaRepository
-	DB              *sql.DB
-	Validate        *validator.Validate
+	SiswaRepository       repository.SiswaRepository
+	AuditRepository       repository.AuditRepository
+	OutboxEventRepository repository.OutboxEventRepository
+	DB                    *sql.DB
+	Validate              *validator.Validate
 }
 
-func NewSiswaService(siswaRepository repository.SiswaRepository, DB *sql.DB, validate *validator.Validate) SiswaService {
+func NewSiswaService(siswaRepository repository.SiswaRepository, auditRepository repository.AuditRepository, outboxEventRepository repository.OutboxEventRepository, DB *sql.DB, validate *validator.Validate) SiswaService {
 	return &SiswaServiceImpl{
-		SiswaRepository: siswaRepository,
-		DB:              DB,
-		Validate:        validate,
+		SiswaRepository:       siswaRepository,
+		AuditRepository:       auditRepository,
+		OutboxEventRepository: outboxEventRepository,
+		DB:                    DB,
+		Validate:              validate,
 	}
 }
 
 func (service *SiswaServiceImpl) Create(ctx context.Context, request web.SiswaCreateRequest) web.SiswaResponse {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.Create")
+	defer end()
+
 	err := service.Validate.Struct(request)
 	helper.PanicIfError(err)
 
@@ -33,6 +43,48 @@ func (service *SiswaServiceImpl) Create(ctx context.Context, request web.SiswaCr
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
+	return service.create(ctx, tx, request)
+}
+
+// CreateTx is Create within tx, for a siswa that must commit or roll back
+// together with other changes, its audit and event included. The caller
+// runs onCommit once tx has committed.
+func (service *SiswaServiceImpl) CreateTx(ctx context.Context, tx *sql.Tx, onCommit *helper.OnCommit, request web.SiswaCreateRequest) web.SiswaResponse {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.CreateTx")
+	defer end()
+
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	return service.create(ctx, tx, request)
+}
+
+func (service *SiswaServiceImpl) Update(ctx context.Context, request web.SiswaUpdateRequest) web.SiswaResponse {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.Update")
+	defer end()
+
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return service.update(ctx, tx, request)
+}
+
+func (service *SiswaServiceImpl) Delete(ctx context.Context, siswaId int, version int) {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.Delete")
+	defer end()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	service.delete(ctx, tx, siswaId, version)
+}
+
+func (service *SiswaServiceImpl) create(ctx context.Context, tx *sql.Tx, request web.SiswaCreateRequest) web.SiswaResponse {
 	siswa := domain.Siswa{
 		Nama:          request.Nama,
 		Alamat:        request.Alamat,
@@ -45,22 +97,20 @@ func (service *SiswaServiceImpl) Create(ctx context.Context, request web.SiswaCr
 	}
 
 	siswa = service.SiswaRepository.Save(ctx, tx, siswa)
+	service.checkKuota(ctx, tx)
+	service.audit(ctx, tx, "create", domain.Siswa{}, siswa)
+	service.event(ctx, tx, domain.EventSiswaCreated, domain.Siswa{}, siswa)
 
 	return helper.ToSiswaResponse(siswa)
 }
 
-func (service *SiswaServiceImpl) Update(ctx context.Context, request web.SiswaUpdateRequest) web.SiswaResponse {
-	err := service.Validate.Struct(request)
-	helper.PanicIfError(err)
-
-	tx, err := service.DB.Begin()
-	helper.PanicIfError(err)
-	defer helper.CommitOrRollback(tx)
-
+func (service *SiswaServiceImpl) update(ctx context.Context, tx *sql.Tx, request web.SiswaUpdateRequest) web.SiswaResponse {
 	siswa, err := service.SiswaRepository.FindById(ctx, tx, request.Id)
 	if err != nil {
 		panic(exception.NewNotFoundError(err.Error()))
 	}
+	checkVersion(siswa, request.Version)
+	before := siswa
 
 	siswa.Nama = request.Nama
 	siswa.Alamat = request.Alamat
@@ -71,25 +121,40 @@ func (service *SiswaServiceImpl) Update(ctx context.Context, request web.SiswaUp
 	siswa.GolonganDarah = request.GolonganDarah
 	siswa.NoTelepon = request.NoTelepon
 
-	siswa = service.SiswaRepository.Update(ctx, tx, siswa)
+	siswa, err = service.SiswaRepository.Update(ctx, tx, siswa)
+	if err != nil {
+		panic(exception.NewPreconditionFailedError(err.Error()))
+	}
+	service.audit(ctx, tx, "update", before, siswa)
+	service.event(ctx, tx, domain.EventSiswaUpdated, before, siswa)
 
 	return helper.ToSiswaResponse(siswa)
 }
 
-func (service *SiswaServiceImpl) Delete(ctx context.Context, siswaId int) {
-	tx, err := service.DB.Begin()
-	helper.PanicIfError(err)
-	defer helper.CommitOrRollback(tx)
-
+func (service *SiswaServiceImpl) delete(ctx context.Context, tx *sql.Tx, siswaId int, version int) {
 	siswa, err := service.SiswaRepository.FindById(ctx, tx, siswaId)
 	if err != nil {
 		panic(exception.NewNotFoundError(err.Error()))
 	}
+	checkVersion(siswa, version)
+	before := siswa
 
-	service.SiswaRepository.Delete(ctx, tx, siswa)
+	deletedAt := time.Now()
+	siswa.DeletedAt = &deletedAt
+	siswa.DeletedBy = helper.ActorFromContext(ctx)
+
+	err = service.SiswaRepository.Delete(ctx, tx, siswa)
+	if err != nil {
+		panic(exception.NewPreconditionFailedError(err.Error()))
+	}
+	service.audit(ctx, tx, "delete", before, siswa)
+	service.event(ctx, tx, domain.EventSiswaDeleted, before, siswa)
 }
 
 func (service *SiswaServiceImpl) FindById(ctx context.Context, siswaId int) web.SiswaResponse {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.FindById")
+	defer end()
+
 	tx, err := service.DB.Begin()
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
@@ -102,12 +167,202 @@ func (service *SiswaServiceImpl) FindById(ctx context.Context, siswaId int) web.
 	return helper.ToSiswaResponse(siswa)
 }
 
+// FindByIds leaves out ids that are unknown or soft deleted instead of
+// failing like FindById.
+func (service *SiswaServiceImpl) FindByIds(ctx context.Context, siswaIds []int) []web.SiswaResponse {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.FindByIds")
+	defer end()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	siswas := service.SiswaRepository.FindByIds(ctx, tx, siswaIds)
+	return helper.ToSiswaResponses(siswas)
+}
+
 func (service *SiswaServiceImpl) FindAll(ctx context.Context) []web.SiswaResponse {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.FindAll")
+	defer end()
+
 	tx, err := service.DB.Begin()
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
-	siswas := service.SiswaRepository.FindByAll(ctx, tx)
+	siswas := service.SiswaRepository.FindAll(ctx, tx)
 
 	return helper.ToSiswaResponses(siswas)
 }
+
+func (service *SiswaServiceImpl) FindTrash(ctx context.Context) []web.SiswaResponse {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.FindTrash")
+	defer end()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	siswas := service.SiswaRepository.FindTrash(ctx, tx)
+
+	return helper.ToSiswaResponses(siswas)
+}
+
+// Search reads one row past the page to tell whether another page follows.
+func (service *SiswaServiceImpl) Search(ctx context.Context, request web.SiswaSearchRequest) web.SiswaPageResponse {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.Search")
+	defer end()
+
+	filter := domain.SiswaFilter{
+		Nama:             request.Nama,
+		JenisKelamin:     request.JenisKelamin,
+		Agama:            request.Agama,
+		GolonganDarah:    request.GolonganDarah,
+		TempatLahir:      request.TempatLahir,
+		TanggalLahirFrom: request.TanggalLahirFrom,
+		TanggalLahirTo:   request.TanggalLahirTo,
+		Trash:            request.Trash,
+		AfterId:          request.After,
+		Limit:            request.First + 1,
+	}
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	siswas := service.SiswaRepository.Search(ctx, tx, filter)
+	page := web.SiswaPageResponse{
+		TotalCount:  service.SiswaRepository.Count(ctx, tx, filter),
+		HasNextPage: len(siswas) > request.First,
+	}
+	if page.HasNextPage {
+		siswas = siswas[:request.First]
+	}
+	page.Siswas = helper.ToSiswaResponses(siswas)
+	return page
+}
+
+func (service *SiswaServiceImpl) Restore(ctx context.Context, siswaId int) web.SiswaResponse {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.Restore")
+	defer end()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	siswa, err := service.SiswaRepository.FindTrashById(ctx, tx, siswaId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+
+	before := siswa
+	siswa = service.SiswaRepository.Restore(ctx, tx, siswa)
+	service.checkKuota(ctx, tx)
+	service.audit(ctx, tx, "restore", before, siswa)
+	service.event(ctx, tx, domain.EventSiswaRestored, before, siswa)
+
+	return helper.ToSiswaResponse(siswa)
+}
+
+// Purge deletes the siswa in the trash for longer than retention for good,
+// with an audit and an event for each of them like any other delete.
+func (service *SiswaServiceImpl) Purge(ctx context.Context, retention time.Duration) int {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.Purge")
+	defer end()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	siswas := service.SiswaRepository.FindTrashBefore(ctx, tx, time.Now().Add(-retention))
+	for _, siswa := range siswas {
+		service.SiswaRepository.Purge(ctx, tx, siswa)
+		service.audit(ctx, tx, "purge", siswa, siswa)
+		service.event(ctx, tx, domain.EventSiswaPurged, siswa, siswa)
+	}
+	return len(siswas)
+}
+
+func (service *SiswaServiceImpl) Statistics(ctx context.Context) web.SiswaStatisticsResponse {
+	ctx, end := helper.StartSpan(ctx, "SiswaService.Statistics")
+	defer end()
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return web.SiswaStatisticsResponse{
+		ActiveByJenisKelamin: service.SiswaRepository.CountByJenisKelamin(ctx, tx),
+		Trash:                service.SiswaRepository.CountTrash(ctx, tx),
+	}
+}
+
+// audit records who changed a siswa inside the transaction of the change
+// itself, so the trail can never disagree with the data.
+func (service *SiswaServiceImpl) audit(ctx context.Context, tx *sql.Tx, action string, before domain.Siswa, after domain.Siswa) {
+	entityId := after.Id
+	if entityId == 0 {
+		entityId = before.Id
+	}
+
+	service.AuditRepository.Save(ctx, tx, domain.Audit{
+		SekolahId: currentSekolah(ctx).Id,
+		Entity:    "siswa",
+		EntityId:  entityId,
+		Action:    action,
+		Actor:     helper.ActorFromContext(ctx),
+		RequestId: helper.RequestIdFromContext(ctx),
+		Changes:   helper.DiffSiswa(before, after),
+		CreatedAt: time.Now(),
+	})
+}
+
+// event adds the change to the outbox in the transaction of the change, so
+// it is published if and only if it was committed; see outbox.Relay.
+func (service *SiswaServiceImpl) event(ctx context.Context, tx *sql.Tx, eventType string, before domain.Siswa, after domain.Siswa) {
+	payload, err := json.Marshal(web.SiswaEventPayload{
+		Sekolah: currentSekolah(ctx).Kode,
+		Siswa:   helper.ToSiswaResponseV2(helper.ToSiswaResponse(after)),
+		Changes: helper.ToAuditChangeResponses(helper.DiffSiswa(before, after)),
+	})
+	helper.PanicIfError(err)
+
+	service.OutboxEventRepository.Save(ctx, tx, domain.OutboxEvent{
+		AggregateType: "siswa",
+		AggregateId:   after.Id,
+		EventType:     eventType,
+		Payload:       payload,
+		Actor:         helper.ActorFromContext(ctx),
+		RequestId:     helper.RequestIdFromContext(ctx),
+		CreatedAt:     time.Now(),
+	})
+}
+
+// checkKuota refuses a siswa the school has no room for. It runs after the
+// write, whose transaction it rolls back; two writes racing for the last
+// place may both get it.
+func (service *SiswaServiceImpl) checkKuota(ctx context.Context, tx *sql.Tx) {
+	kuota := currentSekolah(ctx).KuotaSiswa
+	if kuota == 0 {
+		return
+	}
+
+	active := 0
+	for _, count := range service.SiswaRepository.CountByJenisKelamin(ctx, tx) {
+		active += count
+	}
+	if active > kuota {
+		panic(exception.NewUnprocessableEntityError("sekolah is full, its kuota is " + strconv.Itoa(kuota) + " siswa"))
+	}
+}
+
+// checkVersion compares the version sent by the client in If-Match with the
+// stored one, so a stale write never silently overwrites a newer change.
+// "If-Match: *" accepts any version of a siswa that exists.
+func checkVersion(siswa domain.Siswa, version int) {
+	if version == 0 {
+		panic(exception.NewPreconditionRequiredError("If-Match header is required"))
+	}
+	if version != helper.AnyVersion && version != siswa.Version {
+		panic(exception.NewPreconditionFailedError("siswa has been modified"))
+	}
+}
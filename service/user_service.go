@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Arraf18/go-sisko/model/web"
+)
+
+// ErrInvalidApiKey is returned by Authenticate for unknown or revoked keys.
+var ErrInvalidApiKey = errors.New("missing or invalid X-API-Key")
+
+type UserService interface {
+	Create(ctx context.Context, request web.UserCreateRequest) web.UserResponse
+	FindAll(ctx context.Context) []web.UserResponse
+	CreateApiKey(ctx context.Context, request web.ApiKeyCreateRequest) web.ApiKeyCreateResponse
+	FindAllApiKeys(ctx context.Context) []web.ApiKeyResponse
+	RevokeApiKey(ctx context.Context, apiKeyId int)
+	Authenticate(ctx context.Context, key string) (string, error)
+}
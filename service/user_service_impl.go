@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/go-playground/validator"
+	"time"
+)
+
+// apiKeyLength is the length of a generated key: 32 random bytes in hex.
+const apiKeyLength = 64
+
+// BootstrapActor is the actor of requests made with the bootstrap key.
+const BootstrapActor = "admin"
+
+type UserServiceImpl struct {
+	UserRepository    repository.UserRepository
+	ApiKeyRepository  repository.ApiKeyRepository
+	SekolahRepository repository.SekolahRepository
+	DB                *sql.DB
+	Validate          *validator.Validate
+	BootstrapKey      string
+}
+
+// NewUserService accepts bootstrapKey as a key of the admin user in addition
+// to the keys in the database, so a fresh install can be used before any
+// user exists. An empty bootstrapKey disables it.
+func NewUserService(userRepository repository.UserRepository, apiKeyRepository repository.ApiKeyRepository, sekolahRepository repository.SekolahRepository, DB *sql.DB, validate *validator.Validate, bootstrapKey string) UserService {
+	return &UserServiceImpl{
+		UserRepository:    userRepository,
+		ApiKeyRepository:  apiKeyRepository,
+		SekolahRepository: sekolahRepository,
+		DB:                DB,
+		Validate:          validate,
+		BootstrapKey:      bootstrapKey,
+	}
+}
+
+func (service *UserServiceImpl) Create(ctx context.Context, request web.UserCreateRequest) web.UserResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	user := domain.User{
+		Username:  request.Username,
+		Nama:      request.Nama,
+		CreatedAt: time.Now(),
+	}
+	if request.Sekolah != "" {
+		sekolah, err := service.SekolahRepository.FindByKode(ctx, tx, request.Sekolah)
+		if err != nil {
+			panic(exception.NewNotFoundError(err.Error()))
+		}
+		user.SekolahId = sekolah.Id
+		user.Sekolah = sekolah.Kode
+	}
+
+	user, err = service.UserRepository.Save(ctx, tx, user)
+	if helper.IsDuplicateKey(err) {
+		panic(exception.NewConflictError("username " + request.Username + " is already taken"))
+	}
+	helper.PanicIfError(err)
+
+	return helper.ToUserResponse(user)
+}
+
+func (service *UserServiceImpl) FindAll(ctx context.Context) []web.UserResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	users := service.UserRepository.FindAll(ctx, tx)
+	return helper.ToUserResponses(users)
+}
+
+func (service *UserServiceImpl) CreateApiKey(ctx context.Context, request web.ApiKeyCreateRequest) web.ApiKeyCreateResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	user, err := service.UserRepository.FindByUsername(ctx, tx, request.Username)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+
+	random := make([]byte, apiKeyLength/2)
+	_, err = rand.Read(random)
+	helper.PanicIfError(err)
+	key := hex.EncodeToString(random)
+
+	apiKey := service.ApiKeyRepository.Save(ctx, tx, domain.ApiKey{
+		UserId:    user.Id,
+		Username:  user.Username,
+		Name:      request.Name,
+		Prefix:    key[:8],
+		KeyHash:   hashApiKey(key),
+		CreatedAt: time.Now(),
+	})
+
+	return web.ApiKeyCreateResponse{ApiKeyResponse: helper.ToApiKeyResponse(apiKey), Key: key}
+}
+
+func (service *UserServiceImpl) FindAllApiKeys(ctx context.Context) []web.ApiKeyResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	apiKeys := service.ApiKeyRepository.FindAll(ctx, tx)
+	return helper.ToApiKeyResponses(apiKeys)
+}
+
+func (service *UserServiceImpl) RevokeApiKey(ctx context.Context, apiKeyId int) {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	err = service.ApiKeyRepository.Revoke(ctx, tx, apiKeyId, time.Now())
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+}
+
+// Authenticate returns the username owning key, or ErrInvalidApiKey for a
+// key that is unknown or revoked.
+func (service *UserServiceImpl) Authenticate(ctx context.Context, key string) (string, error) {
+	if service.BootstrapKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(service.BootstrapKey)) == 1 {
+		return BootstrapActor, nil
+	}
+	// keys that cannot have been generated are rejected without a query
+	if len(key) != apiKeyLength {
+		return "", ErrInvalidApiKey
+	}
+	if _, err := hex.DecodeString(key); err != nil {
+		return "", ErrInvalidApiKey
+	}
+
+	tx, err := service.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	helper.PanicIfError(err)
+	defer tx.Rollback()
+
+	apiKey, err := service.ApiKeyRepository.FindActiveByHash(ctx, tx, hashApiKey(key))
+	if err != nil {
+		return "", ErrInvalidApiKey
+	}
+	return apiKey.Username, nil
+}
+
+func hashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
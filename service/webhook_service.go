@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/outbox"
+)
+
+type WebhookService interface {
+	Create(ctx context.Context, request web.WebhookCreateRequest) web.WebhookCreateResponse
+	Update(ctx context.Context, request web.WebhookUpdateRequest) web.WebhookResponse
+	Delete(ctx context.Context, webhookId int)
+	FindById(ctx context.Context, webhookId int) web.WebhookResponse
+	FindAll(ctx context.Context) []web.WebhookResponse
+	FindDeliveries(ctx context.Context, webhookId int) []web.WebhookDeliveryResponse
+	Replay(ctx context.Context, webhookId int, deliveryId int64) web.WebhookDeliveryResponse
+	Enqueue(ctx context.Context, event outbox.Event) int
+	DeliverPending(ctx context.Context) int
+}
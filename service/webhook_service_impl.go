@@ -0,0 +1,345 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/outbox"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// webhookSecretLength is the length of a generated secret: 32 random
+	// bytes in hex.
+	webhookSecretLength = 64
+	// webhookLogSize is how many of the latest deliveries FindDeliveries shows.
+	webhookLogSize = 100
+
+	webhookLock        = "sisko_webhook_dispatcher"
+	webhookBatchSize   = 100
+	webhookMaxAttempts = 8
+	webhookMinBackoff  = 30 * time.Second
+	webhookMaxBackoff  = time.Hour
+)
+
+// WebhookServiceImpl delivers events to the subscribed URLs. A delivery is
+// attempted up to webhookMaxAttempts times, 30 seconds after the first
+// failure and twice as long after every next one, up to an hour. Deliveries
+// of one siswa to one subscription go out in order. A subscription whose
+// last DisableAfter attempts all failed is disabled; its pending deliveries
+// resume once it is enabled again.
+type WebhookServiceImpl struct {
+	WebhookSubscriptionRepository repository.WebhookSubscriptionRepository
+	WebhookDeliveryRepository     repository.WebhookDeliveryRepository
+	DB                            *sql.DB
+	Validate                      *validator.Validate
+	Client                        *http.Client
+	DisableAfter                  int
+}
+
+func NewWebhookService(webhookSubscriptionRepository repository.WebhookSubscriptionRepository, webhookDeliveryRepository repository.WebhookDeliveryRepository, DB *sql.DB, validate *validator.Validate, client *http.Client, disableAfter int) WebhookService {
+	return &WebhookServiceImpl{
+		WebhookSubscriptionRepository: webhookSubscriptionRepository,
+		WebhookDeliveryRepository:     webhookDeliveryRepository,
+		DB:                            DB,
+		Validate:                      validate,
+		Client:                        client,
+		DisableAfter:                  disableAfter,
+	}
+}
+
+func (service *WebhookServiceImpl) Create(ctx context.Context, request web.WebhookCreateRequest) web.WebhookCreateResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	secret := request.Secret
+	if secret == "" {
+		random := make([]byte, webhookSecretLength/2)
+		_, err = rand.Read(random)
+		helper.PanicIfError(err)
+		secret = hex.EncodeToString(random)
+	}
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	now := time.Now()
+	subscription := service.WebhookSubscriptionRepository.Save(ctx, tx, domain.WebhookSubscription{
+		Url:        request.Url,
+		EventTypes: request.EventTypes,
+		Secret:     secret,
+		Active:     true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+
+	return web.WebhookCreateResponse{WebhookResponse: helper.ToWebhookResponse(subscription), Secret: secret}
+}
+
+func (service *WebhookServiceImpl) Update(ctx context.Context, request web.WebhookUpdateRequest) web.WebhookResponse {
+	err := service.Validate.Struct(request)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	subscription := service.findById(ctx, tx, request.Id)
+
+	now := time.Now()
+	if *request.Active && !subscription.Active {
+		subscription.ConsecutiveFailures = 0
+		subscription.DisabledAt = nil
+	} else if !*request.Active && subscription.Active {
+		subscription.DisabledAt = &now
+	}
+	subscription.Url = request.Url
+	subscription.EventTypes = request.EventTypes
+	subscription.Active = *request.Active
+	subscription.UpdatedAt = now
+	if request.Secret != "" {
+		subscription.Secret = request.Secret
+	}
+
+	subscription = service.WebhookSubscriptionRepository.Update(ctx, tx, subscription)
+	return helper.ToWebhookResponse(subscription)
+}
+
+func (service *WebhookServiceImpl) Delete(ctx context.Context, webhookId int) {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	subscription := service.findById(ctx, tx, webhookId)
+	service.WebhookSubscriptionRepository.Delete(ctx, tx, subscription)
+}
+
+func (service *WebhookServiceImpl) FindById(ctx context.Context, webhookId int) web.WebhookResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	return helper.ToWebhookResponse(service.findById(ctx, tx, webhookId))
+}
+
+func (service *WebhookServiceImpl) FindAll(ctx context.Context) []web.WebhookResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	subscriptions := service.WebhookSubscriptionRepository.FindAll(ctx, tx)
+	return helper.ToWebhookResponses(subscriptions)
+}
+
+func (service *WebhookServiceImpl) FindDeliveries(ctx context.Context, webhookId int) []web.WebhookDeliveryResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	service.findById(ctx, tx, webhookId)
+	deliveries := service.WebhookDeliveryRepository.FindBySubscription(ctx, tx, webhookId, webhookLogSize)
+	return helper.ToWebhookDeliveryResponses(deliveries)
+}
+
+// Replay sends a delivery again from scratch, whatever became of it. It goes
+// out on the next run of DeliverPending, possibly after later events of the
+// same siswa.
+func (service *WebhookServiceImpl) Replay(ctx context.Context, webhookId int, deliveryId int64) web.WebhookDeliveryResponse {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	subscription := service.findById(ctx, tx, webhookId)
+	delivery, err := service.WebhookDeliveryRepository.FindById(ctx, tx, deliveryId)
+	if err != nil || delivery.SubscriptionId != subscription.Id {
+		panic(exception.NewNotFoundError("delivery is not found"))
+	}
+	if !subscription.Active {
+		panic(exception.NewConflictError("webhook is disabled, enable it before replaying deliveries"))
+	}
+
+	delivery.Status = domain.DeliveryPending
+	delivery.Attempts = 0
+	delivery.ResponseStatus = 0
+	delivery.LastError = ""
+	delivery.NextAttemptAt = nil
+	delivery.DeliveredAt = nil
+	delivery = service.WebhookDeliveryRepository.Update(ctx, tx, delivery)
+	return helper.ToWebhookDeliveryResponse(delivery)
+}
+
+// Enqueue creates a delivery of event for every active subscription to its
+// type and returns how many it created. An event seen before creates none.
+func (service *WebhookServiceImpl) Enqueue(ctx context.Context, event outbox.Event) int {
+	payload, err := json.Marshal(event)
+	helper.PanicIfError(err)
+
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+
+	created := 0
+	now := time.Now()
+	for _, subscription := range service.WebhookSubscriptionRepository.FindActiveByEventType(ctx, tx, event.Type) {
+		_, saved := service.WebhookDeliveryRepository.Save(ctx, tx, domain.WebhookDelivery{
+			SubscriptionId: subscription.Id,
+			EventId:        event.Id,
+			EventType:      event.Type,
+			AggregateId:    event.AggregateId,
+			Payload:        payload,
+			Status:         domain.DeliveryPending,
+			CreatedAt:      now,
+		})
+		if saved {
+			created++
+		}
+	}
+	return created
+}
+
+// DeliverPending attempts up to one batch of due deliveries and returns how
+// many succeeded, 0 when another instance is already delivering.
+func (service *WebhookServiceImpl) DeliverPending(ctx context.Context) int {
+	conn, err := service.DB.Conn(ctx)
+	helper.PanicIfError(err)
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	err = conn.QueryRowContext(ctx, "select get_lock(?, 0)", webhookLock).Scan(&locked)
+	helper.PanicIfError(err)
+	if locked.Int64 != 1 {
+		return 0
+	}
+	defer conn.ExecContext(context.Background(), "select release_lock(?)", webhookLock)
+
+	var deliveries []domain.WebhookDelivery
+	subscriptions := map[int]domain.WebhookSubscription{}
+	service.inTx(func(tx *sql.Tx) {
+		deliveries = service.WebhookDeliveryRepository.FindPending(ctx, tx, time.Now(), webhookBatchSize)
+		for _, subscription := range service.WebhookSubscriptionRepository.FindAll(ctx, tx) {
+			subscriptions[subscription.Id] = subscription
+		}
+	})
+
+	delivered := 0
+	waiting := map[string]bool{}
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptions[delivery.SubscriptionId]
+		key := strconv.Itoa(delivery.SubscriptionId) + "/" + strconv.Itoa(delivery.AggregateId)
+		if !ok || !subscription.Active || waiting[key] {
+			continue
+		}
+
+		logger := logrus.WithFields(logrus.Fields{"webhook_id": subscription.Id, "delivery_id": delivery.Id, "event_type": delivery.EventType})
+		responseStatus, err := service.send(ctx, subscription, delivery)
+		delivery.Attempts++
+		delivery.ResponseStatus = responseStatus
+
+		if err == nil {
+			logger.Debug("webhook delivered")
+			deliveredAt := time.Now()
+			delivery.Status = domain.DeliverySucceeded
+			delivery.LastError = ""
+			delivery.NextAttemptAt = nil
+			delivery.DeliveredAt = &deliveredAt
+			service.inTx(func(tx *sql.Tx) {
+				service.WebhookDeliveryRepository.Update(ctx, tx, delivery)
+				service.WebhookSubscriptionRepository.RecordSuccess(ctx, tx, subscription.Id)
+			})
+			delivered++
+			continue
+		}
+
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= webhookMaxAttempts {
+			delivery.Status = domain.DeliveryFailed
+			delivery.NextAttemptAt = nil
+		} else {
+			waiting[key] = true
+			nextAttemptAt := time.Now().Add(webhookBackoff(delivery.Attempts))
+			delivery.NextAttemptAt = &nextAttemptAt
+		}
+		logger.WithFields(logrus.Fields{"error": err, "attempts": delivery.Attempts, "status": delivery.Status}).Warn("webhook delivery failed")
+
+		var disabled bool
+		service.inTx(func(tx *sql.Tx) {
+			service.WebhookDeliveryRepository.Update(ctx, tx, delivery)
+			disabled = service.WebhookSubscriptionRepository.RecordFailure(ctx, tx, subscription.Id, service.DisableAfter, time.Now())
+		})
+		if disabled {
+			logger.WithField("url", subscription.Url).Warn("webhook disabled after failing persistently")
+			subscription.Active = false
+			subscriptions[subscription.Id] = subscription
+		}
+	}
+	return delivered
+}
+
+// send POSTs the delivery and returns the status code answered, if any.
+func (service *WebhookServiceImpl) send(ctx context.Context, subscription domain.WebhookSubscription, delivery domain.WebhookDelivery) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "go-sisko-webhook")
+	request.Header.Set("X-Sisko-Event", delivery.EventType)
+	request.Header.Set("X-Sisko-Event-Id", strconv.FormatInt(delivery.EventId, 10))
+	request.Header.Set("X-Sisko-Delivery-Id", strconv.FormatInt(delivery.Id, 10))
+	request.Header.Set("X-Sisko-Timestamp", strconv.FormatInt(timestamp, 10))
+	request.Header.Set("X-Sisko-Signature", helper.SignWebhook(subscription.Secret, timestamp, delivery.Payload))
+
+	response, err := service.Client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("answered %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+func (service *WebhookServiceImpl) findById(ctx context.Context, tx *sql.Tx, webhookId int) domain.WebhookSubscription {
+	subscription, err := service.WebhookSubscriptionRepository.FindById(ctx, tx, webhookId)
+	if err != nil {
+		panic(exception.NewNotFoundError(err.Error()))
+	}
+	return subscription
+}
+
+func (service *WebhookServiceImpl) inTx(fn func(tx *sql.Tx)) {
+	tx, err := service.DB.Begin()
+	helper.PanicIfError(err)
+	defer helper.CommitOrRollback(tx)
+	fn(tx)
+}
+
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookMinBackoff
+	for i := 1; i < attempts && backoff < webhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > webhookMaxBackoff {
+		return webhookMaxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBodyLimitMiddlewareRejectsLargeBody(t *testing.T) {
+	router := httprouter.New()
+	router.PanicHandler = exception.ErrorHandler
+	router.POST("/api/siswas", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		siswaCreateRequest := web.SiswaCreateRequest{}
+		helper.ReadFromRequestBody(request, &siswaCreateRequest)
+		writer.WriteHeader(http.StatusOK)
+	})
+	handler := middleware.NewBodyLimitMiddleware(router, 16)
+
+	// without Content-Length the limit is hit while decoding
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/siswas", strings.NewReader(`{"nama" : "Gadget Gadget Gadget"}`))
+	request.Header.Add("Content-Type", "application/json")
+	request.ContentLength = -1
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 413, response.StatusCode)
+
+	body, _ := io.ReadAll(response.Body)
+	var responseBody map[string]interface{}
+	json.Unmarshal(body, &responseBody)
+	assert.Equal(t, "REQUEST ENTITY TOO LARGE", responseBody["status"])
+
+	request = httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/siswas", strings.NewReader(`{"nama" : "Gadget Gadget Gadget"}`))
+	recorder = httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 413, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/siswas", strings.NewReader(`{"nama" : "G"}`))
+	request.Header.Add("Content-Type", "application/json")
+	recorder = httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 200, recorder.Result().StatusCode)
+}
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {})
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas", nil)
+	recorder := httptest.NewRecorder()
+
+	middleware.NewSecurityHeadersMiddleware(handler).ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, "nosniff", response.Header.Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", response.Header.Get("X-Frame-Options"))
+	assert.Equal(t, "default-src 'none'; frame-ancestors 'none'", response.Header.Get("Content-Security-Policy"))
+	assert.Empty(t, response.Header.Get("Strict-Transport-Security"))
+}
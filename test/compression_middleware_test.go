@@ -0,0 +1,62 @@
+package test
+
+import (
+	"compress/gzip"
+	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func compressedResponse(acceptEncoding string, body string) *http.Response {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("ETag", `"1"`)
+		writer.Write([]byte(body))
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas", nil)
+	request.Header.Add("Accept-Encoding", acceptEncoding)
+	recorder := httptest.NewRecorder()
+
+	middleware.NewCompressionMiddleware(handler, 64).ServeHTTP(recorder, request)
+	return recorder.Result()
+}
+
+func TestCompressionMiddlewareGzip(t *testing.T) {
+	body := strings.Repeat(`{"nama":"Gadget"}`, 20)
+	response := compressedResponse("gzip, br;q=0.5", body)
+
+	assert.Equal(t, "gzip", response.Header.Get("Content-Encoding"))
+	assert.Equal(t, `W/"1"`, response.Header.Get("ETag"))
+	assert.Equal(t, "Accept-Encoding", response.Header.Get("Vary"))
+
+	reader, err := gzip.NewReader(response.Body)
+	assert.Nil(t, err)
+	decompressed, _ := io.ReadAll(reader)
+	assert.Equal(t, body, string(decompressed))
+}
+
+func TestCompressionMiddlewareBrotli(t *testing.T) {
+	body := strings.Repeat(`{"nama":"Gadget"}`, 20)
+	response := compressedResponse("gzip, deflate, br", body)
+
+	assert.Equal(t, "br", response.Header.Get("Content-Encoding"))
+
+	decompressed, _ := io.ReadAll(brotli.NewReader(response.Body))
+	assert.Equal(t, body, string(decompressed))
+}
+
+func TestCompressionMiddlewareSkipsSmallResponses(t *testing.T) {
+	response := compressedResponse("gzip", `{"nama":"Gadget"}`)
+
+	assert.Empty(t, response.Header.Get("Content-Encoding"))
+	assert.Equal(t, `"1"`, response.Header.Get("ETag"))
+
+	body, _ := io.ReadAll(response.Body)
+	assert.Equal(t, `{"nama":"Gadget"}`, string(body))
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func listResponse(accept string) *http.Response {
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v2/siswas", nil)
+	request.Header.Add("Accept", accept)
+	recorder := httptest.NewRecorder()
+
+	helper.WriteListToResponseBody(recorder, request, web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data: []web.SiswaResponseV2{
+			{Id: 1, Nama: "Gadget", Alamat: "Jl. Merdeka 1, Bandung"},
+			{Id: 2, Nama: "Budi"},
+		},
+	})
+	return recorder.Result()
+}
+
+func TestWriteListToResponseBodyFormats(t *testing.T) {
+	response := listResponse("application/x-ndjson")
+	assert.Equal(t, "application/x-ndjson", response.Header.Get("Content-Type"))
+	body, _ := io.ReadAll(response.Body)
+	assert.Equal(t, 2, len(splitLines(string(body))))
+	assert.Contains(t, splitLines(string(body))[0], `"nama":"Gadget"`)
+
+	response = listResponse("text/csv")
+	assert.Equal(t, "text/csv; charset=utf-8", response.Header.Get("Content-Type"))
+	body, _ = io.ReadAll(response.Body)
+	lines := splitLines(string(body))
+	assert.Equal(t, "id,nama,alamat,tanggal_lahir,tempat_lahir,jenis_kelamin,agama,golongan_darah,no_telepon,deleted_at,deleted_by", lines[0])
+	assert.Equal(t, `1,Gadget,"Jl. Merdeka 1, Bandung",,,,,,,,`, lines[1])
+
+	response = listResponse("application/msgpack")
+	assert.Equal(t, "application/msgpack", response.Header.Get("Content-Type"))
+	body, _ = io.ReadAll(response.Body)
+	var decoded map[string]interface{}
+	assert.Nil(t, msgpack.Unmarshal(body, &decoded))
+	assert.Equal(t, "OK", decoded["status"])
+
+	response = listResponse("text/csv;q=0.5, application/json")
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+
+	response = listResponse("application/xml")
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+}
+
+func TestWriteListToResponseBodyCsvEscapesFormulas(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v2/siswas", nil)
+	request.Header.Add("Accept", "text/csv")
+	recorder := httptest.NewRecorder()
+
+	helper.WriteListToResponseBody(recorder, request, web.WebResponse{
+		Code:   200,
+		Status: "OK",
+		Data: []web.SiswaResponseV2{
+			{Id: -1, Nama: "=HYPERLINK(\"http://evil\")", Alamat: "@SUM(A1)", TempatLahir: "-2+3", Agama: "+62"},
+		},
+	})
+
+	body, _ := io.ReadAll(recorder.Result().Body)
+	assert.Equal(t, `-1,"'=HYPERLINK(""http://evil"")",'@SUM(A1),,'-2+3,,'+62,,,,`, splitLines(string(body))[1])
+}
+
+func splitLines(body string) []string {
+	return strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+}
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestCorsMiddleware(handler http.Handler) *middleware.CorsMiddleware {
+	return middleware.NewCorsMiddleware(handler, middleware.CorsConfig{
+		AllowedOrigins:   []string{"https://sisko.example"},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPut},
+		AllowedHeaders:   []string{"Content-Type", "X-API-Key", "If-Match"},
+		ExposedHeaders:   []string{"ETag"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})
+}
+
+func TestCorsMiddlewarePreflight(t *testing.T) {
+	called := false
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		called = true
+	})
+
+	request := httptest.NewRequest(http.MethodOptions, "http://localhost:3000/api/siswas/1", nil)
+	request.Header.Add("Origin", "https://sisko.example")
+	request.Header.Add("Access-Control-Request-Method", "PUT")
+	request.Header.Add("Access-Control-Request-Headers", "content-type, x-api-key, if-match")
+	recorder := httptest.NewRecorder()
+
+	newTestCorsMiddleware(handler).ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.False(t, called)
+	assert.Equal(t, 204, response.StatusCode)
+	assert.Equal(t, "https://sisko.example", response.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", response.Header.Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "GET, PUT", response.Header.Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "600", response.Header.Get("Access-Control-Max-Age"))
+
+	request = httptest.NewRequest(http.MethodOptions, "http://localhost:3000/api/siswas/1", nil)
+	request.Header.Add("Origin", "https://evil.example")
+	request.Header.Add("Access-Control-Request-Method", "PUT")
+	recorder = httptest.NewRecorder()
+
+	newTestCorsMiddleware(handler).ServeHTTP(recorder, request)
+
+	assert.Equal(t, 403, recorder.Result().StatusCode)
+	assert.Empty(t, recorder.Result().Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestCorsMiddlewareActualRequest(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas", nil)
+	request.Header.Add("Origin", "https://sisko.example")
+	recorder := httptest.NewRecorder()
+
+	newTestCorsMiddleware(handler).ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, "https://sisko.example", response.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "ETag", response.Header.Get("Access-Control-Expose-Headers"))
+	assert.Equal(t, "Origin", response.Header.Get("Vary"))
+}
@@ -0,0 +1,27 @@
+package test
+
+import (
+	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeprecationMiddlewareSetsHeaders(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	})
+	sunset := time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v1/siswas", nil)
+	recorder := httptest.NewRecorder()
+
+	middleware.NewDeprecationMiddleware(handler, sunset, "/api/v2/siswas").ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, "true", response.Header.Get("Deprecation"))
+	assert.Equal(t, "Wed, 30 Jun 2027 00:00:00 GMT", response.Header.Get("Sunset"))
+	assert.Equal(t, `</api/v2/siswas>; rel="successor-version"`, response.Header.Get("Link"))
+}
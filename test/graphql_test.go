@@ -0,0 +1,234 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/app"
+	"github.com/Arraf18/go-sisko/controller"
+	"github.com/Arraf18/go-sisko/graph"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// batchingSiswaService records every FindByIds and Search call it gets.
+type batchingSiswaService struct {
+	memorySiswaService
+	batches  [][]int
+	searches []web.SiswaSearchRequest
+}
+
+func (service *batchingSiswaService) FindByIds(ctx context.Context, siswaIds []int) []web.SiswaResponse {
+	service.batches = append(service.batches, siswaIds)
+	var siswaResponses []web.SiswaResponse
+	for _, siswa := range service.siswas {
+		for _, siswaId := range siswaIds {
+			if siswa.Id == siswaId {
+				siswaResponses = append(siswaResponses, siswa)
+			}
+		}
+	}
+	return siswaResponses
+}
+
+// Search pages the siswa by id like the repository does, filtering only by
+// nama and jenis kelamin.
+func (service *batchingSiswaService) Search(ctx context.Context, request web.SiswaSearchRequest) web.SiswaPageResponse {
+	service.searches = append(service.searches, request)
+	var matches []web.SiswaResponse
+	for _, siswa := range service.siswas {
+		if strings.Contains(strings.ToLower(siswa.Nama), strings.ToLower(request.Nama)) &&
+			(request.JenisKelamin == "" || strings.EqualFold(siswa.JenisKelamin, request.JenisKelamin)) {
+			matches = append(matches, siswa)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Id < matches[j].Id })
+
+	page := web.SiswaPageResponse{TotalCount: len(matches)}
+	for _, siswa := range matches {
+		if siswa.Id <= request.After {
+			continue
+		}
+		if len(page.Siswas) == request.First {
+			page.HasNextPage = true
+			break
+		}
+		page.Siswas = append(page.Siswas, siswa)
+	}
+	return page
+}
+
+// memoryAuditService records every FindSiswaHistories call it gets.
+type memoryAuditService struct {
+	service.AuditService
+	audits  []web.AuditResponse
+	batches [][]int
+}
+
+func (service *memoryAuditService) Search(ctx context.Context, request web.AuditSearchRequest) []web.AuditResponse {
+	return service.audits
+}
+
+func (service *memoryAuditService) FindSiswaHistories(ctx context.Context, siswaIds []int, latest int) map[int][]web.AuditResponse {
+	service.batches = append(service.batches, siswaIds)
+	histories := map[int][]web.AuditResponse{}
+	for _, audit := range service.audits {
+		for _, siswaId := range siswaIds {
+			if audit.EntityId == siswaId {
+				histories[siswaId] = append(histories[siswaId], audit)
+			}
+		}
+	}
+	for siswaId, audits := range histories {
+		if len(audits) > latest {
+			histories[siswaId] = audits[len(audits)-latest:]
+		}
+	}
+	return histories
+}
+
+func newGraphqlSiswaService() *batchingSiswaService {
+	return &batchingSiswaService{memorySiswaService: memorySiswaService{siswas: []web.SiswaResponse{
+		{Id: 3, Nama: "Citra", JenisKelamin: "P", TanggalLahir: "2011-05-01"},
+		{Id: 1, Nama: "Gadget", JenisKelamin: "L", TanggalLahir: "2010-01-01"},
+		{Id: 2, Nama: "Budi", JenisKelamin: "L", TanggalLahir: "2012-03-04"},
+	}}}
+}
+
+func graphqlQuery(t *testing.T, siswaService service.SiswaService, auditService service.AuditService, maxComplexity int, query string, variables map[string]interface{}) map[string]interface{} {
+	graphqlController := controller.NewGraphqlController(graph.NewSchema(siswaService, auditService), siswaService, auditService, maxComplexity)
+	router := app.NewRouter(controller.NewSiswaController(nil), controller.NewSiswaControllerV2(nil), controller.NewAuditController(nil), graphqlController, controller.NewWebhookController(nil), controller.NewJobController(nil), controller.NewNotificationController(nil), controller.NewSekolahController(nil), controller.NewPendaftarController(nil))
+
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/graphql", strings.NewReader(string(body)))
+	request.Header.Add("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Code)
+
+	var responseBody map[string]interface{}
+	json.Unmarshal(recorder.Body.Bytes(), &responseBody)
+	return responseBody
+}
+
+func TestGraphqlFiltersAndPaginatesSiswa(t *testing.T) {
+	query := `query($after: String) {
+		siswas(filter: {jenisKelamin: "l"}, first: 1, after: $after) {
+			totalCount
+			nodes { id nama }
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+
+	siswaService := newGraphqlSiswaService()
+	responseBody := graphqlQuery(t, siswaService, nil, 1000, query, nil)
+	assert.Equal(t, []web.SiswaSearchRequest{{JenisKelamin: "l", First: 1}}, siswaService.searches)
+	siswas := responseBody["data"].(map[string]interface{})["siswas"].(map[string]interface{})
+	assert.Equal(t, float64(2), siswas["totalCount"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": float64(1), "nama": "Gadget"}}, siswas["nodes"])
+	pageInfo := siswas["pageInfo"].(map[string]interface{})
+	assert.Equal(t, true, pageInfo["hasNextPage"])
+
+	responseBody = graphqlQuery(t, newGraphqlSiswaService(), nil, 1000, query, map[string]interface{}{"after": pageInfo["endCursor"]})
+	siswas = responseBody["data"].(map[string]interface{})["siswas"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": float64(2), "nama": "Budi"}}, siswas["nodes"])
+	assert.Equal(t, false, siswas["pageInfo"].(map[string]interface{})["hasNextPage"])
+}
+
+func TestGraphqlBatchesSiswaOfAudits(t *testing.T) {
+	siswaService := newGraphqlSiswaService()
+	auditService := &memoryAuditService{audits: []web.AuditResponse{
+		{Id: 1, Entity: "siswa", EntityId: 1, Action: "create", CreatedAt: time.Now()},
+		{Id: 2, Entity: "siswa", EntityId: 2, Action: "create", CreatedAt: time.Now()},
+		{Id: 3, Entity: "siswa", EntityId: 1, Action: "update", CreatedAt: time.Now()},
+		{Id: 4, Entity: "siswa", EntityId: 9, Action: "delete", CreatedAt: time.Now()},
+	}}
+
+	responseBody := graphqlQuery(t, siswaService, auditService, 1000, `{ audits { nodes { action siswa { nama } } } }`, nil)
+	assert.Nil(t, responseBody["errors"])
+
+	nodes := responseBody["data"].(map[string]interface{})["audits"].(map[string]interface{})["nodes"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"nama": "Gadget"}, nodes[0].(map[string]interface{})["siswa"])
+	assert.Equal(t, map[string]interface{}{"nama": "Budi"}, nodes[1].(map[string]interface{})["siswa"])
+	assert.Nil(t, nodes[3].(map[string]interface{})["siswa"])
+	assert.Equal(t, [][]int{{1, 2, 9}}, siswaService.batches)
+}
+
+func TestGraphqlBatchesHistoryOfSiswa(t *testing.T) {
+	auditService := &memoryAuditService{audits: []web.AuditResponse{
+		{Id: 1, Entity: "siswa", EntityId: 1, Action: "create", CreatedAt: time.Now()},
+		{Id: 2, Entity: "siswa", EntityId: 2, Action: "create", CreatedAt: time.Now()},
+		{Id: 3, Entity: "siswa", EntityId: 1, Action: "update", CreatedAt: time.Now()},
+	}}
+
+	responseBody := graphqlQuery(t, newGraphqlSiswaService(), auditService, 1000, `{ siswas { nodes { id history(first: 1) { action } } } }`, nil)
+	assert.Nil(t, responseBody["errors"])
+
+	nodes := responseBody["data"].(map[string]interface{})["siswas"].(map[string]interface{})["nodes"].([]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"action": "update"}}, nodes[0].(map[string]interface{})["history"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"action": "create"}}, nodes[1].(map[string]interface{})["history"])
+	assert.Equal(t, []interface{}{}, nodes[2].(map[string]interface{})["history"])
+	assert.Equal(t, [][]int{{1, 2, 3}}, auditService.batches)
+}
+
+func TestGraphqlRejectsComplexQuery(t *testing.T) {
+	responseBody := graphqlQuery(t, newGraphqlSiswaService(), nil, 100, `{ siswas(first: 50) { nodes { id nama } } }`, nil)
+
+	assert.Nil(t, responseBody["data"])
+	graphqlError := responseBody["errors"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "QUERY_TOO_COMPLEX", graphqlError["extensions"].(map[string]interface{})["code"])
+}
+
+func TestGraphqlReportsServiceErrors(t *testing.T) {
+	responseBody := graphqlQuery(t, newGraphqlSiswaService(), nil, 1000, `{ siswas(filter: {tanggalLahirFrom: "kemarin"}) { totalCount } }`, nil)
+
+	graphqlError := responseBody["errors"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "tanggalLahirFrom must be a date (2006-01-02)", graphqlError["message"])
+	assert.Equal(t, "BAD_REQUEST", graphqlError["extensions"].(map[string]interface{})["code"])
+}
+
+func TestGraphqlComplexity(t *testing.T) {
+	document, err := parser.Parse(parser.ParseParams{Source: `
+		query($n: Int) { siswas(first: $n) { totalCount nodes { ...fields } } }
+		fragment fields on Siswa { id history(first: 5) { id } }
+	`})
+	assert.Nil(t, err)
+
+	// siswas: 1 + 10 * (totalCount 1 + nodes (1 + id 1 + history (1 + 5 * 1)))
+	assert.Equal(t, 1+10*(1+1+1+1+5), graph.Complexity(document, "", map[string]interface{}{"n": float64(10)}, 1000))
+	assert.Equal(t, 1+graph.MaxPageSize*(1+1+1+1+5), graph.Complexity(document, "", map[string]interface{}{"n": float64(1000)}, 1000))
+	assert.Equal(t, 101, graph.Complexity(document, "", map[string]interface{}{"n": float64(1000)}, 100))
+	assert.Equal(t, 4, graph.Depth(document, ""))
+}
+
+func TestGraphqlComplexitySaturates(t *testing.T) {
+	query := "{ id }"
+	for i := 0; i < 20; i++ {
+		query = "{ siswas(first: 100) { nodes " + query + " } }"
+	}
+	document, err := parser.Parse(parser.ParseParams{Source: "query " + query})
+	assert.Nil(t, err)
+
+	// 100^20 overflows an int, the count stops right past the limit instead
+	assert.Equal(t, 1001, graph.Complexity(document, "", nil, 1000))
+}
+
+func TestGraphqlRejectsDeepQuery(t *testing.T) {
+	query := "id"
+	for i := 0; i < graph.MaxDepth; i++ {
+		query = "siswa { " + query + " }"
+		query = "history(first: 1) { " + query + " }"
+	}
+	responseBody := graphqlQuery(t, newGraphqlSiswaService(), nil, 1000, "{ siswa(id: 1) { "+query+" } }", nil)
+
+	assert.Nil(t, responseBody["data"])
+	graphqlError := responseBody["errors"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "QUERY_TOO_DEEP", graphqlError["extensions"].(map[string]interface{})["code"])
+}
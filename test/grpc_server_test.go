@@ -0,0 +1,215 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/app"
+	"github.com/Arraf18/go-sisko/controller"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/pb"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memorySiswaService keeps a few siswa and fails like SiswaServiceImpl does.
+type memorySiswaService struct {
+	service.SiswaService
+	siswas []web.SiswaResponse
+}
+
+func (service *memorySiswaService) FindById(ctx context.Context, siswaId int) web.SiswaResponse {
+	for _, siswa := range service.siswas {
+		if siswa.Id == siswaId {
+			return siswa
+		}
+	}
+	panic(exception.NewNotFoundError("siswa is not found"))
+}
+
+func (service *memorySiswaService) FindAll(ctx context.Context) []web.SiswaResponse {
+	return service.siswas
+}
+
+func (service *memorySiswaService) Create(ctx context.Context, request web.SiswaCreateRequest) web.SiswaResponse {
+	err := validator.New().Struct(request)
+	if err != nil {
+		panic(err)
+	}
+	return web.SiswaResponse{Id: 1, Nama: request.Nama, Version: 1}
+}
+
+// fixedSekolahService resolves every request to the default sekolah.
+type fixedSekolahService struct {
+	service.SekolahService
+}
+
+func (service *fixedSekolahService) Resolve(ctx context.Context, username string, kode string) (domain.Sekolah, bool) {
+	return domain.Sekolah{Id: 1, Kode: "default", Bahasa: "id"}, false
+}
+
+func setupGrpcClient(t *testing.T, siswaService service.SiswaService, userService service.UserService, sekolahService service.SekolahService) pb.SiswaServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	lockout := middleware.NewAuthLockout(5, time.Minute, time.Minute)
+	server := app.NewGrpcServer(controller.NewSiswaGrpcServer(siswaService), middleware.NewGrpcInterceptor(logrus.StandardLogger(), lockout, userService, sekolahService))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	connection, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err)
+	t.Cleanup(func() { connection.Close() })
+
+	return pb.NewSiswaServiceClient(connection)
+}
+
+func withApiKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+}
+
+func TestGrpcRequiresApiKey(t *testing.T) {
+	client := setupGrpcClient(t, &memorySiswaService{}, service.NewUserService(nil, nil, nil, nil, nil, "RAHASIA"), &fixedSekolahService{})
+
+	_, err := client.GetSiswa(context.Background(), &pb.GetSiswaRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err := client.ListSiswa(withApiKey("SALAH"), &pb.ListSiswaRequest{})
+	assert.Nil(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestGrpcMapsServiceErrors(t *testing.T) {
+	client := setupGrpcClient(t, &memorySiswaService{}, service.NewUserService(nil, nil, nil, nil, nil, "RAHASIA"), &fixedSekolahService{})
+
+	_, err := client.GetSiswa(withApiKey("RAHASIA"), &pb.GetSiswaRequest{Id: 404})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "siswa is not found", status.Convert(err).Message())
+
+	_, err = client.CreateSiswa(withApiKey("RAHASIA"), &pb.CreateSiswaRequest{Data: &pb.SiswaData{Nama: "Gadget"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "Alamat")
+}
+
+func TestGrpcStreamsList(t *testing.T) {
+	siswaService := &memorySiswaService{siswas: []web.SiswaResponse{
+		{Id: 1, Nama: "Gadget", Version: 1},
+		{Id: 2, Nama: "Budi", Version: 3},
+	}}
+	client := setupGrpcClient(t, siswaService, service.NewUserService(nil, nil, nil, nil, nil, "RAHASIA"), &fixedSekolahService{})
+
+	stream, err := client.ListSiswa(withApiKey("RAHASIA"), &pb.ListSiswaRequest{})
+	assert.Nil(t, err)
+
+	var names []string
+	for {
+		siswa, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		names = append(names, siswa.Nama)
+	}
+	assert.Equal(t, []string{"Gadget", "Budi"}, names)
+}
+
+func TestGrpcCodeForHttpStatus(t *testing.T) {
+	assert.Equal(t, codes.InvalidArgument, exception.GrpcCode(http.StatusBadRequest))
+	assert.Equal(t, codes.NotFound, exception.GrpcCode(http.StatusNotFound))
+	assert.Equal(t, codes.Aborted, exception.GrpcCode(http.StatusConflict))
+	assert.Equal(t, codes.FailedPrecondition, exception.GrpcCode(http.StatusPreconditionRequired))
+	assert.Equal(t, codes.Internal, exception.GrpcCode(http.StatusInternalServerError))
+}
+
+// TestGrpcRestParity runs the same calls against both APIs on one database.
+func TestGrpcRestParity(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	router := setupRouter(db)
+	validate := validator.New()
+	siswaService := service.NewSiswaService(repository.NewSiswaRepository(), repository.NewAuditRepository(), repository.NewOutboxEventRepository(), db, validate)
+	userService := service.NewUserService(repository.NewUserRepository(), repository.NewApiKeyRepository(), repository.NewSekolahRepository(), db, validate, "RAHASIA")
+	sekolahService := service.NewSekolahService(repository.NewSekolahRepository(), repository.NewUserRepository(), db, validate, "default")
+	client := setupGrpcClient(t, siswaService, userService, sekolahService)
+
+	created, err := client.CreateSiswa(withApiKey("RAHASIA"), &pb.CreateSiswaRequest{Data: &pb.SiswaData{
+		Nama: "Gadget", Alamat: "Jakarta", TanggalLahir: "2010-01-01", TempatLahir: "Jakarta",
+		JenisKelamin: "L", Agama: "Islam", GolonganDarah: "O", NoTelepon: "0812",
+	}})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	restGet := func(id int64) (int, map[string]interface{}) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v2/siswas/"+strconv.FormatInt(id, 10), nil)
+		request.Header.Add("X-API-Key", "RAHASIA")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		var responseBody map[string]interface{}
+		json.Unmarshal(recorder.Body.Bytes(), &responseBody)
+		return recorder.Code, responseBody
+	}
+
+	code, responseBody := restGet(created.Id)
+	assert.Equal(t, 200, code)
+	data := responseBody["data"].(map[string]interface{})
+	assert.Equal(t, float64(created.Id), data["id"])
+	assert.Equal(t, created.Nama, data["nama"])
+	assert.Equal(t, created.TanggalLahir, data["tanggal_lahir"])
+
+	got, err := client.GetSiswa(withApiKey("RAHASIA"), &pb.GetSiswaRequest{Id: created.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, created.Version, got.Version)
+
+	code, _ = restGet(created.Id + 1)
+	_, err = client.GetSiswa(withApiKey("RAHASIA"), &pb.GetSiswaRequest{Id: created.Id + 1})
+	assert.Equal(t, 404, code)
+	assert.Equal(t, exception.GrpcCode(code), status.Code(err))
+
+	request := httptest.NewRequest(http.MethodPut, "http://localhost:3000/api/v2/siswas/"+strconv.FormatInt(created.Id, 10), strings.NewReader(`{"nama" : "Gadget", "alamat" : "Bandung", "tanggal_lahir" : "2010-01-01", "tempat_lahir" : "Jakarta", "jenis_kelamin" : "L", "agama" : "Islam", "golongan_darah" : "O", "no_telepon" : "0812"}`))
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	_, err = client.UpdateSiswa(withApiKey("RAHASIA"), &pb.UpdateSiswaRequest{Id: created.Id, Data: &pb.SiswaData{
+		Nama: "Gadget", Alamat: "Bandung", TanggalLahir: "2010-01-01", TempatLahir: "Jakarta",
+		JenisKelamin: "L", Agama: "Islam", GolonganDarah: "O", NoTelepon: "0812",
+	}})
+	assert.Equal(t, 428, recorder.Code)
+	assert.Equal(t, exception.GrpcCode(recorder.Code), status.Code(err))
+
+	_, err = client.DeleteSiswa(withApiKey("RAHASIA"), &pb.DeleteSiswaRequest{Id: created.Id, Version: created.Version})
+	assert.Nil(t, err)
+	code, _ = restGet(created.Id)
+	assert.Equal(t, 404, code)
+
+	stream, err := client.ListSiswa(withApiKey("RAHASIA"), &pb.ListSiswaRequest{Trash: true})
+	assert.Nil(t, err)
+	trashed, err := stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, created.Id, trashed.Id)
+	assert.NotNil(t, trashed.DeletedAt)
+}
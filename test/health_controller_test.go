@@ -0,0 +1,78 @@
+package test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/app"
+	"github.com/Arraf18/go-sisko/controller"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/Arraf18/go-sisko/service"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setupPublicRouter(db *sql.DB) http.Handler {
+	healthService := service.NewHealthService(repository.NewMigrationRepository(), db, true)
+	healthController := controller.NewHealthController(healthService)
+	return app.NewPublicRouter(healthController, prometheus.NewRegistry())
+}
+
+func TestLivenessWithoutApiKey(t *testing.T) {
+	router := setupPublicRouter(nil)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/healthz", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 200, response.StatusCode)
+}
+
+func TestReadinessDatabaseDown(t *testing.T) {
+	db, err := sql.Open("mysql", "root@tcp(localhost:1)/go_sisko?parseTime=true")
+	helper.PanicIfError(err)
+	defer db.Close()
+	router := setupPublicRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/readyz", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 503, response.StatusCode)
+
+	body, _ := io.ReadAll(response.Body)
+	var responseBody map[string]interface{}
+	json.Unmarshal(body, &responseBody)
+
+	assert.Equal(t, "SERVICE UNAVAILABLE", responseBody["status"])
+	assert.Equal(t, false, responseBody["data"].(map[string]interface{})["ready"])
+}
+
+func TestReadinessWithoutMigrationCheck(t *testing.T) {
+	db := setupTestDB()
+	healthController := controller.NewHealthController(service.NewHealthService(repository.NewMigrationRepository(), db, false))
+	router := app.NewPublicRouter(healthController, prometheus.NewRegistry())
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/readyz", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 200, response.StatusCode)
+
+	body, _ := io.ReadAll(response.Body)
+	var responseBody map[string]interface{}
+	json.Unmarshal(body, &responseBody)
+
+	assert.Equal(t, "skipped", responseBody["data"].(map[string]interface{})["checks"].(map[string]interface{})["migration"])
+}
@@ -0,0 +1,125 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memoryIdempotencyService keeps keys in a map and panics in Finish when
+// failFinish is set.
+type memoryIdempotencyService struct {
+	responses  map[string]*web.IdempotentResponse
+	failFinish bool
+	aborted    []string
+}
+
+func (service *memoryIdempotencyService) Start(ctx context.Context, key string, fingerprint string) *web.IdempotentResponse {
+	if response, ok := service.responses[key]; ok {
+		return response
+	}
+	return nil
+}
+
+func (service *memoryIdempotencyService) Finish(ctx context.Context, key string, response web.IdempotentResponse) {
+	if service.failFinish {
+		panic("database is gone")
+	}
+	service.responses[key] = &response
+}
+
+func (service *memoryIdempotencyService) Abort(ctx context.Context, key string) {
+	service.aborted = append(service.aborted, key)
+}
+
+func (service *memoryIdempotencyService) PurgeExpired(ctx context.Context) int {
+	return 0
+}
+
+func createdHandler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("Location", "/api/v2/siswas/7")
+		writer.Header().Set("ETag", `"1"`)
+		writer.Header().Set("Cache-Control", "no-store")
+		writer.WriteHeader(http.StatusCreated)
+		writer.Write([]byte(`{"code":201}`))
+	})
+}
+
+func postWithKey(handler http.Handler) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/v2/siswas", strings.NewReader(`{}`))
+	request.Header.Set("Idempotency-Key", "create-7")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestIdempotencyMiddlewareReplaysHeaders(t *testing.T) {
+	handler := middleware.NewIdempotencyMiddleware(createdHandler(), &memoryIdempotencyService{responses: map[string]*web.IdempotentResponse{}})
+
+	first := postWithKey(handler)
+	second := postWithKey(handler)
+
+	assert.Equal(t, 201, second.Code)
+	assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+	for _, name := range []string{"Content-Type", "Location", "ETag", "Cache-Control"} {
+		assert.Equal(t, first.Header().Get(name), second.Header().Get(name), name)
+	}
+	assert.Equal(t, first.Body.String(), second.Body.String())
+}
+
+func TestIdempotencyMiddlewareAbortsWhenFinishFails(t *testing.T) {
+	idempotencyService := &memoryIdempotencyService{responses: map[string]*web.IdempotentResponse{}, failFinish: true}
+	handler := middleware.NewIdempotencyMiddleware(createdHandler(), idempotencyService)
+
+	recorder := postWithKey(handler)
+
+	assert.Equal(t, 201, recorder.Code)
+	assert.Equal(t, `{"code":201}`, recorder.Body.String())
+	assert.Equal(t, []string{"create-7"}, idempotencyService.aborted)
+}
+
+// failingIdempotencyKeyRepository refuses every Save with err.
+type failingIdempotencyKeyRepository struct {
+	repository.IdempotencyKeyRepository
+	err error
+}
+
+func (repository *failingIdempotencyKeyRepository) Save(ctx context.Context, tx *sql.Tx, idempotencyKey domain.IdempotencyKey) error {
+	return repository.err
+}
+
+func TestIdempotencyServiceConflictsOnlyOnDuplicateKey(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	ctx := helper.WithActor(context.Background(), "admin")
+
+	start := func(err error) (recovered interface{}) {
+		defer func() {
+			recovered = recover()
+		}()
+		idempotencyService := service.NewIdempotencyService(&failingIdempotencyKeyRepository{repository.NewIdempotencyKeyRepository(), err}, db, time.Hour)
+		idempotencyService.Start(ctx, "kunci-1", "fingerprint")
+		return nil
+	}
+
+	assert.IsType(t, exception.ConflictError{}, start(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}))
+
+	lost := errors.New("invalid connection")
+	assert.Equal(t, lost, start(lost))
+}
@@ -0,0 +1,112 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Arraf18/go-sisko/job"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// a Wednesday
+	from := time.Date(2026, time.October, 14, 10, 7, 30, 0, time.UTC)
+
+	for spec, expected := range map[string]time.Time{
+		"*/15 * * * *": time.Date(2026, time.October, 14, 10, 15, 0, 0, time.UTC),
+		"@hourly":      time.Date(2026, time.October, 14, 11, 0, 0, 0, time.UTC),
+		"30 3 * * *":   time.Date(2026, time.October, 15, 3, 30, 0, 0, time.UTC),
+		"0 0 1 * *":    time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC),
+		"0 7 * * 1-5":  time.Date(2026, time.October, 15, 7, 0, 0, 0, time.UTC),
+		"0 7 * * 0,6":  time.Date(2026, time.October, 17, 7, 0, 0, 0, time.UTC),
+		"0 0 31 * 1":   time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+		"0 0 29 2 *":   time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+	} {
+		schedule, err := job.ParseSchedule(spec)
+		if assert.Nil(t, err, spec) {
+			assert.Equal(t, expected, schedule.Next(from), spec)
+		}
+	}
+}
+
+func TestParseScheduleRejectsInvalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := job.ParseSchedule(spec)
+		assert.NotNil(t, err, spec)
+	}
+}
+
+func TestJobRunnerRetries(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	router := setupRouter(db)
+	ctx := context.Background()
+
+	failing := true
+	runs := 0
+	runner := job.NewRunner(db, repository.NewJobRepository(), 1)
+	runner.Register("flaky", 2, func(ctx context.Context, payload []byte) error {
+		runs++
+		assert.Equal(t, `{"n": 1}`, string(payload))
+		if failing {
+			return errors.New("not today")
+		}
+		return nil
+	})
+
+	var queued bool
+	func() {
+		defer func() { assert.Nil(t, recover()) }()
+		runner.Enqueue(ctx, "flaky", []byte(`{"n": 1}`), time.Now())
+		queued = true
+	}()
+	if !queued {
+		return
+	}
+
+	assert.True(t, runner.RunOnce(ctx))
+	// the retry waits for its backoff
+	assert.False(t, runner.RunOnce(ctx))
+	db.Exec("UPDATE job SET run_at = NOW()")
+	assert.True(t, runner.RunOnce(ctx))
+	assert.Equal(t, 2, runs)
+
+	call := func(method string, path string) (int, map[string]interface{}) {
+		request := httptest.NewRequest(method, "http://localhost:3000"+path, nil)
+		request.Header.Add("X-API-Key", "RAHASIA")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		var responseBody map[string]interface{}
+		json.Unmarshal(recorder.Body.Bytes(), &responseBody)
+		return recorder.Code, responseBody
+	}
+
+	code, responseBody := call(http.MethodGet, "/api/v2/jobs?status=failed")
+	assert.Equal(t, 200, code)
+	jobs := responseBody["data"].([]interface{})
+	if !assert.Len(t, jobs, 1) {
+		return
+	}
+	failed := jobs[0].(map[string]interface{})
+	assert.Equal(t, "not today", failed["last_error"])
+	jobPath := "/api/v2/jobs/" + strconv.Itoa(int(failed["id"].(float64)))
+
+	failing = false
+	code, _ = call(http.MethodPost, jobPath+"/retry")
+	assert.Equal(t, 202, code)
+	assert.True(t, runner.RunOnce(ctx))
+
+	_, responseBody = call(http.MethodGet, jobPath)
+	assert.Equal(t, "succeeded", responseBody["data"].(map[string]interface{})["status"])
+	code, _ = call(http.MethodPost, jobPath+"/retry")
+	assert.Equal(t, 409, code)
+	code, _ = call(http.MethodGet, "/api/v2/jobs?status=lost")
+	assert.Equal(t, 400, code)
+}
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLoggingMiddlewarePropagatesRequestId(t *testing.T) {
+	output := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.SetOutput(output)
+
+	var requestId string
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestId = helper.RequestIdFromContext(request.Context())
+		writer.WriteHeader(http.StatusCreated)
+		writer.Write([]byte("created"))
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/siswas", nil)
+	request.Header.Add("X-Request-ID", "request-1")
+	recorder := httptest.NewRecorder()
+
+	middleware.NewLoggingMiddleware(handler, logger).ServeHTTP(recorder, request)
+
+	assert.Equal(t, "request-1", requestId)
+	assert.Equal(t, "request-1", recorder.Result().Header.Get("X-Request-ID"))
+
+	var entry map[string]interface{}
+	json.Unmarshal(output.Bytes(), &entry)
+	assert.Equal(t, "request-1", entry["request_id"])
+	assert.Equal(t, "POST", entry["method"])
+	assert.Equal(t, "/api/siswas", entry["path"])
+	assert.Equal(t, 201, int(entry["status"].(float64)))
+	assert.Equal(t, 7, int(entry["size"].(float64)))
+}
+
+func TestLoggingMiddlewareAssignsRequestId(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(&bytes.Buffer{})
+
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {})
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas", nil)
+	recorder := httptest.NewRecorder()
+
+	middleware.NewLoggingMiddleware(handler, logger).ServeHTTP(recorder, request)
+
+	assert.Len(t, recorder.Result().Header.Get("X-Request-ID"), 32)
+}
+
+func TestLoggingMiddlewareLogsStackOfPanics(t *testing.T) {
+	output := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.SetOutput(output)
+
+	entries := func(err interface{}) map[string]interface{} {
+		output.Reset()
+		handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			exception.ErrorHandler(writer, request, err)
+		})
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas/1", nil)
+		middleware.NewLoggingMiddleware(handler, logger).ServeHTTP(httptest.NewRecorder(), request)
+
+		var entry map[string]interface{}
+		json.Unmarshal(bytes.SplitN(output.Bytes(), []byte("\n"), 2)[0], &entry)
+		return entry
+	}
+
+	entry := entries(exception.NewNotFoundError("siswa is not found"))
+	assert.Equal(t, "warning", entry["level"])
+	assert.Contains(t, entry["stack"], "runtime/debug.Stack")
+
+	entry = entries("boom")
+	assert.Equal(t, "error", entry["level"])
+	assert.Contains(t, entry["stack"], "runtime/debug.Stack")
+}
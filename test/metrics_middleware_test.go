@@ -0,0 +1,29 @@
+package test
+
+import (
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMetricsMiddlewareUsesRoutePattern(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		helper.RouteFromContext(request.Context()).Pattern = "/api/siswas/:siswaId"
+		writer.WriteHeader(http.StatusNotFound)
+	})
+	metricsMiddleware := middleware.NewMetricsMiddleware(handler, registry)
+
+	for _, id := range []string{"1", "2", "3"} {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas/"+id, nil)
+		metricsMiddleware.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	assert.Equal(t, 1, testutil.CollectAndCount(metricsMiddleware.RequestTotal))
+	assert.Equal(t, float64(3), testutil.ToFloat64(metricsMiddleware.RequestTotal.WithLabelValues("/api/siswas/:siswaId", "GET", "404")))
+}
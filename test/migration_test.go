@@ -0,0 +1,38 @@
+package test
+
+import (
+	"database/sql"
+	"github.com/Arraf18/go-sisko/db"
+	"github.com/Arraf18/go-sisko/helper"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// TestMigrationsUpAndDown applies every embedded migration to an empty
+// database and reverts them all again.
+func TestMigrationsUpAndDown(t *testing.T) {
+	server, err := sql.Open("mysql", "root@tcp(localhost:3306)/")
+	helper.PanicIfError(err)
+	defer server.Close()
+
+	_, err = server.Exec("DROP DATABASE IF EXISTS go_sisko_migration")
+	helper.PanicIfError(err)
+	_, err = server.Exec("CREATE DATABASE go_sisko_migration")
+	helper.PanicIfError(err)
+	defer server.Exec("DROP DATABASE go_sisko_migration")
+
+	m, err := db.NewMigrate("root@tcp(localhost:3306)/go_sisko_migration?parseTime=true")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer m.Close()
+
+	assert.Nil(t, m.Up())
+	version, dirty, err := m.Version()
+	assert.Nil(t, err)
+	assert.False(t, dirty)
+	assert.Equal(t, uint(db.LatestVersion()), version)
+
+	assert.Nil(t, m.Down())
+}
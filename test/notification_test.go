@@ -0,0 +1,178 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/notification"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/go-playground/validator"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNotificationRenderLanguages(t *testing.T) {
+	data := notification.TemplateData{Nama: "Gadget", Data: map[string]string{"tanggal": "19 Oktober 2026"}}
+
+	subject, body, err := notification.Render(notification.TemplateAbsence, notification.LanguageIndonesian, data)
+	assert.Nil(t, err)
+	assert.Equal(t, "Ketidakhadiran Gadget", subject)
+	assert.Contains(t, body, "tidak hadir di sekolah pada 19 Oktober 2026")
+
+	subject, body, err = notification.Render(notification.TemplateAbsence, notification.LanguageEnglish, data)
+	assert.Nil(t, err)
+	assert.Equal(t, "Absence of Gadget", subject)
+	assert.Contains(t, body, "recorded absent from school on 19 Oktober 2026")
+}
+
+func TestNotificationRenderRejectsMissingData(t *testing.T) {
+	_, _, err := notification.Render(notification.TemplateUnpaidFee, notification.LanguageIndonesian, notification.TemplateData{Nama: "Gadget", Data: map[string]string{"bulan": "Oktober"}})
+	assert.NotNil(t, err)
+
+	_, _, err = notification.Render("birthday", notification.LanguageIndonesian, notification.TemplateData{Nama: "Gadget"})
+	assert.NotNil(t, err)
+
+	_, _, err = notification.Render(notification.TemplateAbsence, "fr", notification.TemplateData{Nama: "Gadget"})
+	assert.NotNil(t, err)
+}
+
+func TestNotificationNormalizePhone(t *testing.T) {
+	assert.Equal(t, "628123456789", notification.NormalizePhone("0812-3456-789"))
+	assert.Equal(t, "628123456789", notification.NormalizePhone("+62 812 3456 789"))
+	assert.Equal(t, "", notification.NormalizePhone(""))
+}
+
+func TestNotificationSmsProvider(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "Bearer RAHASIA", request.Header.Get("Authorization"))
+		json.NewDecoder(request.Body).Decode(&received)
+		if received["to"] == "" {
+			http.Error(writer, "missing number", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	provider := notification.NewSmsProvider(server.URL, "RAHASIA", "SEKOLAH")
+	assert.Equal(t, notification.ChannelSms, provider.Channel())
+
+	err := provider.Send(context.Background(), notification.Message{To: "0812 3456 789", Body: "Halo"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"to": "628123456789", "from": "SEKOLAH", "message": "Halo"}, received)
+
+	err = provider.Send(context.Background(), notification.Message{To: "", Body: "Halo"})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "missing number")
+	}
+}
+
+func TestNotificationWhatsappProvider(t *testing.T) {
+	var path string
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		path = request.URL.Path
+		assert.Equal(t, "Bearer TOKEN", request.Header.Get("Authorization"))
+		json.NewDecoder(request.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	provider := notification.NewWhatsappProvider(server.URL+"/", "12345", "TOKEN")
+	err := provider.Send(context.Background(), notification.Message{To: "0812 3456 789", Subject: "Ketidakhadiran Gadget", Body: "Halo"})
+	assert.Nil(t, err)
+	assert.Equal(t, "/12345/messages", path)
+	assert.Equal(t, "whatsapp", received["messaging_product"])
+	assert.Equal(t, "628123456789", received["to"])
+	assert.Equal(t, map[string]interface{}{"body": "Halo"}, received["text"])
+}
+
+// memoryJobQueue records the jobs queued instead of storing them.
+type memoryJobQueue struct {
+	payloads []string
+}
+
+func (queue *memoryJobQueue) EnqueueTx(ctx context.Context, tx *sql.Tx, name string, payload []byte, runAt time.Time) domain.Job {
+	queue.payloads = append(queue.payloads, string(payload))
+	return domain.Job{Name: name, Payload: payload, RunAt: runAt}
+}
+
+func TestNotificationFallsBackToNextChannel(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	ctx := withDefaultSekolah(context.Background())
+
+	whatsapp := notification.NewFakeProvider(notification.ChannelWhatsapp)
+	whatsapp.Err = errors.New("outside the 24 hour window")
+	sms := notification.NewFakeProvider(notification.ChannelSms)
+	queue := &memoryJobQueue{}
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(), repository.NewNotificationPreferenceRepository(), repository.NewSiswaRepository(), db, validator.New(), queue, []notification.Provider{whatsapp, sms})
+
+	var siswa domain.Siswa
+	func() {
+		defer func() { assert.Nil(t, recover()) }()
+		tx, err := db.Begin()
+		if err != nil {
+			panic(err)
+		}
+		siswa = repository.NewSiswaRepository().Save(ctx, tx, domain.Siswa{Nama: "Gadget", Alamat: "Jakarta", TanggalLahir: "2010-01-01", TempatLahir: "Jakarta", JenisKelamin: "L", Agama: "Islam", GolonganDarah: "O", NoTelepon: "0812 3456 789"})
+		tx.Commit()
+	}()
+	if siswa.Id == 0 {
+		return
+	}
+
+	// without a preference the parent is reached on the number of the siswa
+	notifications := notificationService.Send(ctx, web.NotificationSendRequest{
+		SiswaIds: []int{siswa.Id},
+		Template: notification.TemplateAbsence,
+		Data:     map[string]string{"tanggal": "19 Oktober 2026"},
+	})
+	if !assert.Len(t, notifications, 1) {
+		return
+	}
+	assert.Equal(t, domain.NotificationPending, notifications[0].Status)
+	assert.Equal(t, []string{strconv.FormatInt(notifications[0].Id, 10)}, queue.payloads)
+
+	assert.Nil(t, notificationService.Deliver(ctx, notifications[0].Id))
+	// delivering again does not send twice
+	assert.Nil(t, notificationService.Deliver(ctx, notifications[0].Id))
+
+	if assert.Len(t, sms.Sent(), 1) {
+		assert.Equal(t, "0812 3456 789", sms.Sent()[0].To)
+		assert.Equal(t, "Ketidakhadiran Gadget", sms.Sent()[0].Subject)
+	}
+
+	notifications = notificationService.FindAll(ctx, web.NotificationSearchRequest{SiswaId: siswa.Id})
+	if assert.Len(t, notifications, 1) {
+		assert.Equal(t, domain.NotificationSent, notifications[0].Status)
+		assert.Equal(t, notification.ChannelSms, notifications[0].Channel)
+		assert.Equal(t, 1, notifications[0].Attempts)
+		assert.Contains(t, notifications[0].LastError, "outside the 24 hour window")
+	}
+
+	// an English-speaking parent who only wants email
+	notificationService.SavePreference(ctx, web.NotificationPreferenceRequest{
+		SiswaId:  siswa.Id,
+		NamaWali: "Budi",
+		Email:    "budi@example.com",
+		Channels: []string{notification.ChannelEmail},
+		Language: notification.LanguageEnglish,
+	})
+	notifications = notificationService.Send(ctx, web.NotificationSendRequest{
+		SiswaIds: []int{siswa.Id},
+		Template: notification.TemplateAbsence,
+		Data:     map[string]string{"tanggal": "20 October 2026"},
+	})
+	if assert.Len(t, notifications, 1) {
+		assert.Equal(t, "Absence of Gadget", notifications[0].Subject)
+		// no email provider is configured
+		assert.NotNil(t, notificationService.Deliver(ctx, notifications[0].Id))
+	}
+}
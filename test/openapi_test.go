@@ -0,0 +1,66 @@
+package test
+
+import (
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/app"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func fetchOpenAPI(t *testing.T) map[string]interface{} {
+	router := setupPublicRouter(nil)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/openapi.json", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 200, response.StatusCode)
+
+	body, _ := io.ReadAll(response.Body)
+	var document map[string]interface{}
+	err := json.Unmarshal(body, &document)
+	assert.Nil(t, err)
+	return document
+}
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	document := fetchOpenAPI(t)
+
+	var documented []string
+	for path, operations := range document["paths"].(map[string]interface{}) {
+		for method := range operations.(map[string]interface{}) {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	assert.ElementsMatch(t, app.ApiRoutes(), documented, "routes in app/router.go and apiOperations in app/openapi.go have drifted")
+}
+
+func TestOpenAPISchemaFromTags(t *testing.T) {
+	document := fetchOpenAPI(t)
+
+	schemas := document["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	createRequest := schemas["SiswaCreateRequest"].(map[string]interface{})
+	assert.Contains(t, createRequest["required"], "nama")
+
+	nama := createRequest["properties"].(map[string]interface{})["nama"].(map[string]interface{})
+	assert.Equal(t, "string", nama["type"])
+	assert.Equal(t, float64(1), nama["minLength"])
+	assert.Equal(t, float64(100), nama["maxLength"])
+
+	updateRequest := schemas["SiswaUpdateRequest"].(map[string]interface{})
+	assert.NotContains(t, updateRequest["properties"], "Id")
+
+	eventTypes := schemas["WebhookCreateRequest"].(map[string]interface{})["properties"].(map[string]interface{})["event_types"].(map[string]interface{})
+	assert.Equal(t, float64(1), eventTypes["minItems"])
+	assert.Contains(t, eventTypes["items"].(map[string]interface{})["enum"], "SiswaDeleted")
+
+	response := schemas["SiswaResponse"].(map[string]interface{})
+	assert.Contains(t, response["properties"], "Agama")
+}
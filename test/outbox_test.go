@@ -0,0 +1,224 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/outbox"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/go-playground/validator"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var outboxTestEvent = outbox.Event{
+	Id:            7,
+	Type:          "SiswaCreated",
+	AggregateType: "siswa",
+	AggregateId:   1,
+	OccurredAt:    time.Now(),
+	Data:          json.RawMessage(`{"siswa":{"id":1}}`),
+}
+
+func TestOutboxBusHandsEventsToSubscribersInOrder(t *testing.T) {
+	bus := outbox.NewBus()
+	var calls []string
+	bus.Subscribe(func(ctx context.Context, event outbox.Event) error {
+		calls = append(calls, "first")
+		return nil
+	})
+	bus.Subscribe(func(ctx context.Context, event outbox.Event) error {
+		calls = append(calls, "second")
+		return errors.New("down")
+	})
+
+	err := bus.Publish(context.Background(), outboxTestEvent)
+	assert.EqualError(t, err, "down")
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
+func TestOutboxWebhookSink(t *testing.T) {
+	var received outbox.Event
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "SiswaCreated", request.Header.Get("X-Sisko-Event"))
+		assert.Equal(t, "7", request.Header.Get("X-Sisko-Event-Id"))
+		json.NewDecoder(request.Body).Decode(&received)
+		writer.WriteHeader(status)
+	}))
+	defer server.Close()
+	sink := outbox.NewWebhookSink(server.URL)
+
+	assert.Nil(t, sink.Publish(context.Background(), outboxTestEvent))
+	assert.Equal(t, int64(7), received.Id)
+	assert.JSONEq(t, `{"siswa":{"id":1}}`, string(received.Data))
+
+	status = http.StatusServiceUnavailable
+	assert.NotNil(t, sink.Publish(context.Background(), outboxTestEvent))
+}
+
+func TestOutboxKafkaSink(t *testing.T) {
+	answer := `{"offsets":[{"partition":0,"offset":1}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "/topics/sisko.siswa", request.URL.Path)
+		assert.Equal(t, "application/vnd.kafka.json.v2+json", request.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(request.Body)
+		assert.Contains(t, string(body), `"key":"siswa-1"`)
+		writer.Write([]byte(answer))
+	}))
+	defer server.Close()
+	sink := outbox.NewKafkaSink(server.URL+"/", "sisko.siswa")
+
+	assert.Nil(t, sink.Publish(context.Background(), outboxTestEvent))
+
+	answer = `{"offsets":[{"partition":null,"offset":null,"error_code":50003,"error":"broker not available"}]}`
+	assert.EqualError(t, sink.Publish(context.Background(), outboxTestEvent), "kafka: broker not available")
+}
+
+// fakeNatsServer accepts one connection and answers every PUB with reply.
+func fakeNatsServer(t *testing.T, reply string) (string, chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { listener.Close() })
+	subjects := make(chan string, 10)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		conn.Write([]byte("INFO {\"server_id\":\"test\"}\r\n"))
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				conn.Write([]byte("+OK\r\nPING\r\n"))
+			case strings.HasPrefix(line, "PUB "):
+				reader.ReadString('\n')
+				subjects <- strings.Fields(line)[1]
+				conn.Write([]byte(reply))
+			}
+		}
+	}()
+	return listener.Addr().String(), subjects
+}
+
+func TestOutboxNatsSink(t *testing.T) {
+	addr, subjects := fakeNatsServer(t, "+OK\r\n")
+	sink := outbox.NewNatsSink("nats://"+addr, "sisko")
+	defer sink.Close()
+
+	assert.Nil(t, sink.Publish(context.Background(), outboxTestEvent))
+	assert.Equal(t, "sisko.siswa.SiswaCreated", <-subjects)
+}
+
+func TestOutboxNatsSinkRejected(t *testing.T) {
+	addr, _ := fakeNatsServer(t, "-ERR 'Permissions Violation'\r\n")
+	sink := outbox.NewNatsSink("nats://"+addr, "sisko")
+	defer sink.Close()
+
+	assert.EqualError(t, sink.Publish(context.Background(), outboxTestEvent), "nats: 'Permissions Violation'")
+}
+
+func TestOutboxRelayKeepsOrderPerSiswa(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	siswaService := service.NewSiswaService(repository.NewSiswaRepository(), repository.NewAuditRepository(), repository.NewOutboxEventRepository(), db, validator.New())
+	ctx := withDefaultSekolah(context.Background())
+
+	var created web.SiswaResponse
+	func() {
+		defer func() { assert.Nil(t, recover()) }()
+		created = siswaService.Create(ctx, web.SiswaCreateRequest{
+			Nama: "Gadget", Alamat: "Jakarta", TanggalLahir: "2010-01-01", TempatLahir: "Jakarta",
+			JenisKelamin: "L", Agama: "Islam", GolonganDarah: "O", NoTelepon: "0812",
+		})
+		siswaService.Delete(ctx, created.Id, created.Version)
+	}()
+	if t.Failed() {
+		return
+	}
+
+	bus := outbox.NewBus()
+	failing := true
+	var types []string
+	bus.Subscribe(func(ctx context.Context, event outbox.Event) error {
+		if failing {
+			return errors.New("down")
+		}
+		types = append(types, event.Type)
+		return nil
+	})
+	relay := outbox.NewRelay(db, repository.NewOutboxEventRepository(), []outbox.Sink{bus}, time.Hour, 10)
+
+	// the delete waits behind the failed create
+	assert.Equal(t, 0, relay.RelayOnce(ctx))
+	db.Exec("UPDATE outbox_event SET next_attempt_at = NULL")
+
+	failing = false
+	assert.Equal(t, 2, relay.RelayOnce(ctx))
+	assert.Equal(t, []string{"SiswaCreated", "SiswaDeleted"}, types)
+	assert.Equal(t, 0, relay.RelayOnce(ctx))
+}
+
+func TestOutboxRelaySkipsWaitingSiswaAndGivesUp(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+
+	outboxEventRepository := repository.NewOutboxEventRepository()
+	tx, _ := db.Begin()
+	for _, aggregateId := range []int{1, 1, 2} {
+		outboxEventRepository.Save(context.Background(), tx, domain.OutboxEvent{
+			AggregateType: "siswa", AggregateId: aggregateId, EventType: "SiswaUpdated",
+			Payload: []byte(`{}`), CreatedAt: time.Now(),
+		})
+	}
+	tx.Commit()
+
+	bus := outbox.NewBus()
+	var published []int
+	bus.Subscribe(func(ctx context.Context, event outbox.Event) error {
+		if event.AggregateId == 1 {
+			return errors.New("down")
+		}
+		published = append(published, event.AggregateId)
+		return nil
+	})
+	relay := outbox.NewRelay(db, outboxEventRepository, []outbox.Sink{bus}, time.Hour, 2)
+	ctx := context.Background()
+
+	// siswa 1 waits for its retry, siswa 2 is not held up behind it
+	assert.Equal(t, 1, relay.RelayOnce(ctx))
+	assert.Equal(t, []int{2}, published)
+	tx, _ = db.Begin()
+	assert.Empty(t, outboxEventRepository.FindPending(ctx, tx, time.Now(), 100))
+	tx.Commit()
+
+	// the second failure is the last, the next event of siswa 1 is tried right after
+	db.Exec("UPDATE outbox_event SET next_attempt_at = NULL")
+	assert.Equal(t, 0, relay.RelayOnce(ctx))
+	var dead int
+	db.QueryRow("SELECT count(*) FROM outbox_event WHERE dead_at IS NOT NULL").Scan(&dead)
+	assert.Equal(t, 1, dead)
+
+	var attempts int
+	db.QueryRow("SELECT attempts FROM outbox_event WHERE aggregate_id = 1 AND dead_at IS NULL").Scan(&attempts)
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,255 @@
+package test
+
+import (
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/app"
+	"github.com/Arraf18/go-sisko/controller"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/ppdb"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func nilai(value float64) *float64 {
+	return &value
+}
+
+func TestPpdbTransition(t *testing.T) {
+	assert.Nil(t, ppdb.Transition(domain.PendaftarRegistered, domain.PendaftarVerified))
+	assert.Nil(t, ppdb.Transition(domain.PendaftarVerified, domain.PendaftarTested))
+	assert.Nil(t, ppdb.Transition(domain.PendaftarTested, domain.PendaftarAccepted))
+	assert.Nil(t, ppdb.Transition(domain.PendaftarVerified, domain.PendaftarRejected))
+	assert.Nil(t, ppdb.Transition(domain.PendaftarAccepted, domain.PendaftarReRegistered))
+
+	assert.EqualError(t, ppdb.Transition(domain.PendaftarRegistered, domain.PendaftarTested), "pendaftar that is registered cannot become tested")
+	assert.NotNil(t, ppdb.Transition(domain.PendaftarRejected, domain.PendaftarAccepted))
+	assert.NotNil(t, ppdb.Transition(domain.PendaftarAccepted, domain.PendaftarRejected))
+	assert.NotNil(t, ppdb.Transition(domain.PendaftarReRegistered, domain.PendaftarReRegistered))
+}
+
+func TestPpdbRank(t *testing.T) {
+	now := time.Now()
+	zonasi := []domain.Pendaftar{
+		{Id: 1, Jalur: domain.JalurZonasi, Jarak: 900, Nilai: nilai(95), CreatedAt: now},
+		{Id: 2, Jalur: domain.JalurZonasi, Jarak: 300, Nilai: nilai(70), CreatedAt: now},
+		{Id: 3, Jalur: domain.JalurZonasi, Jarak: 300, Nilai: nilai(80), CreatedAt: now},
+	}
+	ppdb.Rank(zonasi)
+	assert.Equal(t, []int{3, 2, 1}, []int{zonasi[0].Id, zonasi[1].Id, zonasi[2].Id})
+
+	prestasi := []domain.Pendaftar{
+		{Id: 1, Jalur: domain.JalurPrestasi, Jarak: 900, Nilai: nilai(95), CreatedAt: now.Add(time.Minute)},
+		{Id: 2, Jalur: domain.JalurPrestasi, Jarak: 300, Nilai: nilai(70), CreatedAt: now},
+		{Id: 3, Jalur: domain.JalurPrestasi, Jarak: 900, Nilai: nilai(95), CreatedAt: now},
+	}
+	ppdb.Rank(prestasi)
+	assert.Equal(t, []int{3, 1, 2}, []int{prestasi[0].Id, prestasi[1].Id, prestasi[2].Id})
+}
+
+func TestPpdbSelectKuota(t *testing.T) {
+	pendaftars := []domain.Pendaftar{
+		{Id: 1, Jalur: domain.JalurPrestasi, Nilai: nilai(60)},
+		{Id: 2, Jalur: domain.JalurZonasi, Jarak: 100, Nilai: nilai(60)},
+		{Id: 3, Jalur: domain.JalurPrestasi, Nilai: nilai(90)},
+		{Id: 4, Jalur: domain.JalurPrestasi, Nilai: nilai(75)},
+		{Id: 5, Jalur: domain.JalurAfirmasi, Nilai: nilai(75)},
+	}
+	kuota := map[string]int{domain.JalurZonasi: 1, domain.JalurPrestasi: 3, domain.JalurAfirmasi: 0}
+	taken := map[string]int{domain.JalurPrestasi: 1}
+
+	selected := map[int]domain.Pendaftar{}
+	for _, pendaftar := range ppdb.Select(pendaftars, kuota, taken, -1) {
+		selected[pendaftar.Id] = pendaftar
+	}
+	assert.Len(t, selected, 5)
+
+	assert.Equal(t, domain.PendaftarAccepted, selected[2].Status)
+	assert.Equal(t, 1, selected[2].Peringkat)
+	assert.Equal(t, domain.PendaftarAccepted, selected[3].Status)
+	assert.Equal(t, 2, selected[3].Peringkat)
+	assert.Equal(t, domain.PendaftarAccepted, selected[4].Status)
+	assert.Equal(t, 3, selected[4].Peringkat)
+	assert.Equal(t, domain.PendaftarRejected, selected[1].Status)
+	assert.Equal(t, 4, selected[1].Peringkat)
+	assert.Equal(t, domain.PendaftarRejected, selected[5].Status)
+}
+
+func TestPpdbSelectSeats(t *testing.T) {
+	pendaftars := []domain.Pendaftar{
+		{Id: 1, Jalur: domain.JalurPrestasi, Nilai: nilai(90)},
+		{Id: 2, Jalur: domain.JalurPrestasi, Nilai: nilai(80)},
+		{Id: 3, Jalur: domain.JalurZonasi, Jarak: 100},
+		{Id: 4, Jalur: domain.JalurZonasi, Jarak: 200},
+	}
+	kuota := map[string]int{domain.JalurZonasi: 2, domain.JalurPrestasi: 2}
+
+	// the best of each jalur take the seats left, whatever the kuota of the jalur
+	status := map[int]string{}
+	for _, pendaftar := range ppdb.Select(pendaftars, kuota, nil, 3) {
+		status[pendaftar.Id] = pendaftar.Status
+	}
+	assert.Equal(t, map[int]string{1: domain.PendaftarAccepted, 2: domain.PendaftarAccepted, 3: domain.PendaftarAccepted, 4: domain.PendaftarRejected}, status)
+
+	for _, pendaftar := range ppdb.Select(pendaftars, kuota, nil, 0) {
+		assert.Equal(t, domain.PendaftarRejected, pendaftar.Status)
+	}
+}
+
+func TestPpdbMissingDokumen(t *testing.T) {
+	pendaftar := domain.Pendaftar{Jalur: domain.JalurPrestasi, Dokumen: []domain.Dokumen{{Jenis: ppdb.DokumenKartuKeluarga}, {Jenis: ppdb.DokumenKip}}}
+	assert.Equal(t, []string{ppdb.DokumenAktaKelahiran, ppdb.DokumenRapor, ppdb.DokumenSertifikat}, ppdb.MissingDokumen(pendaftar))
+
+	pendaftar = domain.Pendaftar{Jalur: domain.JalurZonasi, Dokumen: []domain.Dokumen{{Jenis: ppdb.DokumenKartuKeluarga}, {Jenis: ppdb.DokumenAktaKelahiran}}}
+	assert.Empty(t, ppdb.MissingDokumen(pendaftar))
+}
+
+func TestPpdbDokumenContentType(t *testing.T) {
+	contentType, ok := ppdb.DokumenContentType("application/PDF")
+	assert.True(t, ok)
+	assert.Equal(t, "application/pdf", contentType)
+
+	_, ok = ppdb.DokumenContentType("text/plain")
+	assert.False(t, ok)
+	_, ok = ppdb.DokumenContentType("")
+	assert.False(t, ok)
+}
+
+func TestPpdbUploadRejectsBadDokumen(t *testing.T) {
+	router := app.NewPpdbRouter(controller.NewPendaftarController(nil))
+	upload := func(path string, contentType string, body string) int {
+		request := httptest.NewRequest(http.MethodPut, "http://localhost:3000"+path, strings.NewReader(body))
+		request.Header.Set("Content-Type", contentType)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	assert.Equal(t, 404, upload("/api/v2/ppdb/pendaftars/1/dokumen/ijazah", "application/pdf", "%PDF"))
+	assert.Equal(t, 400, upload("/api/v2/ppdb/pendaftars/x/dokumen/kip", "application/pdf", "%PDF"))
+	assert.Equal(t, 415, upload("/api/v2/ppdb/pendaftars/1/dokumen/kip", "text/plain", "%PDF"))
+	assert.Equal(t, 400, upload("/api/v2/ppdb/pendaftars/1/dokumen/kip", "application/pdf", ""))
+}
+
+func TestPpdbAdmission(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	router := setupRouter(db)
+
+	call := func(method string, path string, contentType string, body string, headers map[string]string) (int, map[string]interface{}) {
+		request := httptest.NewRequest(method, "http://localhost:3000"+path, strings.NewReader(body))
+		request.Header.Add("Content-Type", contentType)
+		request.Header.Add("X-Sekolah", "p")
+		for key, value := range headers {
+			request.Header.Set(key, value)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		var responseBody map[string]interface{}
+		json.Unmarshal(recorder.Body.Bytes(), &responseBody)
+		return recorder.Code, responseBody
+	}
+	staff := map[string]string{"X-API-Key": "RAHASIA"}
+
+	code, _ := call(http.MethodPost, "/api/v2/sekolahs", "application/json", `{"kode" : "p", "nama" : "SMP P", "bahasa" : "id", "kuota_zonasi" : 1}`, staff)
+	if !assert.Equal(t, 201, code) {
+		return
+	}
+
+	register := func(nama string, jarak int) (string, string) {
+		code, responseBody := call(http.MethodPost, "/api/v2/ppdb/pendaftars", "application/json", `{"jalur" : "zonasi", "nama" : "`+nama+`", "alamat" : "Jakarta", "tanggal_lahir" : "2013-01-01", "tempat_lahir" : "Jakarta", "jenis_kelamin" : "L", "agama" : "Islam", "golongan_darah" : "O", "no_telepon" : "0812", "asal_sekolah" : "SD 1", "jarak" : `+strconv.Itoa(jarak)+`}`, nil)
+		assert.Equal(t, 201, code)
+		data := responseBody["data"].(map[string]interface{})
+		assert.Equal(t, "registered", data["status"])
+		return strconv.Itoa(int(data["id"].(float64))), data["token"].(string)
+	}
+	dekat, dekatToken := register("Dekat", 500)
+	jauh, jauhToken := register("Jauh", 900)
+	dekatPath, jauhPath := "/api/v2/ppdb/pendaftars/"+dekat, "/api/v2/ppdb/pendaftars/"+jauh
+
+	code, _ = call(http.MethodGet, dekatPath, "", "", nil)
+	assert.Equal(t, 404, code)
+	code, _ = call(http.MethodGet, dekatPath, "", "", map[string]string{"X-Ppdb-Token": jauhToken})
+	assert.Equal(t, 404, code)
+	code, responseBody := call(http.MethodGet, dekatPath, "", "", map[string]string{"X-Ppdb-Token": dekatToken})
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "Dekat", responseBody["data"].(map[string]interface{})["nama"])
+
+	code, responseBody = call(http.MethodPost, "/api/v2/pendaftars/"+dekat+"/verify", "", "", staff)
+	assert.Equal(t, 422, code)
+
+	for _, pendaftar := range []struct{ path, token string }{{dekatPath, dekatToken}, {jauhPath, jauhToken}} {
+		token := map[string]string{"X-Ppdb-Token": pendaftar.token}
+		code, _ = call(http.MethodPut, pendaftar.path+"/dokumen/akta_kelahiran", "application/pdf", "%PDF-akta", token)
+		assert.Equal(t, 200, code)
+		code, _ = call(http.MethodPut, pendaftar.path+"/dokumen/kartu_keluarga", "image/png", "PNG-kk", token)
+		assert.Equal(t, 200, code)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v2/pendaftars/"+dekat+"/dokumen/kartu_keluarga", nil)
+	request.Header.Add("X-Sekolah", "p")
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "PNG-kk", recorder.Body.String())
+
+	for _, id := range []string{dekat, jauh} {
+		code, _ = call(http.MethodPost, "/api/v2/pendaftars/"+id+"/verify", "", "", staff)
+		assert.Equal(t, 200, code)
+		code, _ = call(http.MethodPost, "/api/v2/pendaftars/"+id+"/score", "application/json", `{"nilai" : 80}`, staff)
+		assert.Equal(t, 200, code)
+	}
+	code, _ = call(http.MethodPut, dekatPath+"/dokumen/kip", "application/pdf", "%PDF-kip", map[string]string{"X-Ppdb-Token": dekatToken})
+	assert.Equal(t, 409, code)
+
+	code, responseBody = call(http.MethodPost, "/api/v2/pendaftars/select", "", "", staff)
+	assert.Equal(t, 200, code)
+	assert.Len(t, responseBody["data"], 2)
+
+	code, responseBody = call(http.MethodGet, dekatPath, "", "", map[string]string{"X-Ppdb-Token": dekatToken})
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "tested", responseBody["data"].(map[string]interface{})["status"])
+	assert.Nil(t, responseBody["data"].(map[string]interface{})["peringkat"])
+	code, _ = call(http.MethodPost, "/api/v2/pendaftars/"+dekat+"/re-register", "", "", staff)
+	assert.Equal(t, 409, code)
+
+	code, responseBody = call(http.MethodPost, "/api/v2/pendaftars/announce", "", "", staff)
+	assert.Equal(t, 200, code)
+	assert.Len(t, responseBody["data"], 2)
+
+	code, responseBody = call(http.MethodGet, "/api/v2/ppdb/pengumuman", "", "", nil)
+	assert.Equal(t, 200, code)
+	pengumuman := responseBody["data"].([]interface{})
+	if assert.Len(t, pengumuman, 1) {
+		assert.Equal(t, "Dekat", pengumuman[0].(map[string]interface{})["nama"])
+		assert.Equal(t, float64(1), pengumuman[0].(map[string]interface{})["peringkat"])
+	}
+	code, responseBody = call(http.MethodGet, jauhPath, "", "", map[string]string{"X-Ppdb-Token": jauhToken})
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "rejected", responseBody["data"].(map[string]interface{})["status"])
+
+	code, _ = call(http.MethodPost, "/api/v2/pendaftars/"+jauh+"/re-register", "", "", staff)
+	assert.Equal(t, 409, code)
+	code, responseBody = call(http.MethodPost, "/api/v2/pendaftars/"+dekat+"/re-register", "", "", staff)
+	if !assert.Equal(t, 200, code) {
+		return
+	}
+	data := responseBody["data"].(map[string]interface{})
+	assert.Equal(t, "re-registered", data["status"])
+
+	// a retry returns the same siswa instead of creating another
+	code, responseBody = call(http.MethodPost, "/api/v2/pendaftars/"+dekat+"/re-register", "", "", staff)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, data["siswa_id"], responseBody["data"].(map[string]interface{})["siswa_id"])
+
+	code, responseBody = call(http.MethodGet, "/api/v2/siswas/"+strconv.Itoa(int(data["siswa_id"].(float64))), "", "", staff)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "Dekat", responseBody["data"].(map[string]interface{})["nama"])
+}
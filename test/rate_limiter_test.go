@@ -0,0 +1,81 @@
+package test
+
+import (
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterRejectsExhaustedBucket(t *testing.T) {
+	limiter := middleware.NewRateLimiter(middleware.RateLimit{Requests: 2, Per: time.Minute})
+	handle := limiter.Handle(func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	var response *http.Response
+	for i := 0; i < 3; i++ {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas", nil)
+		request.Header.Add("X-API-Key", "RAHASIA")
+		recorder := httptest.NewRecorder()
+		handle(recorder, request, nil)
+		response = recorder.Result()
+
+		if i == 0 {
+			assert.Equal(t, 200, response.StatusCode)
+			assert.Equal(t, "2", response.Header.Get("RateLimit-Limit"))
+			assert.Equal(t, "1", response.Header.Get("RateLimit-Remaining"))
+		}
+	}
+
+	assert.Equal(t, 429, response.StatusCode)
+	assert.Equal(t, "0", response.Header.Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", response.Header.Get("Retry-After"))
+
+	body, _ := io.ReadAll(response.Body)
+	var responseBody map[string]interface{}
+	json.Unmarshal(body, &responseBody)
+
+	assert.Equal(t, 429, int(responseBody["code"].(float64)))
+	assert.Equal(t, "TOO MANY REQUESTS", responseBody["status"])
+
+	// another API key has its own bucket
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas", nil)
+	request.Header.Add("X-API-Key", "LAIN")
+	recorder := httptest.NewRecorder()
+	handle(recorder, request, nil)
+	assert.Equal(t, 200, recorder.Result().StatusCode)
+}
+
+func TestAuthMiddlewareLocksOutAfterFailures(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	})
+	auth := middleware.NewAuthMiddleware(handler, middleware.NewAuthLockout(2, time.Minute, time.Hour), service.NewUserService(nil, nil, nil, nil, nil, "RAHASIA"))
+
+	serve := func(apiKey string) *http.Response {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v2/siswas", nil)
+		request.Header.Add("X-API-Key", apiKey)
+		recorder := httptest.NewRecorder()
+		auth.ServeHTTP(recorder, request)
+		return recorder.Result()
+	}
+
+	assert.Equal(t, 401, serve("SALAH").StatusCode)
+	assert.Equal(t, 401, serve("SALAH").StatusCode)
+
+	// another key counts against the same address
+	response := serve("KELIRU")
+	assert.Equal(t, 429, response.StatusCode)
+	assert.Equal(t, "application/problem+json", response.Header.Get("Content-Type"))
+	assert.Equal(t, "3600", response.Header.Get("Retry-After"))
+
+	// a locked out address is refused even with the valid key
+	assert.Equal(t, 429, serve("RAHASIA").StatusCode)
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/app"
+	"github.com/Arraf18/go-sisko/controller"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/graph"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func decodeSiswaCreateRequest(contentType string, body string) web.WebResponse {
+	router := httprouter.New()
+	router.PanicHandler = exception.ErrorHandler
+	router.POST("/api/siswas", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		siswaCreateRequest := web.SiswaCreateRequest{}
+		helper.ReadFromRequestBody(request, &siswaCreateRequest)
+		helper.WriteToResponseBody(writer, web.WebResponse{Code: 200, Status: "OK"})
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/siswas", strings.NewReader(body))
+	request.Header.Add("Content-Type", contentType)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	responseBody, _ := io.ReadAll(recorder.Result().Body)
+	var webResponse web.WebResponse
+	json.Unmarshal(responseBody, &webResponse)
+	return webResponse
+}
+
+func TestReadFromRequestBodyStrict(t *testing.T) {
+	webResponse := decodeSiswaCreateRequest("application/json; charset=utf-8", `{"nama" : "Gadget"}`)
+	assert.Equal(t, 200, webResponse.Code)
+
+	webResponse = decodeSiswaCreateRequest("application/json", `{"name" : "Gadget"}`)
+	assert.Equal(t, 400, webResponse.Code)
+	assert.Equal(t, "BAD REQUEST", webResponse.Status)
+	assert.Contains(t, webResponse.Data, `unknown field "name"`)
+
+	webResponse = decodeSiswaCreateRequest("application/json", `{"nama" : 1}`)
+	assert.Equal(t, 400, webResponse.Code)
+	assert.Equal(t, `field "nama" must be a string, got number at offset 11`, webResponse.Data)
+
+	webResponse = decodeSiswaCreateRequest("application/json", `{"nama" : "Gadget",}`)
+	assert.Equal(t, 400, webResponse.Code)
+	assert.Contains(t, webResponse.Data, "malformed JSON at offset 20")
+
+	webResponse = decodeSiswaCreateRequest("application/json", `{"nama" : "Gadget"} {}`)
+	assert.Equal(t, 400, webResponse.Code)
+	assert.Contains(t, webResponse.Data, "unexpected data after the JSON value")
+
+	webResponse = decodeSiswaCreateRequest("text/plain", `{"nama" : "Gadget"}`)
+	assert.Equal(t, 415, webResponse.Code)
+	assert.Equal(t, "UNSUPPORTED MEDIA TYPE", webResponse.Status)
+}
+
+func TestNonNumericSiswaId(t *testing.T) {
+	router := app.NewRouter(controller.NewSiswaController(nil), controller.NewSiswaControllerV2(nil), controller.NewAuditController(nil), controller.NewGraphqlController(graph.NewSchema(nil, nil), nil, nil, 1000), controller.NewWebhookController(nil), controller.NewJobController(nil), controller.NewNotificationController(nil), controller.NewSekolahController(nil), controller.NewPendaftarController(nil))
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas/abc", nil)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 400, response.StatusCode)
+
+	body, _ := io.ReadAll(response.Body)
+	var responseBody map[string]interface{}
+	json.Unmarshal(body, &responseBody)
+
+	assert.Equal(t, "BAD REQUEST", responseBody["status"])
+	assert.Equal(t, `siswaId must be a number, got "abc"`, responseBody["data"])
+}
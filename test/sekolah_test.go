@@ -0,0 +1,210 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/cache"
+	"github.com/Arraf18/go-sisko/exception"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/go-playground/validator"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withDefaultSekolah scopes ctx to the school every deployment starts with.
+func withDefaultSekolah(ctx context.Context) context.Context {
+	return helper.WithSekolah(ctx, domain.Sekolah{Id: 1, Kode: "default", Bahasa: "id"}, false)
+}
+
+// boundSekolahService knows schools a and b and binds the user guru to b.
+type boundSekolahService struct {
+	service.SekolahService
+	kodes []string
+}
+
+func (service *boundSekolahService) Resolve(ctx context.Context, username string, kode string) (domain.Sekolah, bool) {
+	service.kodes = append(service.kodes, kode)
+	if username == "guru" {
+		if kode != "" && kode != "b" {
+			panic(exception.NewForbiddenError(username + " may only work on sekolah b"))
+		}
+		return domain.Sekolah{Id: 2, Kode: "b"}, true
+	}
+	if kode == "b" {
+		return domain.Sekolah{Id: 2, Kode: "b"}, false
+	}
+	return domain.Sekolah{Id: 1, Kode: "a"}, false
+}
+
+func TestTenantSubdomain(t *testing.T) {
+	assert.Equal(t, "smpn1", middleware.Subdomain("smpn1.sisko.example.id", "sisko.example.id"))
+	assert.Equal(t, "smpn1", middleware.Subdomain("SMPN1.Sisko.Example.Id:3000", "sisko.example.id"))
+	assert.Equal(t, "", middleware.Subdomain("sisko.example.id", "sisko.example.id"))
+	assert.Equal(t, "", middleware.Subdomain("a.b.sisko.example.id", "sisko.example.id"))
+	assert.Equal(t, "", middleware.Subdomain("smpn1.example.org", "sisko.example.id"))
+	assert.Equal(t, "", middleware.Subdomain("smpn1.sisko.example.id", ""))
+}
+
+func TestTenantMiddlewareResolvesSekolah(t *testing.T) {
+	sekolahService := &boundSekolahService{}
+	var sekolah domain.Sekolah
+	var restricted bool
+	handler := middleware.NewTenantMiddleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		sekolah, _ = helper.SekolahFromContext(request.Context())
+		restricted = helper.RestrictedToSekolah(request.Context())
+	}), sekolahService, "sisko.example.id")
+
+	serve := func(host string, kode string, actor string) int {
+		request := httptest.NewRequest(http.MethodGet, "http://"+host+"/api/v2/siswas", nil)
+		if kode != "" {
+			request.Header.Add("X-Sekolah", kode)
+		}
+		request = request.WithContext(helper.WithActor(request.Context(), actor))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	assert.Equal(t, 200, serve("a.sisko.example.id", "B", "admin"))
+	assert.Equal(t, "b", sekolah.Kode)
+	assert.False(t, restricted)
+
+	assert.Equal(t, 200, serve("a.sisko.example.id", "", "admin"))
+	assert.Equal(t, "a", sekolah.Kode)
+
+	assert.Equal(t, 200, serve("localhost", "", "guru"))
+	assert.Equal(t, "b", sekolah.Kode)
+	assert.True(t, restricted)
+
+	assert.Equal(t, 403, serve("localhost", "a", "guru"))
+	assert.Equal(t, []string{"b", "a", "", "a"}, sekolahService.kodes)
+}
+
+func TestTenantYayasanOnly(t *testing.T) {
+	router := httprouter.New()
+	router.PanicHandler = exception.ErrorHandler
+	router.GET("/api/v2/webhooks", middleware.YayasanOnly(func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {}))
+
+	serve := func(restricted bool) int {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v2/webhooks", nil)
+		request = request.WithContext(helper.WithSekolah(request.Context(), domain.Sekolah{Id: 2, Kode: "b"}, restricted))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	assert.Equal(t, 200, serve(false))
+	assert.Equal(t, 403, serve(true))
+}
+
+func TestTenantSiswaRepositoryRequiresSekolah(t *testing.T) {
+	assert.PanicsWithError(t, "siswa queried without a sekolah", func() {
+		repository.NewSiswaRepository().FindAll(context.Background(), nil)
+	})
+}
+
+// sekolahSiswaService names every siswa after the school it is read from.
+type sekolahSiswaService struct {
+	service.SiswaService
+}
+
+func (service *sekolahSiswaService) FindById(ctx context.Context, siswaId int) web.SiswaResponse {
+	sekolah, _ := helper.SekolahFromContext(ctx)
+	return web.SiswaResponse{Id: siswaId, Nama: sekolah.Kode, Version: 1}
+}
+
+func TestTenantCacheKeepsSekolahApart(t *testing.T) {
+	siswaService := service.NewSiswaServiceCache(&sekolahSiswaService{}, cache.NewLRUCache(100), time.Minute)
+	a := helper.WithSekolah(context.Background(), domain.Sekolah{Id: 1, Kode: "a"}, false)
+	b := helper.WithSekolah(context.Background(), domain.Sekolah{Id: 2, Kode: "b"}, false)
+
+	assert.Equal(t, "a", siswaService.FindById(a, 1).Nama)
+	assert.Equal(t, "b", siswaService.FindById(b, 1).Nama)
+	assert.Equal(t, "a", siswaService.FindById(a, 1).Nama)
+}
+
+// TestSekolahIsolatesSiswa proves a school can never reach the siswa of
+// another, whichever way it asks.
+func TestSekolahIsolatesSiswa(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	router := setupRouter(db)
+
+	call := func(method string, path string, body string, headers map[string]string) (int, map[string]interface{}) {
+		request := httptest.NewRequest(method, "http://localhost:3000"+path, strings.NewReader(body))
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("X-API-Key", "RAHASIA")
+		for key, value := range headers {
+			request.Header.Set(key, value)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		var responseBody map[string]interface{}
+		json.Unmarshal(recorder.Body.Bytes(), &responseBody)
+		return recorder.Code, responseBody
+	}
+
+	code, _ := call(http.MethodPost, "/api/v2/sekolahs", `{"kode" : "a", "nama" : "SMP A", "bahasa" : "id"}`, nil)
+	if !assert.Equal(t, 201, code) {
+		return
+	}
+	code, _ = call(http.MethodPost, "/api/v2/sekolahs", `{"kode" : "b", "nama" : "SMP B", "bahasa" : "en"}`, nil)
+	assert.Equal(t, 201, code)
+
+	a := map[string]string{"X-Sekolah": "a"}
+	b := map[string]string{"X-Sekolah": "b"}
+	code, responseBody := call(http.MethodPost, "/api/v2/siswas", `{"nama" : "Gadget", "alamat" : "Jakarta", "tanggal_lahir" : "2010-01-01", "tempat_lahir" : "Jakarta", "jenis_kelamin" : "L", "agama" : "Islam", "golongan_darah" : "O", "no_telepon" : "0812"}`, a)
+	if !assert.Equal(t, 201, code) {
+		return
+	}
+	siswaPath := "/api/v2/siswas/" + strconv.Itoa(int(responseBody["data"].(map[string]interface{})["id"].(float64)))
+	ifMatch := map[string]string{"X-Sekolah": "b", "If-Match": helper.ToETag(1)}
+
+	code, _ = call(http.MethodGet, siswaPath, "", b)
+	assert.Equal(t, 404, code)
+	code, _ = call(http.MethodPut, siswaPath, `{"nama" : "Budi", "alamat" : "Bandung", "tanggal_lahir" : "2010-01-01", "tempat_lahir" : "Bandung", "jenis_kelamin" : "L", "agama" : "Islam", "golongan_darah" : "O", "no_telepon" : "0812"}`, ifMatch)
+	assert.Equal(t, 404, code)
+	code, _ = call(http.MethodDelete, siswaPath, "", ifMatch)
+	assert.Equal(t, 404, code)
+	code, responseBody = call(http.MethodGet, siswaPath+"/history", "", b)
+	assert.Equal(t, 200, code)
+	assert.Empty(t, responseBody["data"])
+	code, responseBody = call(http.MethodGet, "/api/v2/siswas", "", b)
+	assert.Equal(t, 200, code)
+	assert.Empty(t, responseBody["data"])
+
+	code, responseBody = call(http.MethodGet, siswaPath, "", a)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "Gadget", responseBody["data"].(map[string]interface{})["nama"])
+
+	validate := validator.New()
+	userService := service.NewUserService(repository.NewUserRepository(), repository.NewApiKeyRepository(), repository.NewSekolahRepository(), db, validate, "RAHASIA")
+	var key string
+	func() {
+		defer func() { assert.Nil(t, recover()) }()
+		userService.Create(context.Background(), web.UserCreateRequest{Username: "gurub", Nama: "Guru B", Sekolah: "b"})
+		key = userService.CreateApiKey(context.Background(), web.ApiKeyCreateRequest{Username: "gurub", Name: "test"}).Key
+	}()
+	if key == "" {
+		return
+	}
+
+	code, _ = call(http.MethodGet, siswaPath, "", map[string]string{"X-API-Key": key, "X-Sekolah": "a"})
+	assert.Equal(t, 403, code)
+	code, _ = call(http.MethodGet, siswaPath, "", map[string]string{"X-API-Key": key})
+	assert.Equal(t, 404, code)
+	code, _ = call(http.MethodGet, "/api/v2/sekolahs", "", map[string]string{"X-API-Key": key})
+	assert.Equal(t, 403, code)
+}
@@ -7,12 +7,16 @@ import (
 	"fmt"
 	"github.com/Arraf18/go-sisko/app"
 	"github.com/Arraf18/go-sisko/controller"
+	"github.com/Arraf18/go-sisko/graph"
 	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/job"
 	"github.com/Arraf18/go-sisko/middleware"
 	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
 	"github.com/Arraf18/go-sisko/repository"
 	"github.com/Arraf18/go-sisko/service"
 	"github.com/go-playground/validator"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"net/http"
@@ -24,7 +28,7 @@ import (
 )
 
 func setupTestDB() *sql.DB {
-	db, err := sql.Open("mysql", "root@tcp(localhost:3306)/go_sisko")
+	db, err := sql.Open("mysql", "root@tcp(localhost:3306)/go_sisko?parseTime=true")
 	helper.PanicIfError(err)
 
 	db.SetMaxIdleConns(5)
@@ -38,15 +42,49 @@ func setupTestDB() *sql.DB {
 func setupRouter(db *sql.DB) http.Handler {
 	validate := validator.New()
 	siswaRepository := repository.NewSiswaRepository()
-	siswaService := service.NewSiswaService(siswaRepository, db, validate)
+	auditRepository := repository.NewAuditRepository()
+	siswaService := service.NewSiswaService(siswaRepository, auditRepository, repository.NewOutboxEventRepository(), db, validate)
+	auditService := service.NewAuditService(auditRepository, db)
 	siswaController := controller.NewSiswaController(siswaService)
-	router := app.NewRouter(siswaController)
-
-	return middleware.NewAuthMiddleware(router)
+	siswaControllerV2 := controller.NewSiswaControllerV2(siswaService)
+	auditController := controller.NewAuditController(auditService)
+	graphqlController := controller.NewGraphqlController(graph.NewSchema(siswaService, auditService), siswaService, auditService, 1000)
+	webhookController := controller.NewWebhookController(service.NewWebhookService(repository.NewWebhookSubscriptionRepository(), repository.NewWebhookDeliveryRepository(), db, validate, http.DefaultClient, 20))
+	jobService := service.NewJobService(repository.NewJobRepository(), db, validate)
+	jobController := controller.NewJobController(jobService)
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(), repository.NewNotificationPreferenceRepository(), siswaRepository, db, validate, job.NewRunner(db, repository.NewJobRepository(), 1), nil)
+	notificationController := controller.NewNotificationController(notificationService)
+	sekolahService := service.NewSekolahService(repository.NewSekolahRepository(), repository.NewUserRepository(), db, validate, "default")
+	sekolahController := controller.NewSekolahController(sekolahService)
+	pendaftarController := controller.NewPendaftarController(service.NewPendaftarService(repository.NewPendaftarRepository(), siswaService, db, validate))
+	router := app.NewRouter(siswaController, siswaControllerV2, auditController, graphqlController, webhookController, jobController, notificationController, sekolahController, pendaftarController)
+	idempotencyService := service.NewIdempotencyService(repository.NewIdempotencyKeyRepository(), db, time.Hour)
+
+	var handler http.Handler = router
+	handler = middleware.NewIdempotencyMiddleware(handler, idempotencyService)
+	handler = middleware.NewTenantMiddleware(handler, sekolahService, "")
+	handler = middleware.NewAuthMiddleware(handler, app.NewAuthLockout(), service.NewUserService(repository.NewUserRepository(), repository.NewApiKeyRepository(), repository.NewSekolahRepository(), db, validate, "RAHASIA"))
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/v2/ppdb/", middleware.NewTenantMiddleware(app.NewPpdbRouter(pendaftarController), sekolahService, ""))
+	mux.Handle("/", handler)
+	return middleware.NewLoggingMiddleware(mux, logrus.StandardLogger())
 }
 
 func truncateSiswa(db *sql.DB) {
 	db.Exec("TRUNCATE siswa")
+	db.Exec("TRUNCATE audit")
+	db.Exec("TRUNCATE idempotency_key")
+	db.Exec("TRUNCATE outbox_event")
+	db.Exec("DELETE FROM webhook_subscription")
+	db.Exec("TRUNCATE job")
+	db.Exec("TRUNCATE notification")
+	db.Exec("TRUNCATE notification_preference")
+	db.Exec("TRUNCATE pendaftar_dokumen")
+	db.Exec("TRUNCATE pendaftar")
+	db.Exec("DELETE FROM api_key WHERE user_id IN (SELECT id FROM user_account WHERE sekolah_id IS NOT NULL)")
+	db.Exec("DELETE FROM user_account WHERE sekolah_id IS NOT NULL")
+	db.Exec("DELETE FROM sekolah WHERE id <> 1")
 }
 
 func TestCreateSiswaSuccess(t *testing.T) {
@@ -54,7 +92,7 @@ func TestCreateSiswaSuccess(t *testing.T) {
 	truncateSiswa(db)
 	router := setupRouter(db)
 
-	requestBody := strings.NewReader(`{"name" : "Gadget"}`)
+	requestBody := strings.NewReader(`{"nama" : "Gadget", "alamat" : "Jakarta", "tanggal_lahir" : "2010-01-01", "tempat_lahir" : "Jakarta", "jenis_kelamin" : "L", "agama" : "Islam", "golongan_darah" : "O", "no_telepon" : "0812"}`)
 	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/siswas", requestBody)
 	request.Header.Add("Content-Type", "application/json")
 	request.Header.Add("X-API-Key", "RAHASIA")
@@ -71,7 +109,7 @@ func TestCreateSiswaSuccess(t *testing.T) {
 
 	assert.Equal(t, 200, int(responseBody["code"].(float64)))
 	assert.Equal(t, "OK", responseBody["status"])
-	assert.Equal(t, "Gadget", responseBody["data"].(map[string]interface{})["name"])
+	assert.Equal(t, "Gadget", responseBody["data"].(map[string]interface{})["nama"])
 }
 
 func TestCreateSiswaFailed(t *testing.T) {
@@ -105,17 +143,18 @@ func TestUpdateSiswaSuccess(t *testing.T) {
 
 	tx, _ := db.Begin()
 	siswaRepository := repository.NewSiswaRepository()
-	siswa := siswaRepository.Save(context.Background(), tx, domain.Siswa{
+	siswa := siswaRepository.Save(withDefaultSekolah(context.Background()), tx, domain.Siswa{
 		Nama: "Gadget",
 	})
 	tx.Commit()
 
 	router := setupRouter(db)
 
-	requestBody := strings.NewReader(`{"name" : "Gadget"}`)
+	requestBody := strings.NewReader(`{"nama" : "Gadget", "alamat" : "Jakarta", "tanggal_lahir" : "2010-01-01", "tempat_lahir" : "Jakarta", "jenis_kelamin" : "L", "agama" : "Islam", "golongan_darah" : "O", "no_telepon" : "0812"}`)
 	request := httptest.NewRequest(http.MethodPut, "http://localhost:3000/api/siswas/"+strconv.Itoa(siswa.Id), requestBody)
 	request.Header.Add("Content-Type", "application/json")
 	request.Header.Add("X-API-Key", "RAHASIA")
+	request.Header.Add("If-Match", helper.ToETag(siswa.Version))
 
 	recorder := httptest.NewRecorder()
 
@@ -131,7 +170,7 @@ func TestUpdateSiswaSuccess(t *testing.T) {
 	assert.Equal(t, 200, int(responseBody["code"].(float64)))
 	assert.Equal(t, "OK", responseBody["status"])
 	assert.Equal(t, siswa.Id, int(responseBody["data"].(map[string]interface{})["id"].(float64)))
-	assert.Equal(t, "Gadget", responseBody["data"].(map[string]interface{})["name"])
+	assert.Equal(t, "Gadget", responseBody["data"].(map[string]interface{})["nama"])
 }
 
 func TestUpdateSiswaFailed(t *testing.T) {
@@ -140,7 +179,7 @@ func TestUpdateSiswaFailed(t *testing.T) {
 
 	tx, _ := db.Begin()
 	siswaRepository := repository.NewSiswaRepository()
-	siswa := siswaRepository.Save(context.Background(), tx, domain.Siswa{
+	siswa := siswaRepository.Save(withDefaultSekolah(context.Background()), tx, domain.Siswa{
 		Nama: "Gadget",
 	})
 	tx.Commit()
@@ -168,13 +207,109 @@ func TestUpdateSiswaFailed(t *testing.T) {
 
 }
 
+func TestUpdateSiswaPreconditionFailed(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+
+	tx, _ := db.Begin()
+	siswaRepository := repository.NewSiswaRepository()
+	siswa := siswaRepository.Save(withDefaultSekolah(context.Background()), tx, domain.Siswa{
+		Nama: "Gadget",
+	})
+	tx.Commit()
+
+	router := setupRouter(db)
+
+	requestBody := strings.NewReader(`{"nama" : "Gadget", "alamat" : "Jakarta", "tanggal_lahir" : "2010-01-01", "tempat_lahir" : "Jakarta", "jenis_kelamin" : "L", "agama" : "Islam", "golongan_darah" : "O", "no_telepon" : "0812"}`)
+	request := httptest.NewRequest(http.MethodPut, "http://localhost:3000/api/siswas/"+strconv.Itoa(siswa.Id), requestBody)
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("X-API-Key", "RAHASIA")
+	request.Header.Add("If-Match", helper.ToETag(siswa.Version+1))
+
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 412, response.StatusCode)
+
+	body, _ := io.ReadAll(response.Body)
+	var responseBody map[string]interface{}
+	json.Unmarshal(body, &responseBody)
+
+	assert.Equal(t, 412, int(responseBody["code"].(float64)))
+	assert.Equal(t, "PRECONDITION FAILED", responseBody["status"])
+}
+
+func TestPatchSiswaAnyVersion(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+
+	tx, _ := db.Begin()
+	siswaRepository := repository.NewSiswaRepository()
+	siswa := siswaRepository.Save(withDefaultSekolah(context.Background()), tx, domain.Siswa{
+		Nama: "Gadget", Alamat: "Jakarta", TanggalLahir: "2010-01-01", TempatLahir: "Jakarta", JenisKelamin: "L", Agama: "Islam", GolonganDarah: "O", NoTelepon: "0812",
+	})
+	tx.Commit()
+
+	router := setupRouter(db)
+
+	requestBody := strings.NewReader(`{"alamat" : "Bandung"}`)
+	request := httptest.NewRequest(http.MethodPatch, "http://localhost:3000/api/v2/siswas/"+strconv.Itoa(siswa.Id), requestBody)
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("X-API-Key", "RAHASIA")
+	request.Header.Add("If-Match", "*")
+
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, helper.ToETag(siswa.Version+1), response.Header.Get("ETag"))
+
+	body, _ := io.ReadAll(response.Body)
+	var responseBody map[string]interface{}
+	json.Unmarshal(body, &responseBody)
+
+	data := responseBody["data"].(map[string]interface{})
+	assert.Equal(t, "Gadget", data["nama"])
+	assert.Equal(t, "Bandung", data["alamat"])
+}
+
+func TestGetSiswaNotModified(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+
+	tx, _ := db.Begin()
+	siswaRepository := repository.NewSiswaRepository()
+	siswa := siswaRepository.Save(withDefaultSekolah(context.Background()), tx, domain.Siswa{
+		Nama: "Gadget",
+	})
+	tx.Commit()
+
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas/"+strconv.Itoa(siswa.Id), nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	request.Header.Add("If-None-Match", helper.ToETag(siswa.Version))
+
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 304, response.StatusCode)
+	assert.Equal(t, helper.ToETag(siswa.Version), response.Header.Get("ETag"))
+}
+
 func TestGetSiswaSuccess(t *testing.T) {
 	db := setupTestDB()
 	truncateSiswa(db)
 
 	tx, _ := db.Begin()
 	siswaRepository := repository.NewSiswaRepository()
-	siswa := siswaRepository.Save(context.Background(), tx, domain.Siswa{
+	siswa := siswaRepository.Save(withDefaultSekolah(context.Background()), tx, domain.Siswa{
 		Nama: "Gadget",
 	})
 	tx.Commit()
@@ -198,7 +333,7 @@ func TestGetSiswaSuccess(t *testing.T) {
 	assert.Equal(t, 200, int(responseBody["code"].(float64)))
 	assert.Equal(t, "OK", responseBody["status"])
 	assert.Equal(t, siswa.Id, int(responseBody["data"].(map[string]interface{})["id"].(float64)))
-	assert.Equal(t, siswa.Nama, responseBody["data"].(map[string]interface{})["name"])
+	assert.Equal(t, siswa.Nama, responseBody["data"].(map[string]interface{})["nama"])
 	assert.Equal(t, siswa.Alamat, responseBody["data"].(map[string]interface{})["alamat"])
 	assert.Equal(t, siswa.TanggalLahir, responseBody["data"].(map[string]interface{})["tanggal_lahir"])
 	assert.Equal(t, siswa.TempatLahir, responseBody["data"].(map[string]interface{})["tempat_lahir"])
@@ -214,7 +349,7 @@ func TestGetSiswaFailed(t *testing.T) {
 
 	tx, _ := db.Begin()
 	siswaRepository := repository.NewSiswaRepository()
-	siswa := siswaRepository.Save(context.Background(), tx, domain.Siswa{
+	siswa := siswaRepository.Save(withDefaultSekolah(context.Background()), tx, domain.Siswa{
 		Nama: "Gadget",
 	})
 	tx.Commit()
@@ -245,7 +380,7 @@ func TestDeleteSiswaSuccess(t *testing.T) {
 
 	tx, _ := db.Begin()
 	siswaRepository := repository.NewSiswaRepository()
-	siswa := siswaRepository.Save(context.Background(), tx, domain.Siswa{
+	siswa := siswaRepository.Save(withDefaultSekolah(context.Background()), tx, domain.Siswa{
 		Nama: "Gadget",
 	})
 	tx.Commit()
@@ -255,6 +390,7 @@ func TestDeleteSiswaSuccess(t *testing.T) {
 	request := httptest.NewRequest(http.MethodPut, "http://localhost:3000/api/siswas/"+strconv.Itoa(siswa.Id), nil)
 	request.Header.Add("Content-Type", "application/json")
 	request.Header.Add("X-API-Key", "RAHASIA")
+	request.Header.Add("If-Match", helper.ToETag(siswa.Version))
 
 	recorder := httptest.NewRecorder()
 
@@ -295,16 +431,332 @@ func TestDeleteSiswaFailed(t *testing.T) {
 	assert.Equal(t, "NOT FOUND", responseBody["status"])
 }
 
+func TestRestoreSiswaSuccess(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+
+	tx, _ := db.Begin()
+	siswaRepository := repository.NewSiswaRepository()
+	siswa := siswaRepository.Save(withDefaultSekolah(context.Background()), tx, domain.Siswa{
+		Nama: "Gadget",
+	})
+	tx.Commit()
+
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodDelete, "http://localhost:3000/api/siswas/"+strconv.Itoa(siswa.Id), nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	request.Header.Add("If-Match", helper.ToETag(siswa.Version))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas/trash", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	body, _ := io.ReadAll(recorder.Result().Body)
+	var responseBody map[string]interface{}
+	json.Unmarshal(body, &responseBody)
+
+	var siswas = responseBody["data"].([]interface{})
+	assert.Equal(t, 1, len(siswas))
+	assert.Equal(t, "admin", siswas[0].(map[string]interface{})["deleted_by"])
+
+	request = httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/siswas/"+strconv.Itoa(siswa.Id)+"/restore", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas/"+strconv.Itoa(siswa.Id), nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Result().StatusCode)
+}
+
+func TestSiswaHistorySuccess(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+
+	tx, _ := db.Begin()
+	siswaRepository := repository.NewSiswaRepository()
+	siswa := siswaRepository.Save(withDefaultSekolah(context.Background()), tx, domain.Siswa{
+		Nama: "Gadget",
+	})
+	tx.Commit()
+
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodDelete, "http://localhost:3000/api/siswas/"+strconv.Itoa(siswa.Id), nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	request.Header.Add("X-Request-ID", "request-1")
+	request.Header.Add("If-Match", helper.ToETag(siswa.Version))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas/"+strconv.Itoa(siswa.Id)+"/history", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 200, response.StatusCode)
+
+	body, _ := io.ReadAll(response.Body)
+	var responseBody map[string]interface{}
+	json.Unmarshal(body, &responseBody)
+
+	var audits = responseBody["data"].([]interface{})
+	assert.Equal(t, 1, len(audits))
+
+	audit := audits[0].(map[string]interface{})
+	assert.Equal(t, "delete", audit["action"])
+	assert.Equal(t, "admin", audit["actor"])
+	assert.Equal(t, "request-1", audit["request_id"])
+	assert.Equal(t, "deleted_at", audit["changes"].([]interface{})[0].(map[string]interface{})["field"])
+}
+
+func TestSearchSiswaPages(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+
+	ctx := withDefaultSekolah(context.Background())
+	tx, _ := db.Begin()
+	siswaRepository := repository.NewSiswaRepository()
+	gadget := siswaRepository.Save(ctx, tx, domain.Siswa{Nama: "Gadget", JenisKelamin: "L", TanggalLahir: "2010-01-01"})
+	siswaRepository.Save(ctx, tx, domain.Siswa{Nama: "Citra", JenisKelamin: "P", TanggalLahir: "2011-05-01"})
+	budi := siswaRepository.Save(ctx, tx, domain.Siswa{Nama: "Budi 100%", JenisKelamin: "L", TanggalLahir: "2012-03-04T00:00:00Z"})
+	tx.Commit()
+
+	siswaService := service.NewSiswaService(siswaRepository, repository.NewAuditRepository(), repository.NewOutboxEventRepository(), db, validator.New())
+	page := siswaService.Search(ctx, web.SiswaSearchRequest{JenisKelamin: "l", First: 1})
+	assert.Equal(t, 2, page.TotalCount)
+	assert.True(t, page.HasNextPage)
+	assert.Equal(t, gadget.Id, page.Siswas[0].Id)
+
+	page = siswaService.Search(ctx, web.SiswaSearchRequest{JenisKelamin: "l", First: 1, After: gadget.Id})
+	assert.False(t, page.HasNextPage)
+	assert.Equal(t, budi.Id, page.Siswas[0].Id)
+
+	page = siswaService.Search(ctx, web.SiswaSearchRequest{Nama: "0%", TanggalLahirFrom: "2012-03-04", TanggalLahirTo: "2012-03-04", First: 20})
+	assert.Equal(t, 1, page.TotalCount)
+	assert.Equal(t, budi.Id, page.Siswas[0].Id)
+
+	page = siswaService.Search(ctx, web.SiswaSearchRequest{Trash: true, First: 20})
+	assert.Equal(t, 0, page.TotalCount)
+}
+
+func TestPurgeSiswaAuditsAndPublishes(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+
+	ctx := withDefaultSekolah(context.Background())
+	tx, _ := db.Begin()
+	siswaRepository := repository.NewSiswaRepository()
+	lama := siswaRepository.Save(ctx, tx, domain.Siswa{Nama: "Gadget", JenisKelamin: "L", TanggalLahir: "2010-01-01"})
+	baru := siswaRepository.Save(ctx, tx, domain.Siswa{Nama: "Citra", JenisKelamin: "P", TanggalLahir: "2011-05-01"})
+	trash := func(siswa domain.Siswa, deletedAt time.Time) {
+		siswa.DeletedAt = &deletedAt
+		siswa.DeletedBy = "admin"
+		siswaRepository.Delete(ctx, tx, siswa)
+	}
+	trash(lama, time.Now().Add(-48*time.Hour))
+	trash(baru, time.Now())
+	tx.Commit()
+
+	auditRepository := repository.NewAuditRepository()
+	outboxEventRepository := repository.NewOutboxEventRepository()
+	siswaService := service.NewSiswaService(siswaRepository, auditRepository, outboxEventRepository, db, validator.New())
+	assert.Equal(t, 1, siswaService.Purge(ctx, 24*time.Hour))
+
+	tx, _ = db.Begin()
+	defer tx.Commit()
+	assert.Len(t, siswaRepository.FindTrash(ctx, tx), 1)
+	audits := auditRepository.FindAll(ctx, tx, domain.AuditFilter{Entity: "siswa", EntityId: lama.Id})
+	if assert.Len(t, audits, 1) {
+		assert.Equal(t, "purge", audits[0].Action)
+	}
+	events := outboxEventRepository.FindPending(ctx, tx, time.Now(), 100)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, domain.EventSiswaPurged, events[0].EventType)
+		assert.Equal(t, lama.Id, events[0].AggregateId)
+	}
+}
+
+func TestFindSiswaHistoriesKeepsLatest(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+
+	ctx := withDefaultSekolah(context.Background())
+	tx, _ := db.Begin()
+	auditRepository := repository.NewAuditRepository()
+	for _, audit := range []domain.Audit{
+		{SekolahId: 1, Entity: "siswa", EntityId: 1, Action: "create"},
+		{SekolahId: 1, Entity: "siswa", EntityId: 2, Action: "create"},
+		{SekolahId: 1, Entity: "siswa", EntityId: 1, Action: "update"},
+		{SekolahId: 1, Entity: "siswa", EntityId: 1, Action: "delete"},
+	} {
+		audit.CreatedAt = time.Now()
+		auditRepository.Save(ctx, tx, audit)
+	}
+	tx.Commit()
+
+	histories := service.NewAuditService(auditRepository, db).FindSiswaHistories(ctx, []int{1, 2}, 2)
+	assert.Len(t, histories[1], 2)
+	assert.Equal(t, "update", histories[1][0].Action)
+	assert.Equal(t, "delete", histories[1][1].Action)
+	assert.Len(t, histories[2], 1)
+}
+
+func TestBatchSiswaAtomicRollback(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	router := setupRouter(db)
+
+	requestBody := strings.NewReader(`{"mode": "atomic", "operations": [
+		{"action": "create", "data": {"nama": "Gadget", "alamat": "Jakarta", "tanggal_lahir": "2010-01-01", "tempat_lahir": "Jakarta", "jenis_kelamin": "L", "agama": "Islam", "golongan_darah": "O", "no_telepon": "0812"}},
+		{"action": "delete", "id": 404, "version": 1}
+	]}`)
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/siswas/batch", requestBody)
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 422, response.StatusCode)
+
+	body, _ := io.ReadAll(response.Body)
+	var responseBody map[string]interface{}
+	json.Unmarshal(body, &responseBody)
+
+	results := responseBody["data"].(map[string]interface{})["results"].([]interface{})
+	assert.Equal(t, "ROLLED BACK", results[0].(map[string]interface{})["status"])
+	assert.Equal(t, "NOT FOUND", results[1].(map[string]interface{})["status"])
+
+	var count int
+	db.QueryRow("select count(*) from siswa").Scan(&count)
+	assert.Equal(t, 0, count)
+}
+
+func TestCreateSiswaIdempotent(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	router := setupRouter(db)
+
+	create := func(body string) *http.Response {
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/siswas", strings.NewReader(body))
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("X-API-Key", "RAHASIA")
+		request.Header.Add("Idempotency-Key", "create-gadget")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder.Result()
+	}
+
+	body := `{"nama": "Gadget", "alamat": "Jakarta", "tanggal_lahir": "2010-01-01", "tempat_lahir": "Jakarta", "jenis_kelamin": "L", "agama": "Islam", "golongan_darah": "O", "no_telepon": "0812"}`
+	first := create(body)
+	assert.Equal(t, 200, first.StatusCode)
+
+	second := create(body)
+	assert.Equal(t, 200, second.StatusCode)
+	assert.Equal(t, "true", second.Header.Get("Idempotent-Replayed"))
+
+	firstBody, _ := io.ReadAll(first.Body)
+	secondBody, _ := io.ReadAll(second.Body)
+	assert.Equal(t, string(firstBody), string(secondBody))
+
+	mismatch := create(strings.Replace(body, "Gadget", "Computer", 1))
+	assert.Equal(t, 422, mismatch.StatusCode)
+
+	var count int
+	db.QueryRow("select count(*) from siswa").Scan(&count)
+	assert.Equal(t, 1, count)
+}
+
+func TestCreateSiswaV2Created(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	router := setupRouter(db)
+
+	requestBody := strings.NewReader(`{"nama" : "Gadget", "alamat" : "Jl. Merdeka 1", "tanggal_lahir" : "2008-01-02", "tempat_lahir" : "Bandung", "jenis_kelamin" : "L", "agama" : "Islam", "golongan_darah" : "O", "no_telepon" : "08123456789"}`)
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/v2/siswas", requestBody)
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 201, response.StatusCode)
+	assert.Empty(t, response.Header.Get("Deprecation"))
+
+	body, _ := io.ReadAll(response.Body)
+	var responseBody map[string]interface{}
+	json.Unmarshal(body, &responseBody)
+
+	data := responseBody["data"].(map[string]interface{})
+	assert.Equal(t, "Gadget", data["nama"])
+	assert.Contains(t, data, "jenis_kelamin")
+	assert.Equal(t, "/api/v2/siswas/"+strconv.Itoa(int(data["id"].(float64))), response.Header.Get("Location"))
+}
+
+func TestGetSiswaV2NotFoundProblem(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v2/siswas/404", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 404, response.StatusCode)
+	assert.Equal(t, "application/problem+json", response.Header.Get("Content-Type"))
+
+	body, _ := io.ReadAll(response.Body)
+	var responseBody map[string]interface{}
+	json.Unmarshal(body, &responseBody)
+
+	assert.Equal(t, 404, int(responseBody["status"].(float64)))
+	assert.Equal(t, "Not Found", responseBody["title"])
+	assert.Equal(t, "/api/v2/siswas/404", responseBody["instance"])
+}
+
+func TestListSiswasV1Deprecated(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/v1/siswas", nil)
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, "true", response.Header.Get("Deprecation"))
+	assert.NotEmpty(t, response.Header.Get("Sunset"))
+}
+
 func TestListSiswasSuccess(t *testing.T) {
 	db := setupTestDB()
 	truncateSiswa(db)
 
 	tx, _ := db.Begin()
 	siswaRepository := repository.NewSiswaRepository()
-	siswa1 := siswaRepository.Save(context.Background(), tx, domain.Siswa{
+	siswa1 := siswaRepository.Save(withDefaultSekolah(context.Background()), tx, domain.Siswa{
 		Nama: "Gadget",
 	})
-	siswa2 := siswaRepository.Save(context.Background(), tx, domain.Siswa{
+	siswa2 := siswaRepository.Save(withDefaultSekolah(context.Background()), tx, domain.Siswa{
 		Nama: "Computer",
 	})
 
@@ -0,0 +1,124 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Arraf18/go-sisko/cache"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// countingSiswaService answers from memory and counts the reads reaching it.
+type countingSiswaService struct {
+	service.SiswaService
+	siswas map[int]web.SiswaResponse
+	reads  int
+}
+
+func (service *countingSiswaService) FindById(ctx context.Context, siswaId int) web.SiswaResponse {
+	service.reads++
+	return service.siswas[siswaId]
+}
+
+func (service *countingSiswaService) FindAll(ctx context.Context) []web.SiswaResponse {
+	service.reads++
+	var siswaResponses []web.SiswaResponse
+	for _, siswa := range service.siswas {
+		siswaResponses = append(siswaResponses, siswa)
+	}
+	return siswaResponses
+}
+
+func (service *countingSiswaService) Update(ctx context.Context, request web.SiswaUpdateRequest) web.SiswaResponse {
+	siswa := service.siswas[request.Id]
+	siswa.Nama = request.Nama
+	siswa.Version++
+	service.siswas[request.Id] = siswa
+	return siswa
+}
+
+// CreateTx adds the siswa at once, as if tx were already visible.
+func (service *countingSiswaService) CreateTx(ctx context.Context, tx *sql.Tx, onCommit *helper.OnCommit, request web.SiswaCreateRequest) web.SiswaResponse {
+	siswa := web.SiswaResponse{Id: len(service.siswas) + 1, Nama: request.Nama, Version: 1}
+	service.siswas[siswa.Id] = siswa
+	return siswa
+}
+
+func testSiswaServiceCache(t *testing.T, siswaCache cache.Cache) {
+	inner := &countingSiswaService{siswas: map[int]web.SiswaResponse{
+		1: {Id: 1, Nama: "Gadget", Version: 1},
+	}}
+	siswaService := service.NewSiswaServiceCache(inner, siswaCache, time.Minute)
+	ctx := withDefaultSekolah(context.Background())
+
+	assert.Equal(t, "Gadget", siswaService.FindById(ctx, 1).Nama)
+	siswaResponse := siswaService.FindById(ctx, 1)
+	assert.Equal(t, "Gadget", siswaResponse.Nama)
+	assert.Equal(t, 1, siswaResponse.Version)
+	assert.Len(t, siswaService.FindAll(ctx), 1)
+	assert.Len(t, siswaService.FindAll(ctx), 1)
+	assert.Equal(t, 2, inner.reads)
+
+	siswaService.Update(ctx, web.SiswaUpdateRequest{Id: 1, Nama: "Gadget Baru"})
+
+	siswaResponse = siswaService.FindById(ctx, 1)
+	assert.Equal(t, "Gadget Baru", siswaResponse.Nama)
+	assert.Equal(t, 2, siswaResponse.Version)
+	assert.Equal(t, "Gadget Baru", siswaService.FindAll(ctx)[0].Nama)
+	assert.Equal(t, 4, inner.reads)
+}
+
+func TestSiswaServiceCacheLRU(t *testing.T) {
+	testSiswaServiceCache(t, cache.NewLRUCache(100))
+}
+
+func TestSiswaServiceCacheRedis(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	testSiswaServiceCache(t, cache.NewRedisCache(client, "sisko:"))
+	assert.True(t, server.Exists("sisko:sekolah:1:siswa:1"))
+}
+
+func TestSiswaServiceCacheInvalidatesCreateTxOnCommit(t *testing.T) {
+	inner := &countingSiswaService{siswas: map[int]web.SiswaResponse{
+		1: {Id: 1, Nama: "Gadget", Version: 1},
+	}}
+	siswaService := service.NewSiswaServiceCache(inner, cache.NewLRUCache(100), time.Minute)
+	ctx := withDefaultSekolah(context.Background())
+
+	assert.Len(t, siswaService.FindAll(ctx), 1)
+
+	var onCommit helper.OnCommit
+	siswaService.CreateTx(ctx, nil, &onCommit, web.SiswaCreateRequest{Nama: "Gadget Baru"})
+
+	// the list stays cached until tx commits
+	assert.Len(t, siswaService.FindAll(ctx), 1)
+	assert.Equal(t, 1, inner.reads)
+
+	onCommit.Run()
+	assert.Len(t, siswaService.FindAll(ctx), 2)
+	assert.Equal(t, 2, inner.reads)
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	lruCache := cache.NewLRUCache(2)
+	ctx := context.Background()
+
+	lruCache.Set(ctx, "a", []byte("a"), time.Minute)
+	lruCache.Set(ctx, "b", []byte("b"), time.Minute)
+	lruCache.Get(ctx, "a")
+	lruCache.Set(ctx, "c", []byte("c"), time.Minute)
+
+	_, ok, _ := lruCache.Get(ctx, "a")
+	assert.True(t, ok)
+	_, ok, _ = lruCache.Get(ctx, "b")
+	assert.False(t, ok)
+}
@@ -0,0 +1,58 @@
+package test
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTracingMiddlewareContinuesTrace(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	// the default provider delegates to the first one set for good, so shut
+	// ours down as well to stop it recording spans of other tests
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+		tracerProvider.Shutdown(context.Background())
+	})
+
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		helper.RouteFromContext(request.Context()).Pattern = "/api/siswas/:siswaId"
+		_, end := helper.StartSpan(request.Context(), "SiswaService.FindById")
+		end()
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:3000/api/siswas/1?nama=Gadget", nil)
+	request.Header.Add("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	middleware.NewTracingMiddleware(handler).ServeHTTP(httptest.NewRecorder(), request)
+
+	spans := spanRecorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "SiswaService.FindById", spans[0].Name())
+	assert.Equal(t, "GET /api/siswas/:siswaId", spans[1].Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[1].SpanContext().TraceID().String())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+
+	// query parameters such as filters stay out of the span
+	target := ""
+	for _, attribute := range spans[1].Attributes() {
+		if attribute.Key == semconv.HTTPTargetKey {
+			target = attribute.Value.AsString()
+		}
+	}
+	assert.Equal(t, "/api/siswas/1", target)
+}
@@ -0,0 +1,26 @@
+package test
+
+import (
+	"context"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUserServiceAuthenticateBootstrapKey(t *testing.T) {
+	userService := service.NewUserService(nil, nil, nil, nil, nil, "RAHASIA")
+
+	username, err := userService.Authenticate(context.Background(), "RAHASIA")
+	assert.Nil(t, err)
+	assert.Equal(t, "admin", username)
+}
+
+func TestUserServiceAuthenticateMalformedKey(t *testing.T) {
+	// a key that could not have been issued is rejected before the database
+	userService := service.NewUserService(nil, nil, nil, nil, nil, "")
+
+	for _, key := range []string{"", "RAHASIA", "zz" + string(make([]byte, 62))} {
+		_, err := userService.Authenticate(context.Background(), key)
+		assert.ErrorIs(t, err, service.ErrInvalidApiKey)
+	}
+}
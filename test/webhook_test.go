@@ -0,0 +1,188 @@
+package test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/Arraf18/go-sisko/app"
+	"github.com/Arraf18/go-sisko/helper"
+	"github.com/Arraf18/go-sisko/model/domain"
+	"github.com/Arraf18/go-sisko/model/web"
+	"github.com/Arraf18/go-sisko/outbox"
+	"github.com/Arraf18/go-sisko/repository"
+	"github.com/Arraf18/go-sisko/service"
+	"github.com/go-playground/validator"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignWebhook(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	mac := hmac.New(sha256.New, []byte("rahasia-webhook-1"))
+	mac.Write([]byte("1700000000." + string(body)))
+
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), helper.SignWebhook("rahasia-webhook-1", 1700000000, body))
+	assert.NotEqual(t, helper.SignWebhook("rahasia-webhook-1", 1700000000, body), helper.SignWebhook("rahasia-webhook-1", 1700000001, body))
+}
+
+func TestWebhookCreateValidation(t *testing.T) {
+	db := setupTestDB()
+	router := setupRouter(db)
+
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:3000/api/v2/webhooks", strings.NewReader(`{"url" : "not a url", "event_types" : ["SiswaEaten"]}`))
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("X-API-Key", "RAHASIA")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 400, recorder.Code)
+}
+
+func TestWebhookDeliveryDisableAndReplay(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	router := setupRouter(db)
+	ctx := withDefaultSekolah(context.Background())
+
+	var received []*http.Request
+	var bodies [][]byte
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		received = append(received, request)
+		bodies = append(bodies, body)
+		writer.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	call := func(method string, path string, body string) (int, map[string]interface{}) {
+		request := httptest.NewRequest(method, "http://localhost:3000"+path, strings.NewReader(body))
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("X-API-Key", "RAHASIA")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		var responseBody map[string]interface{}
+		json.Unmarshal(recorder.Body.Bytes(), &responseBody)
+		return recorder.Code, responseBody
+	}
+
+	code, responseBody := call(http.MethodPost, "/api/v2/webhooks", `{"url" : "`+server.URL+`", "event_types" : ["SiswaCreated", "SiswaUpdated"], "secret" : "rahasia-webhook-1"}`)
+	if !assert.Equal(t, 201, code) {
+		return
+	}
+	webhookPath := "/api/v2/webhooks/" + strconv.Itoa(int(responseBody["data"].(map[string]interface{})["id"].(float64)))
+
+	validate := validator.New()
+	siswaService := service.NewSiswaService(repository.NewSiswaRepository(), repository.NewAuditRepository(), repository.NewOutboxEventRepository(), db, validate)
+	webhookService := service.NewWebhookService(repository.NewWebhookSubscriptionRepository(), repository.NewWebhookDeliveryRepository(), db, validate, server.Client(), 1)
+	bus := outbox.NewBus()
+	bus.Subscribe(app.NewWebhookHandler(webhookService))
+	relay := outbox.NewRelay(db, repository.NewOutboxEventRepository(), []outbox.Sink{bus}, time.Hour, 10)
+
+	created := siswaService.Create(ctx, web.SiswaCreateRequest{
+		Nama: "Gadget", Alamat: "Jakarta", TanggalLahir: "2010-01-01", TempatLahir: "Jakarta",
+		JenisKelamin: "L", Agama: "Islam", GolonganDarah: "O", NoTelepon: "0812",
+	})
+	relay.RelayOnce(ctx)
+	assert.Equal(t, 1, webhookService.DeliverPending(ctx))
+
+	if !assert.Len(t, received, 1) {
+		return
+	}
+	assert.Equal(t, "SiswaCreated", received[0].Header.Get("X-Sisko-Event"))
+	timestamp, _ := strconv.ParseInt(received[0].Header.Get("X-Sisko-Timestamp"), 10, 64)
+	assert.Equal(t, helper.SignWebhook("rahasia-webhook-1", timestamp, bodies[0]), received[0].Header.Get("X-Sisko-Signature"))
+
+	// the endpoint breaks and, with DisableAfter 1, the subscription is disabled
+	status = http.StatusInternalServerError
+	siswaService.Update(ctx, web.SiswaUpdateRequest{
+		Id: created.Id, Version: created.Version, Nama: "Gadget", Alamat: "Bandung", TanggalLahir: "2010-01-01",
+		TempatLahir: "Jakarta", JenisKelamin: "L", Agama: "Islam", GolonganDarah: "O", NoTelepon: "0812",
+	})
+	relay.RelayOnce(ctx)
+	assert.Equal(t, 0, webhookService.DeliverPending(ctx))
+
+	_, responseBody = call(http.MethodGet, webhookPath, "")
+	assert.Equal(t, false, responseBody["data"].(map[string]interface{})["active"])
+
+	_, responseBody = call(http.MethodGet, webhookPath+"/deliveries", "")
+	deliveries := responseBody["data"].([]interface{})
+	assert.Len(t, deliveries, 2)
+	latest := deliveries[0].(map[string]interface{})
+	assert.Equal(t, "SiswaUpdated", latest["event_type"])
+	assert.Equal(t, float64(500), latest["response_status"])
+	deliveryPath := webhookPath + "/deliveries/" + strconv.Itoa(int(latest["id"].(float64)))
+
+	code, _ = call(http.MethodPost, deliveryPath+"/replay", "")
+	assert.Equal(t, 409, code)
+
+	status = http.StatusOK
+	code, _ = call(http.MethodPut, webhookPath, `{"url" : "`+server.URL+`", "event_types" : ["SiswaCreated", "SiswaUpdated"], "active" : true}`)
+	assert.Equal(t, 200, code)
+	code, _ = call(http.MethodPost, deliveryPath+"/replay", "")
+	assert.Equal(t, 202, code)
+
+	assert.Equal(t, 1, webhookService.DeliverPending(ctx))
+	assert.Equal(t, "SiswaUpdated", received[len(received)-1].Header.Get("X-Sisko-Event"))
+}
+
+func TestWebhookDeliverySkipsWaitingDeliveries(t *testing.T) {
+	db := setupTestDB()
+	truncateSiswa(db)
+	ctx := context.Background()
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		received = append(received, request.Header.Get("X-Sisko-Delivery-Id"))
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	subscriptionRepository := repository.NewWebhookSubscriptionRepository()
+	deliveryRepository := repository.NewWebhookDeliveryRepository()
+	webhookService := service.NewWebhookService(subscriptionRepository, deliveryRepository, db, validator.New(), server.Client(), 1000)
+
+	tx, err := db.Begin()
+	helper.PanicIfError(err)
+	now := time.Now()
+	subscription := func() int {
+		return subscriptionRepository.Save(ctx, tx, domain.WebhookSubscription{
+			Url: server.URL, EventTypes: []string{"SiswaCreated"}, Secret: "rahasia-webhook-1", Active: true, CreatedAt: now, UpdatedAt: now,
+		}).Id
+	}
+	deliver := func(subscriptionId int, eventId int64, aggregateId int, nextAttemptAt *time.Time) int64 {
+		delivery, _ := deliveryRepository.Save(ctx, tx, domain.WebhookDelivery{
+			SubscriptionId: subscriptionId, EventId: eventId, EventType: "SiswaCreated", AggregateId: aggregateId,
+			Payload: []byte(`{}`), Status: domain.DeliveryPending, CreatedAt: now,
+		})
+		if nextAttemptAt != nil {
+			delivery.Attempts = 1
+			delivery.NextAttemptAt = nextAttemptAt
+			deliveryRepository.Update(ctx, tx, delivery)
+		}
+		return delivery.Id
+	}
+
+	// one subscription has more deliveries backing off than fit in a batch
+	backingOff := subscription()
+	later := now.Add(time.Hour)
+	for i := 1; i <= 150; i++ {
+		deliver(backingOff, int64(i), i, &later)
+	}
+	// a due delivery of siswa 1 is held back by the one waiting before it
+	deliver(backingOff, 151, 1, nil)
+	due := deliver(subscription(), 1, 1, nil)
+	tx.Commit()
+
+	assert.Equal(t, 1, webhookService.DeliverPending(ctx))
+	assert.Equal(t, []string{strconv.FormatInt(due, 10)}, received)
+}